@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"context"
+)
+
+type contextKey int
+
+const (
+	requestKey contextKey = iota
+)
+
+// RequestInfo -> Where a request comes from, recorded by every audit entry of the mutations it makes
+type RequestInfo struct {
+	RequestID string
+	ClientIP  string
+}
+
+// WithRequestInfo -> Return a copy of ctx holding given request information
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestKey, info)
+}
+
+// RequestInfoFromContext -> Retrieve request information from ctx, ok is false when RequestContext did not run
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestKey).(RequestInfo)
+	return info, ok
+}
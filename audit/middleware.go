@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// RequestIDHeader -> Header carrying the request ID, read from clients and proxies, and sent back in every response
+const RequestIDHeader = "X-Request-Id"
+
+// validRequestID -> Request IDs given by clients are kept when short and made of safe characters only
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestContext -> Middleware identifying every request (see RequestInfoFromContext): its ID, taken from header X-Request-Id
+// when valid and generated otherwise, and its client IP. X-Forwarded-For is only trusted when the application runs behind a proxy
+func RequestContext(trustProxy bool) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID.MatchString(requestID) {
+				requestID = bson.NewObjectId().Hex()
+			}
+			w.Header().Set(RequestIDHeader, requestID)
+
+			info := RequestInfo{RequestID: requestID, ClientIP: clientIP(r, trustProxy)}
+			next.ServeHTTP(w, r.WithContext(WithRequestInfo(r.Context(), info)))
+		})
+	}
+}
+
+// clientIP -> Address of the client sending given request, the first address of X-Forwarded-For when proxies are trusted
+func clientIP(r *http.Request, trustProxy bool) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); trustProxy && forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
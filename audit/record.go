@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Record -> Append a mutation made by given request to the audit log, along with the principal and request information.
+// Snapshots are nil for creations/deletions, boardID is empty for entities outside of boards.
+// Failures are logged, they never fail the request which made the mutation: it is already stored, clients would retry it
+func Record(r *http.Request, s dao.Store, boardID bson.ObjectId, action, entityType string, entityID bson.ObjectId, before, after models.Snapshot, handlerLogger *log.Entry) {
+	entry := models.NewAuditEntry(action, entityType, boardID, entityID)
+	entry.SetSnapshots(before, after)
+
+	principal, _ := auth.PrincipalFromContext(r.Context())
+	entry.ActorId = principal.User.UserId
+	if principal.ApiKey != nil {
+		entry.ApiKeyId = principal.ApiKey.ApiKeyId
+	}
+	info, _ := RequestInfoFromContext(r.Context())
+	entry.RequestId, entry.ClientIP = info.RequestID, info.ClientIP
+
+	if err := s.Audit.Insert(&entry); err != nil {
+		handlerLogger.Errorf("Could not record audit entry for %s %s %s (request %s), got error: %s", action, entityType, entityID.Hex(), info.RequestID, err.Error())
+	}
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var (
+	// ErrNoCredentials is returned by an Authenticator when the request carries no credentials it handles
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials is returned by an Authenticator when the request carries credentials it rejects
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// apiKeyTouchInterval -> Last-used timestamps of API keys are only refreshed once in this interval, sparing a write per request
+const apiKeyTouchInterval = time.Minute
+
+// Authenticator -> Resolves the Principal sending a request from the credentials it carries (bearer token, API key, ...)
+type Authenticator interface {
+	// Authenticate -> Return request's principal, ErrNoCredentials when the request carries none of the handled kind,
+	// ErrInvalidCredentials when they are rejected, any other error when they could not be checked
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+// BearerAuthenticator -> Authenticates requests with header "Authorization: Bearer <token>", token being issued by Tokens
+type BearerAuthenticator struct {
+	Tokens *Tokens
+	Users  dao.UserStore
+}
+
+// authorizationCredentials -> Extract credentials from header "Authorization: <scheme> <credentials>", empty if absent or of another scheme
+func authorizationCredentials(r *http.Request, scheme string) string {
+	header := r.Header.Get("Authorization")
+	if len(header) <= len(scheme) || !strings.EqualFold(header[:len(scheme)+1], scheme+" ") {
+		return ""
+	}
+	return strings.TrimSpace(header[len(scheme)+1:])
+}
+
+// bearerToken -> Extract token from header "Authorization: Bearer <token>", empty if absent
+func bearerToken(r *http.Request) string {
+	return authorizationCredentials(r, "Bearer")
+}
+
+// Authenticate -> Verify bearer token and retrieve the user it was issued for
+func (b BearerAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return Principal{}, ErrNoCredentials
+	}
+
+	userID, err := b.Tokens.Verify(token)
+	if err != nil {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	// Tokens of deleted users are no longer valid
+	user, err := findUser(b.Users, userID)
+	return Principal{User: user}, err
+}
+
+// ApiKeyAuthenticator -> Authenticates requests with header "Authorization: ApiKey <key>", key being one of Keys
+type ApiKeyAuthenticator struct {
+	Keys  dao.ApiKeyStore
+	Users dao.UserStore
+}
+
+// Authenticate -> Check that the API key exists and is not expired, retrieve its user and record the key's use
+func (a ApiKeyAuthenticator) Authenticate(r *http.Request) (Principal, error) {
+	key := authorizationCredentials(r, "ApiKey")
+	if key == "" {
+		return Principal{}, ErrNoCredentials
+	}
+
+	apiKey, err := a.Keys.FindByHash(models.HashApiKey(key))
+	if err == dao.ErrNotFound {
+		return Principal{}, ErrInvalidCredentials
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	if apiKey.IsExpired(now) {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	user, err := findUser(a.Users, apiKey.UserId)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchInterval {
+		if err := a.Keys.Touch(apiKey.ApiKeyId, now); err != nil {
+			return Principal{}, err
+		}
+		apiKey.LastUsedAt = &now
+	}
+	return Principal{User: user, ApiKey: &apiKey}, nil
+}
+
+// findUser -> Retrieve the user credentials were issued for, credentials of deleted users are rejected
+func findUser(users dao.UserStore, userID bson.ObjectId) (models.User, error) {
+	user, err := users.FindByID(userID)
+	if err == dao.ErrNotFound {
+		return user, ErrInvalidCredentials
+	}
+	return user, err
+}
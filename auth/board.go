@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// RequireBoardMember -> Middleware restricting routes with a boardId parameter to members of this board,
+// other users get a 404 as if the board did not exist. Membership is stored in request context (see MemberFromContext).
+// Must run after RequireUser
+func RequireBoardMember(members dao.MemberStore) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := mux.Vars(r)["boardId"]; !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			handlerLogger := logger.GenerateLogger(constants.HandlerAuthLogger, r.URL.Path, r.Method)
+			boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+			if !ok {
+				return
+			}
+
+			user, _ := UserFromContext(r.Context())
+			member, err := members.FindByBoardAndUser(boardID, user.UserId)
+			if err != nil && err != dao.ErrNotFound {
+				handlerLogger.Errorf("Could not retrieve membership on board %s, got error: %s", boardID.Hex(), err.Error())
+				helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+				return
+			}
+			if err != nil {
+				handlerLogger.Warnf("User %s is not a member of board %s", user.UserId.Hex(), boardID.Hex())
+				helpers.RespondWithError(w, http.StatusNotFound, "Board not found")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithMember(r.Context(), member)))
+		})
+	}
+}
+
+// Authorize -> Check that the current user's role on the board grants at least given role, respond 403 otherwise
+func Authorize(w http.ResponseWriter, r *http.Request, role string, handlerLogger *log.Entry) bool {
+	member, ok := MemberFromContext(r.Context())
+	if !ok || !member.HasRole(role) {
+		handlerLogger.Warnf("Forbidden, role %s required on board", role)
+		helpers.RespondWithError(w, http.StatusForbidden, "Insufficient role on this board, "+role+" required")
+		return false
+	}
+	return true
+}
+
+// FindBoard -> Retrieve Board from route parameter boardId, respond with an error if it does not exist
+func FindBoard(w http.ResponseWriter, r *http.Request, boards dao.BoardStore, handlerLogger *log.Entry) (models.Board, bool) {
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return models.Board{}, false
+	}
+
+	board, err := boards.FindByID(boardID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return board, false
+	}
+	if err != nil {
+		handlerLogger.Warnf("Board not found with id: %s", boardID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Board not found")
+		return board, false
+	}
+	return board, true
+}
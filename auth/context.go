@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+type contextKey int
+
+const (
+	principalKey contextKey = iota
+	memberKey
+)
+
+// WithPrincipal -> Return a copy of ctx holding given authenticated principal
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey, principal)
+}
+
+// PrincipalFromContext -> Retrieve authenticated principal from ctx, ok is false for anonymous requests
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(principalKey).(Principal)
+	return principal, ok
+}
+
+// WithUser -> Return a copy of ctx holding given authenticated user
+func WithUser(ctx context.Context, user models.User) context.Context {
+	return WithPrincipal(ctx, Principal{User: user})
+}
+
+// UserFromContext -> Retrieve authenticated user from ctx, ok is false for anonymous requests
+func UserFromContext(ctx context.Context) (models.User, bool) {
+	principal, ok := PrincipalFromContext(ctx)
+	return principal.User, ok
+}
+
+// WithMember -> Return a copy of ctx holding authenticated user's membership on the requested board
+func WithMember(ctx context.Context, member models.BoardMember) context.Context {
+	return context.WithValue(ctx, memberKey, member)
+}
+
+// MemberFromContext -> Retrieve authenticated user's membership on the requested board from ctx
+func MemberFromContext(ctx context.Context) (models.BoardMember, bool) {
+	member, ok := ctx.Value(memberKey).(models.BoardMember)
+	return member, ok
+}
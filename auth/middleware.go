@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/gorilla/mux"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// unauthorized -> Respond 401, telling clients which authentication scheme is expected
+func unauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="taco"`)
+	helpers.RespondWithError(w, http.StatusUnauthorized, message)
+}
+
+// Authenticate -> Middleware resolving request's principal with the first authenticator recognizing its credentials,
+// the principal is stored in request context (see PrincipalFromContext). Requests without credentials go through anonymously,
+// requests with rejected credentials get a 401
+func Authenticate(authenticators ...Authenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handlerLogger := logger.GenerateLogger(constants.HandlerAuthLogger, r.URL.Path, r.Method)
+
+			for _, authenticator := range authenticators {
+				principal, err := authenticator.Authenticate(r)
+				if err == ErrNoCredentials {
+					continue
+				}
+				if err == ErrInvalidCredentials {
+					handlerLogger.Warn("Rejected request credentials")
+					unauthorized(w, "Invalid or expired credentials")
+					return
+				}
+				if err != nil {
+					handlerLogger.Errorf("Could not check request credentials, got error: %s", err.Error())
+					helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+					return
+				}
+
+				next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireUser -> Middleware rejecting anonymous requests (401), must run after Authenticate
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := UserFromContext(r.Context()); !ok {
+			logger.GenerateLogger(constants.HandlerAuthLogger, r.URL.Path, r.Method).Warn("Anonymous request to a protected endpoint")
+			unauthorized(w, "Missing credentials")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// EnforceScope -> Middleware rejecting (403) unsafe requests (POST, PATCH, DELETE, ...) of read-only principals, except
+// to given paths (with or without trailing slash) which only run requests checked on their own, e.g. batches.
+// Must run after Authenticate
+func EnforceScope(exemptPaths ...string) mux.MiddlewareFunc {
+	exempt := make(map[string]bool, len(exemptPaths))
+	for _, exemptPath := range exemptPaths {
+		exempt[strings.TrimSuffix(exemptPath, "/")] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFromContext(r.Context())
+			if ok && principal.ReadOnly() && !isSafeMethod(r.Method) && !exempt[strings.TrimSuffix(r.URL.Path, "/")] {
+				logger.GenerateLogger(constants.HandlerAuthLogger, r.URL.Path, r.Method).Warnf("Read-only API key %s sent an unsafe request", principal.ApiKey.ApiKeyId.Hex())
+				helpers.RespondWithError(w, http.StatusForbidden, "API key is read-only")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession -> Middleware restricting routes to users authenticated with a session token rather than an API key (403),
+// must run after RequireUser
+func RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if principal, _ := PrincipalFromContext(r.Context()); principal.ApiKey != nil {
+			logger.GenerateLogger(constants.HandlerAuthLogger, r.URL.Path, r.Method).Warnf("API key %s used on a session-only endpoint", principal.ApiKey.ApiKeyId.Hex())
+			helpers.RespondWithError(w, http.StatusForbidden, "This endpoint can not be used with an API key")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireAdmin -> Middleware restricting routes to administrators, users whose ID is among given ones (403),
+// must run after RequireUser
+func RequireAdmin(userIDs []bson.ObjectId) mux.MiddlewareFunc {
+	admins := make(map[bson.ObjectId]bool, len(userIDs))
+	for _, userID := range userIDs {
+		admins[userID] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, _ := UserFromContext(r.Context()); !admins[user.UserId] {
+				logger.GenerateLogger(constants.HandlerAuthLogger, r.URL.Path, r.Method).Warnf("User %s is not an administrator", user.UserId.Hex())
+				helpers.RespondWithError(w, http.StatusForbidden, "This endpoint is restricted to administrators")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// Principal -> Who is sending a request: a User, and the API key used when authenticated with one
+type Principal struct {
+	User   models.User
+	ApiKey *models.ApiKey
+}
+
+// ReadOnly -> Whether the principal may only send safe requests (read scoped API key)
+func (p Principal) ReadOnly() bool {
+	return p.ApiKey != nil && p.ApiKey.Scope == models.ScopeRead
+}
+
+// isSafeMethod -> Whether an HTTP method only reads resources
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
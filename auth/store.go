@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/dao"
+)
+
+// StoreFor -> Store recording the user sending given request as the author of the changes made through it
+func StoreFor(r *http.Request, s dao.Store) dao.Store {
+	user, _ := UserFromContext(r.Context())
+	return s.As(user.UserId)
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ErrInvalidToken is returned when a token is malformed, expired, or not signed by the API
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// Tokens -> Issues and verifies signed JWTs (HS256) identifying users, the subject claim holds the user ID
+type Tokens struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewTokens -> Create a token issuer signing with given secret, issued tokens expire after ttl
+func NewTokens(secret []byte, ttl time.Duration) *Tokens {
+	return &Tokens{secret: secret, ttl: ttl}
+}
+
+// Issue -> Sign a token for given user, return it along with its expiration date
+func (t *Tokens) Issue(userID bson.ObjectId) (string, time.Time, error) {
+	now := time.Now().UTC()
+	expiresAt := now.Add(t.ttl).Truncate(time.Second)
+	claims := jwt.StandardClaims{
+		Subject:   userID.Hex(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.secret)
+	return token, expiresAt, err
+}
+
+// Verify -> Check token's signature and expiration, return the ID of the user it was issued for
+func (t *Tokens) Verify(token string) (bson.ObjectId, error) {
+	claims := jwt.StandardClaims{}
+	_, err := jwt.ParseWithClaims(token, &claims, func(parsed *jwt.Token) (interface{}, error) {
+		// Only accept the algorithm tokens are issued with, prevents algorithm substitution
+		if parsed.Method != jwt.SigningMethodHS256 {
+			return nil, ErrInvalidToken
+		}
+		return t.secret, nil
+	})
+	if err != nil || !bson.IsObjectIdHex(claims.Subject) {
+		return "", ErrInvalidToken
+	}
+	return bson.ObjectIdHex(claims.Subject), nil
+}
@@ -6,19 +6,30 @@ import (
 	"net/http"
 	"github.com/gorilla/handlers"
 	"os"
-	"github.com/AmFlint/taco-api-go/config/database"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/dao"
 )
 
 type App struct {
 	Router    *mux.Router
+	// Persistence layer, injected into every handler
+	Store     dao.Store
+	// Storage configuration the application was started with
+	Storage   StorageConfig
+	// Issues and verifies bearer tokens of authenticated users
+	Tokens    *auth.Tokens
+	// Authentication configuration the application was started with
+	Auth      AuthConfig
+	// Release resources held by Store (e.g. Database session)
+	closeStore func()
 }
 
 var a App
 
-// Create a new App structure from given configuration (Database Connection) + Initialize Routing
-func NewApp(user, password, dbName, dbHost, dbPort string) App {
+// Create a new App structure from given configuration (Storage backend, Authentication) + Initialize Routing
+func NewApp(storage StorageConfig, authConfig AuthConfig) App {
 	a = App{}
-	a.Initialize(user, password, dbName, dbHost, dbPort)
+	a.Initialize(storage, authConfig)
 	return a
 }
 
@@ -27,10 +38,14 @@ func GetApp() App {
 	return a
 }
 
-// Initialize Application Structure: Configure Database Connection, save dbName for later uses and create Router
-func (a *App) Initialize(user, password, dbName, dbHost, dbPort string) {
-
-	database.SetDBSession(user, password, dbName, dbHost, dbPort)
+// Initialize Application Structure: Open Storage backend, save its configuration for later uses, configure tokens and create Router
+func (a *App) Initialize(storage StorageConfig, authConfig AuthConfig) {
+	store, closeStore, err := openStore(storage)
+	if err != nil {
+		log.Fatal(err)
+	}
+	a.Store, a.Storage, a.closeStore = store, storage, closeStore
+	a.Tokens, a.Auth = newTokens(authConfig), authConfig
 
 	// Initialize Mux Router and assign it to application Structure
 	a.Router = mux.NewRouter()
@@ -41,8 +56,8 @@ func (a *App) Initialize(user, password, dbName, dbHost, dbPort string) {
 
 // Parameter addr of form ":8080", represents the port where the application will be served
 func (a *App) Run(addr string) {
-	// Close Database connection at the end of Application Runtime
-	defer database.CloseSession()
+	// Close Storage (e.g. Database connection) at the end of Application Runtime
+	defer a.Close()
 
 	log.Printf("Server listening on port%s", addr)
 	// Listen on port defined in addr parameter, and serve Application via Mux Router
@@ -50,4 +65,20 @@ func (a *App) Run(addr string) {
 	if err := http.ListenAndServe(addr, handlers.LoggingHandler( os.Stdout, a.Router)); err != nil {
 		log.Fatal(err)
 	}
-}
\ No newline at end of file
+}
+
+// Close -> Release resources held by the Storage backend
+func (a *App) Close() {
+	if a.closeStore != nil {
+		a.closeStore()
+	}
+}
+
+// Migrate -> Apply pending storage migrations (schema changes, backfills), logging every applied version
+func (a *App) Migrate() error {
+	applied, err := a.Store.Migrator.Migrate()
+	for _, migration := range applied {
+		log.Printf("Applied migration %d: %s", migration.Version, migration.Description)
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"crypto/rand"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AuthConfig -> How bearer tokens (JWT) are signed and how long they remain valid, who administrates the application
+// and how clients are identified
+type AuthConfig struct {
+	// Secret signing tokens, a random one is generated when empty (tokens then do not survive restarts)
+	JWTSecret string
+	TokenTTL  time.Duration
+	// IDs of users allowed on administration endpoints (e.g. audit log), emails are not verified so they do not identify admins
+	AdminIDs []bson.ObjectId
+	// Whether X-Forwarded-For, set by a reverse proxy, identifies the client IP of requests
+	TrustProxy bool
+}
+
+// AuthConfigFromEnv -> Read authentication configuration from Environment Variables (APP_JWT_SECRET, APP_JWT_TTL e.g. "24h",
+// APP_ADMIN_USER_IDS comma separated, APP_TRUST_PROXY)
+func AuthConfigFromEnv() AuthConfig {
+	ttl, err := time.ParseDuration(helpers.GetEnv("APP_JWT_TTL", "24h"))
+	if err != nil {
+		log.Fatalf("Invalid APP_JWT_TTL, got error: %s", err.Error())
+	}
+
+	adminIDs := []bson.ObjectId{}
+	for _, id := range strings.Split(helpers.GetEnv("APP_ADMIN_USER_IDS", ""), ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if !bson.IsObjectIdHex(id) {
+			log.Fatalf("Invalid APP_ADMIN_USER_IDS, %q is not a user ID", id)
+		}
+		adminIDs = append(adminIDs, bson.ObjectIdHex(id))
+	}
+
+	return AuthConfig{
+		JWTSecret:  helpers.GetEnv("APP_JWT_SECRET", ""),
+		TokenTTL:   ttl,
+		AdminIDs:   adminIDs,
+		TrustProxy: helpers.GetEnv("APP_TRUST_PROXY", "false") == "true",
+	}
+}
+
+// newTokens -> Create the token issuer described by configuration
+func newTokens(c AuthConfig) *auth.Tokens {
+	secret := []byte(c.JWTSecret)
+	if len(secret) == 0 {
+		log.Print("APP_JWT_SECRET is not set, signing tokens with a random secret: tokens will not survive restarts")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal(err)
+		}
+	}
+	return auth.NewTokens(secret, c.TokenTTL)
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"database/sql"
+	"log"
+	"strings"
+)
+
+// SQL connection pool, used instead of the Mongo session by the SQL storage backend
+var sqlDB *sql.DB
+
+// Open SQL connection pool with given database/sql driver (sqlite3 | postgres) and data source name
+func SetSQLConnection(driver, dsn string) {
+	if driver == "sqlite3" {
+		// SQLite only enforces foreign keys (cascading deletions) when asked to, on every connection
+		dsn += sqliteParamSeparator(dsn) + "_foreign_keys=1"
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		panic(err)
+	}
+
+	if driver == "sqlite3" {
+		// SQLite handles a single writer at a time (and each connection to ":memory:" gets its own database)
+		db.SetMaxOpenConns(1)
+	}
+
+	if err := db.Ping(); err != nil {
+		panic(err)
+	}
+	sqlDB = db
+
+	log.Print("Connection to SQL Database established with success !")
+}
+
+// Return SQL connection pool
+func GetSQLConnection() *sql.DB {
+	return sqlDB
+}
+
+// Close SQL connection pool
+func CloseSQLConnection() {
+	sqlDB.Close()
+}
+
+func sqliteParamSeparator(dsn string) string {
+	if strings.Contains(dsn, "?") {
+		return "&"
+	}
+	return "?"
+}
@@ -1,24 +1,122 @@
 package config
 
 import (
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
 	"github.com/AmFlint/taco-api-go/routes"
+	"github.com/AmFlint/taco-api-go/routes/activity"
+	"github.com/AmFlint/taco-api-go/routes/admin"
+	"github.com/AmFlint/taco-api-go/routes/apikeys"
+	"github.com/AmFlint/taco-api-go/routes/batch"
+	"github.com/AmFlint/taco-api-go/routes/boards"
+	"github.com/AmFlint/taco-api-go/routes/checklists"
+	"github.com/AmFlint/taco-api-go/routes/comments"
+	"github.com/AmFlint/taco-api-go/routes/filters"
+	"github.com/AmFlint/taco-api-go/routes/labels"
 	"github.com/AmFlint/taco-api-go/routes/tasks"
 	"github.com/AmFlint/taco-api-go/routes/lists"
+	"github.com/AmFlint/taco-api-go/routes/members"
+	"github.com/AmFlint/taco-api-go/routes/search"
+	"github.com/AmFlint/taco-api-go/routes/users"
 )
 
 // Function in charge of setting up Application Routes
 func (a *App) initializeRoutes() {
+	// Run requests alongside each other, but all-or-nothing batches alone: nothing else may use the store during their transaction
+	a.Router.Use(batch.Gate)
+	// Identify each request (request ID, client IP) for the audit log
+	a.Router.Use(audit.RequestContext(a.Auth.TrustProxy))
+	// Resolve the user sending each request from its credentials (session token or API key), if any
+	a.Router.Use(auth.Authenticate(
+		auth.BearerAuthenticator{Tokens: a.Tokens, Users: a.Store.Users},
+		auth.ApiKeyAuthenticator{Keys: a.Store.ApiKeys, Users: a.Store.Users},
+	))
+	// Read-only API keys may not modify anything, requests of a batch go through this router and are checked on their own
+	a.Router.Use(auth.EnforceScope(batch.BatchPath))
+
 	// ---- General Endpoints ---- //
 
 	// Health Endpoint, check whether service is up or down
 	a.Router.HandleFunc("/health", routes.HealthIndexHandler).Methods("GET")
 	a.Router.HandleFunc("/health/", routes.HealthIndexHandler).Methods("GET")
 
+	// ---- Authentication Endpoints (public) ---- //
+	authRouter := a.Router.PathPrefix("/auth").Subrouter()
+	users.InitRoutes(authRouter, a.Store, a.Tokens)
+
+	// ---- Current User Endpoints ---- //
+	meRouter := a.Router.PathPrefix("/me").Subrouter()
+	meRouter.Use(auth.RequireUser)
+
+	// API keys are managed with a session only, a leaked key can not be used to create other keys
+	apiKeyRouter := meRouter.PathPrefix("/api-keys").Subrouter()
+	apiKeyRouter.Use(auth.RequireSession)
+	apikeys.InitRoutes(apiKeyRouter, a.Store)
+
+	// Tasks assigned to the current user, across boards
+	tasks.InitUserRoutes(meRouter, a.Store)
+
+	// ---- Search Endpoint, across boards the current user is a member of ---- //
+	searchRouter := a.Router.PathPrefix("/search").Subrouter()
+	searchRouter.Use(auth.RequireUser)
+	search.InitRoutes(searchRouter, a.Store)
+
+	// ---- Batch Endpoint, runs many requests of the current user through this router at once ---- //
+	batchRouter := a.Router.PathPrefix("/batch").Subrouter()
+	batchRouter.Use(auth.RequireUser)
+	batch.InitRoutes(batchRouter, a.Router, a.Store)
+
+	// ---- Administration Endpoints (APP_ADMIN_USER_IDS), with a session only ---- //
+	adminRouter := a.Router.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(auth.RequireUser)
+	adminRouter.Use(auth.RequireSession)
+	adminRouter.Use(auth.RequireAdmin(a.Auth.AdminIDs))
+	admin.InitRoutes(adminRouter, a.Store)
+
+	// ---- Board Management Endpoints ---- //
+	// Boards require an authenticated user, every resource nested in a board is restricted to its members
+	boardRouter := a.Router.PathPrefix("/boards").Subrouter()
+	boardRouter.Use(auth.RequireUser)
+	boardRouter.Use(auth.RequireBoardMember(a.Store.Members))
+	boards.InitRoutes(boardRouter, a.Store)
+
+	// ---- Board Membership Endpoints ---- //
+	memberRouter := boardRouter.PathPrefix("/{boardId}/members").Subrouter()
+	members.InitRoutes(memberRouter, a.Store)
+
+	// ---- Board Labels Endpoints ---- //
+	labelRouter := boardRouter.PathPrefix("/{boardId}/labels").Subrouter()
+	labels.InitRoutes(labelRouter, a.Store)
+
+	// ---- Board saved task Filters Endpoints ---- //
+	filterRouter := boardRouter.PathPrefix("/{boardId}/filters").Subrouter()
+	filters.InitRoutes(filterRouter, a.Store)
+
+	// ---- Board Tasks Endpoints (filters, due dates and bulk operations across lists) ---- //
+	boardTaskRouter := boardRouter.PathPrefix("/{boardId}/tasks").Subrouter()
+	tasks.InitBoardRoutes(boardTaskRouter, a.Store)
+
+	// ---- Board Activity Endpoints ---- //
+	activityRouter := boardRouter.PathPrefix("/{boardId}/activity").Subrouter()
+	activity.InitRoutes(activityRouter, a.Store)
+
 	// ---- List Management Endpoints ---- //
-	listRouter := a.Router.PathPrefix("/boards/{boardId}/lists").Subrouter()
-	lists.InitRoutes(listRouter)
+	listRouter := boardRouter.PathPrefix("/{boardId}/lists").Subrouter()
+	lists.InitRoutes(listRouter, a.Store)
 
 	// ---- Tasks Management Endpoints ---- //
 	taskRouter:= listRouter.PathPrefix("/{listId}/tasks").Subrouter()
-	tasks.InitRoutes(taskRouter)
+	tasks.InitRoutes(taskRouter, a.Store)
+
+	// ---- Task Comments Endpoints ---- //
+	commentRouter := taskRouter.PathPrefix("/{taskId}/comments").Subrouter()
+	comments.InitRoutes(commentRouter, a.Store)
+
+	// ---- Task Checklists Endpoints ---- //
+	checklistRouter := taskRouter.PathPrefix("/{taskId}/checklists").Subrouter()
+	checklists.InitRoutes(checklistRouter, a.Store)
+
+	// ---- Task Activity Endpoints ---- //
+	taskActivityRouter := taskRouter.PathPrefix("/{taskId}/activity").Subrouter()
+	activity.InitTaskRoutes(taskActivityRouter, a.Store)
 }
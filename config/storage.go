@@ -0,0 +1,81 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/AmFlint/taco-api-go/config/database"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/dao/memory"
+	"github.com/AmFlint/taco-api-go/dao/mongo"
+	"github.com/AmFlint/taco-api-go/dao/sqlstore"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"gopkg.in/mgo.v2/bson"
+
+	// database/sql drivers supported by the sql backend
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// Storage backends, selected with environment variable APP_STORAGE
+const (
+	StorageMongo  = "mongo"
+	StorageMemory = "memory"
+	StorageSQL    = "sql"
+)
+
+// StorageConfig -> Persistence layer used by the application, and how to reach it
+type StorageConfig struct {
+	Backend string
+	// Mongo connection, only used by the mongo backend
+	User     string
+	Password string
+	DbName   string
+	DbHost   string
+	DbPort   string
+	// database/sql driver (sqlite3 | postgres) and data source name, only used by the sql backend
+	SQLDriver string
+	SQLDSN    string
+	// ID of the user made owner of boards without owner nor known creator by migrations, see dao.MigrationConfig
+	BoardOwner string
+}
+
+// StorageConfigFromEnv -> Read storage configuration from Environment Variables, defaults to a local Mongo server
+func StorageConfigFromEnv() StorageConfig {
+	return StorageConfig{
+		Backend:    helpers.GetEnv("APP_STORAGE", StorageMongo),
+		User:       helpers.GetEnv("APP_USERNAME", ""),
+		Password:   helpers.GetEnv("APP_PASSWORD", ""),
+		DbName:     helpers.GetEnv("APP_DB_NAME", "taco"),
+		DbHost:     helpers.GetEnv("APP_DB_HOST", "localhost"),
+		DbPort:     helpers.GetEnv("APP_DB_PORT", "27017"),
+		SQLDriver:  helpers.GetEnv("APP_SQL_DRIVER", sqlstore.DriverSQLite),
+		SQLDSN:     helpers.GetEnv("APP_SQL_DSN", "taco.db"),
+		BoardOwner: helpers.GetEnv("APP_MIGRATION_BOARD_OWNER", ""),
+	}
+}
+
+// openStore -> Open the persistence layer described by configuration, along with a function releasing its resources
+func openStore(c StorageConfig) (dao.Store, func(), error) {
+	var migration dao.MigrationConfig
+	if c.BoardOwner != "" {
+		if !bson.IsObjectIdHex(c.BoardOwner) {
+			return dao.Store{}, nil, fmt.Errorf("invalid board owner %q, expected a user ID", c.BoardOwner)
+		}
+		migration.BoardOwner = bson.ObjectIdHex(c.BoardOwner)
+	}
+
+	switch c.Backend {
+	case StorageMongo:
+		database.SetDBSession(c.User, c.Password, c.DbName, c.DbHost, c.DbPort)
+		return mongo.NewStore(database.GetDatabaseConnection(), migration), database.CloseSession, nil
+	case StorageMemory:
+		return memory.NewStore(), func() {}, nil
+	case StorageSQL:
+		if c.SQLDriver != sqlstore.DriverSQLite && c.SQLDriver != sqlstore.DriverPostgres {
+			return dao.Store{}, nil, fmt.Errorf("unknown sql driver %q, expected one of: %s, %s", c.SQLDriver, sqlstore.DriverSQLite, sqlstore.DriverPostgres)
+		}
+		database.SetSQLConnection(c.SQLDriver, c.SQLDSN)
+		return sqlstore.NewStore(database.GetSQLConnection(), c.SQLDriver, migration), database.CloseSQLConnection, nil
+	}
+	return dao.Store{}, nil, fmt.Errorf("unknown storage backend %q, expected one of: %s, %s, %s", c.Backend, StorageMongo, StorageMemory, StorageSQL)
+}
@@ -13,8 +13,26 @@ const (
 	HandlerDeleteLogger = "delete"
 	HandlerViewLogger = "view"
 	HandlerListLogger = "list"
+	HandlerMoveLogger = "move"
+	HandlerBulkLogger = "bulk"
+	HandlerBatchLogger = "batch"
+	HandlerAuthLogger = "auth"
+	HandlerRegisterLogger = "register"
+	HandlerLoginLogger = "login"
 
 	// Resources
 	ResourceTasksLogger = "tasks"
 	ResourceListsLogger = "lists"
+	ResourceBoardsLogger = "boards"
+	ResourceUsersLogger = "users"
+	ResourceMembersLogger = "members"
+	ResourceApiKeysLogger = "apikeys"
+	ResourceCommentsLogger = "comments"
+	ResourceLabelsLogger = "labels"
+	ResourceChecklistsLogger = "checklists"
+	ResourceActivityLogger = "activity"
+	ResourceAdminLogger = "admin"
+	ResourceSearchLogger = "search"
+	ResourceFiltersLogger = "filters"
+	ResourceBatchLogger = "batch"
 )
@@ -0,0 +1,21 @@
+package dao
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ActivityQuery -> Selects activities of a board, or of a single entity of the board when EntityID is set
+type ActivityQuery struct {
+	BoardID    bson.ObjectId
+	EntityType string
+	EntityID   bson.ObjectId
+}
+
+// Matches -> Whether given activity is selected by the query
+func (q ActivityQuery) Matches(activity models.Activity) bool {
+	if activity.BoardId != q.BoardID {
+		return false
+	}
+	return q.EntityID == "" || (activity.EntityType == q.EntityType && activity.EntityId == q.EntityID)
+}
@@ -0,0 +1,35 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AuditQuery -> Selects audit entries, every criterion being optional: an entity (type, and ID), an actor,
+// and a time range (From inclusive, To exclusive)
+type AuditQuery struct {
+	EntityType string
+	EntityID   bson.ObjectId
+	ActorID    bson.ObjectId
+	From       *time.Time
+	To         *time.Time
+}
+
+// Matches -> Whether given audit entry is selected by the query
+func (q AuditQuery) Matches(entry models.AuditEntry) bool {
+	if q.EntityType != "" && entry.EntityType != q.EntityType {
+		return false
+	}
+	if q.EntityID != "" && entry.EntityId != q.EntityID {
+		return false
+	}
+	if q.ActorID != "" && entry.ActorId != q.ActorID {
+		return false
+	}
+	if q.From != nil && entry.CreatedAt.Before(*q.From) {
+		return false
+	}
+	return q.To == nil || entry.CreatedAt.Before(*q.To)
+}
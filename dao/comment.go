@@ -0,0 +1,27 @@
+package dao
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// FillCommentCounts -> Set the number of comments of given tasks, with a single query
+func FillCommentCounts(comments CommentStore, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]bson.ObjectId, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.TaskId
+	}
+	counts, err := comments.CountByTaskIDs(ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range tasks {
+		tasks[i].CommentCount = counts[tasks[i].TaskId]
+	}
+	return nil
+}
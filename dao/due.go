@@ -0,0 +1,17 @@
+package dao
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// DueQuery -> Selects tasks due before a deadline whose due date has not been marked complete, sorted by due date
+type DueQuery struct {
+	// Restrict to tasks of a board and/or assigned to a user, when set
+	BoardID  bson.ObjectId
+	Assignee bson.ObjectId
+	// Restrict to tasks due at or after this date, when set (e.g. due within the next days rather than overdue)
+	From   *time.Time
+	Before time.Time
+}
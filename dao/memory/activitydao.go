@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+type ActivityDAO struct {
+	db *database
+}
+
+// Find -> Activities selected by given query, newest first, restricted to given page
+func (a *ActivityDAO) Find(query dao.ActivityQuery, page dao.Page) ([]models.Activity, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+
+	activities := []models.Activity{}
+	stored := a.db.activities[query.BoardID]
+	for i := len(stored) - 1; i >= 0; i-- {
+		if query.Matches(stored[i]) {
+			activities = append(activities, cloneActivity(stored[i]))
+		}
+	}
+
+	if page.Offset >= len(activities) {
+		return []models.Activity{}, nil
+	}
+	activities = activities[page.Offset:]
+	if page.Limit < len(activities) {
+		activities = activities[:page.Limit]
+	}
+	return activities, nil
+}
+
+// Count -> Number of activities selected by given query
+func (a *ActivityDAO) Count(query dao.ActivityQuery) (int, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+
+	count := 0
+	for _, activity := range a.db.activities[query.BoardID] {
+		if query.Matches(activity) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Insert an Activity, after the activities of its board
+func (a *ActivityDAO) Insert(activity *models.Activity) error {
+	a.db.Lock()
+	defer a.db.Unlock()
+
+	a.db.activities[activity.BoardId] = append(a.db.activities[activity.BoardId], cloneActivity(*activity))
+	return nil
+}
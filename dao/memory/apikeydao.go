@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ApiKeyDAO struct {
+	db *database
+}
+
+// FindByHash -> Find an API key from the hash of its value
+func (a *ApiKeyDAO) FindByHash(hash string) (models.ApiKey, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+
+	for _, apiKey := range a.db.apiKeys {
+		if apiKey.Hash == hash {
+			return cloneApiKey(apiKey), nil
+		}
+	}
+	return models.ApiKey{}, dao.ErrNotFound
+}
+
+// FindByUserAndID -> Find an API key by its id, only if it belongs to given user
+func (a *ApiKeyDAO) FindByUserAndID(userID, apiKeyID bson.ObjectId) (models.ApiKey, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+
+	apiKey, ok := a.db.apiKeys[apiKeyID]
+	if !ok || apiKey.UserId != userID {
+		return models.ApiKey{}, dao.ErrNotFound
+	}
+	return cloneApiKey(apiKey), nil
+}
+
+// FindByUserID -> API keys of given user, oldest first
+func (a *ApiKeyDAO) FindByUserID(userID bson.ObjectId) ([]models.ApiKey, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+
+	apiKeys := []models.ApiKey{}
+	for _, apiKey := range a.db.apiKeys {
+		if apiKey.UserId == userID {
+			apiKeys = append(apiKeys, cloneApiKey(apiKey))
+		}
+	}
+	sort.Slice(apiKeys, func(i, j int) bool { return apiKeys[i].ApiKeyId < apiKeys[j].ApiKeyId })
+	return apiKeys, nil
+}
+
+// Insert an API key, ids and hashes are unique
+func (a *ApiKeyDAO) Insert(apiKey *models.ApiKey) error {
+	a.db.Lock()
+	defer a.db.Unlock()
+
+	for id, existing := range a.db.apiKeys {
+		if id == apiKey.ApiKeyId || existing.Hash == apiKey.Hash {
+			return dao.ErrDuplicate
+		}
+	}
+	a.db.apiKeys[apiKey.ApiKeyId] = cloneApiKey(*apiKey)
+	return nil
+}
+
+// Delete an API key
+func (a *ApiKeyDAO) Delete(apiKey *models.ApiKey) error {
+	a.db.Lock()
+	defer a.db.Unlock()
+
+	if _, ok := a.db.apiKeys[apiKey.ApiKeyId]; !ok {
+		return dao.ErrNotFound
+	}
+	delete(a.db.apiKeys, apiKey.ApiKeyId)
+	return nil
+}
+
+// Touch -> Record that an API key has been used at given time
+func (a *ApiKeyDAO) Touch(apiKeyID bson.ObjectId, usedAt time.Time) error {
+	a.db.Lock()
+	defer a.db.Unlock()
+
+	apiKey, ok := a.db.apiKeys[apiKeyID]
+	if !ok {
+		return dao.ErrNotFound
+	}
+	apiKey.LastUsedAt = &usedAt
+	a.db.apiKeys[apiKeyID] = apiKey
+	return nil
+}
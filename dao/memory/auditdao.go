@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+type AuditDAO struct {
+	db *database
+}
+
+// Find -> Audit entries selected by given query, newest first, restricted to given page
+func (a *AuditDAO) Find(query dao.AuditQuery, page dao.Page) ([]models.AuditEntry, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+
+	entries := []models.AuditEntry{}
+	for i := len(a.db.audit) - 1; i >= 0; i-- {
+		if query.Matches(a.db.audit[i]) {
+			entries = append(entries, cloneAuditEntry(a.db.audit[i]))
+		}
+	}
+
+	if page.Offset >= len(entries) {
+		return []models.AuditEntry{}, nil
+	}
+	entries = entries[page.Offset:]
+	if page.Limit < len(entries) {
+		entries = entries[:page.Limit]
+	}
+	return entries, nil
+}
+
+// Count -> Number of audit entries selected by given query
+func (a *AuditDAO) Count(query dao.AuditQuery) (int, error) {
+	a.db.RLock()
+	defer a.db.RUnlock()
+
+	count := 0
+	for _, entry := range a.db.audit {
+		if query.Matches(entry) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Insert an AuditEntry, after every recorded entry
+func (a *AuditDAO) Insert(entry *models.AuditEntry) error {
+	a.db.Lock()
+	defer a.db.Unlock()
+
+	a.db.audit = append(a.db.audit, cloneAuditEntry(*entry))
+	return nil
+}
@@ -0,0 +1,121 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type BoardDAO struct {
+	db *database
+}
+
+// FindAll -> Find every Board, oldest first
+func (b *BoardDAO) FindAll() ([]models.Board, error) {
+	b.db.RLock()
+	defer b.db.RUnlock()
+
+	boards := make([]models.Board, 0, len(b.db.boards))
+	for _, board := range b.db.boards {
+		boards = append(boards, board)
+	}
+	sortBoards(boards)
+	return boards, nil
+}
+
+// sortBoards -> Sort boards oldest first
+func sortBoards(boards []models.Board) {
+	sort.Slice(boards, func(i, j int) bool {
+		if !boards[i].CreatedAt.Equal(boards[j].CreatedAt) {
+			return boards[i].CreatedAt.Before(boards[j].CreatedAt)
+		}
+		return boards[i].BoardId < boards[j].BoardId
+	})
+}
+
+// FindByID -> Find a Board by its id
+func (b *BoardDAO) FindByID(boardID bson.ObjectId) (models.Board, error) {
+	b.db.RLock()
+	defer b.db.RUnlock()
+
+	board, ok := b.db.boards[boardID]
+	if !ok {
+		return models.Board{}, dao.ErrNotFound
+	}
+	return board, nil
+}
+
+// FindByIDs -> Find Boards having given ids, oldest first
+func (b *BoardDAO) FindByIDs(boardIDs []bson.ObjectId) ([]models.Board, error) {
+	b.db.RLock()
+	defer b.db.RUnlock()
+
+	boards := make([]models.Board, 0, len(boardIDs))
+	for _, id := range boardIDs {
+		if board, ok := b.db.boards[id]; ok {
+			boards = append(boards, board)
+		}
+	}
+	sortBoards(boards)
+	return boards, nil
+}
+
+// Insert a Board
+func (b *BoardDAO) Insert(board *models.Board) error {
+	b.db.Lock()
+	defer b.db.Unlock()
+
+	if _, ok := b.db.boards[board.BoardId]; ok {
+		return dao.ErrDuplicate
+	}
+	b.db.boards[board.BoardId] = *board
+	return nil
+}
+
+// Update a Board
+func (b *BoardDAO) Update(board *models.Board) error {
+	b.db.Lock()
+	defer b.db.Unlock()
+
+	if _, ok := b.db.boards[board.BoardId]; !ok {
+		return dao.ErrNotFound
+	}
+	b.db.boards[board.BoardId] = *board
+	return nil
+}
+
+// Delete a Board along with its lists, tasks and members
+func (b *BoardDAO) Delete(board *models.Board) error {
+	b.db.Lock()
+	defer b.db.Unlock()
+
+	if _, ok := b.db.boards[board.BoardId]; !ok {
+		return dao.ErrNotFound
+	}
+	delete(b.db.boards, board.BoardId)
+	delete(b.db.members, board.BoardId)
+	delete(b.db.activities, board.BoardId)
+	for id, task := range b.db.tasks {
+		if task.BoardId == board.BoardId {
+			b.db.deleteTask(id)
+		}
+	}
+	for id, label := range b.db.labels {
+		if label.BoardId == board.BoardId {
+			delete(b.db.labels, id)
+		}
+	}
+	for id, filter := range b.db.filters {
+		if filter.BoardId == board.BoardId {
+			delete(b.db.filters, id)
+		}
+	}
+	for id, list := range b.db.lists {
+		if list.BoardId == board.BoardId {
+			delete(b.db.lists, id)
+		}
+	}
+	return nil
+}
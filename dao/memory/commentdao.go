@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type CommentDAO struct {
+	db *database
+}
+
+// FindByTaskAndID -> Find a Comment by its id, only if it is attached to given task
+func (c *CommentDAO) FindByTaskAndID(taskID, commentID bson.ObjectId) (models.Comment, error) {
+	c.db.RLock()
+	defer c.db.RUnlock()
+
+	comment, ok := c.db.comments[taskID][commentID]
+	if !ok {
+		return models.Comment{}, dao.ErrNotFound
+	}
+	return comment, nil
+}
+
+// FindByTaskID -> Comments of given task, oldest first, restricted to given page
+func (c *CommentDAO) FindByTaskID(taskID bson.ObjectId, page dao.Page) ([]models.Comment, error) {
+	c.db.RLock()
+	defer c.db.RUnlock()
+
+	comments := []models.Comment{}
+	for _, comment := range c.db.comments[taskID] {
+		comments = append(comments, comment)
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].CommentId < comments[j].CommentId
+	})
+
+	if page.Offset >= len(comments) {
+		return []models.Comment{}, nil
+	}
+	comments = comments[page.Offset:]
+	if page.Limit < len(comments) {
+		comments = comments[:page.Limit]
+	}
+	return comments, nil
+}
+
+// CountByTaskID -> Number of comments of given task
+func (c *CommentDAO) CountByTaskID(taskID bson.ObjectId) (int, error) {
+	c.db.RLock()
+	defer c.db.RUnlock()
+
+	return len(c.db.comments[taskID]), nil
+}
+
+// CountByTaskIDs -> Number of comments of each given task, tasks without comments are absent from the map
+func (c *CommentDAO) CountByTaskIDs(taskIDs []bson.ObjectId) (map[bson.ObjectId]int, error) {
+	c.db.RLock()
+	defer c.db.RUnlock()
+
+	counts := make(map[bson.ObjectId]int)
+	for _, taskID := range taskIDs {
+		if count := len(c.db.comments[taskID]); count > 0 {
+			counts[taskID] = count
+		}
+	}
+	return counts, nil
+}
+
+// Insert a comment, its task must exist
+func (c *CommentDAO) Insert(comment *models.Comment) error {
+	c.db.Lock()
+	defer c.db.Unlock()
+
+	if _, ok := c.db.tasks[comment.TaskId]; !ok {
+		return dao.ErrNotFound
+	}
+	comments, ok := c.db.comments[comment.TaskId]
+	if !ok {
+		comments = make(map[bson.ObjectId]models.Comment)
+		c.db.comments[comment.TaskId] = comments
+	}
+	if _, ok := comments[comment.CommentId]; ok {
+		return dao.ErrDuplicate
+	}
+	comments[comment.CommentId] = *comment
+	return nil
+}
+
+// Update a comment
+func (c *CommentDAO) Update(comment *models.Comment) error {
+	c.db.Lock()
+	defer c.db.Unlock()
+
+	if _, ok := c.db.comments[comment.TaskId][comment.CommentId]; !ok {
+		return dao.ErrNotFound
+	}
+	c.db.comments[comment.TaskId][comment.CommentId] = *comment
+	return nil
+}
+
+// Delete a comment
+func (c *CommentDAO) Delete(comment *models.Comment) error {
+	c.db.Lock()
+	defer c.db.Unlock()
+
+	if _, ok := c.db.comments[comment.TaskId][comment.CommentId]; !ok {
+		return dao.ErrNotFound
+	}
+	delete(c.db.comments[comment.TaskId], comment.CommentId)
+	return nil
+}
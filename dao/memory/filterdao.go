@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type FilterDAO struct {
+	db *database
+}
+
+// FindByBoardAndID -> Find a Filter by its id, only if it belongs to given board
+func (f *FilterDAO) FindByBoardAndID(boardID, filterID bson.ObjectId) (models.Filter, error) {
+	f.db.RLock()
+	defer f.db.RUnlock()
+
+	filter, ok := f.db.filters[filterID]
+	if !ok || filter.BoardId != boardID {
+		return models.Filter{}, dao.ErrNotFound
+	}
+	return filter, nil
+}
+
+// FindByBoardID -> Filters of given board, sorted by name
+func (f *FilterDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Filter, error) {
+	f.db.RLock()
+	defer f.db.RUnlock()
+
+	filters := []models.Filter{}
+	for _, filter := range f.db.filters {
+		if filter.BoardId == boardID {
+			filters = append(filters, filter)
+		}
+	}
+	sort.Slice(filters, func(i, j int) bool {
+		if filters[i].Name != filters[j].Name {
+			return filters[i].Name < filters[j].Name
+		}
+		return filters[i].FilterId < filters[j].FilterId
+	})
+	return filters, nil
+}
+
+// Insert a Filter
+func (f *FilterDAO) Insert(filter *models.Filter) error {
+	f.db.Lock()
+	defer f.db.Unlock()
+
+	if _, ok := f.db.filters[filter.FilterId]; ok {
+		return dao.ErrDuplicate
+	}
+	f.db.filters[filter.FilterId] = *filter
+	return nil
+}
+
+// Update a Filter
+func (f *FilterDAO) Update(filter *models.Filter) error {
+	f.db.Lock()
+	defer f.db.Unlock()
+
+	if _, ok := f.db.filters[filter.FilterId]; !ok {
+		return dao.ErrNotFound
+	}
+	f.db.filters[filter.FilterId] = *filter
+	return nil
+}
+
+// Delete a Filter
+func (f *FilterDAO) Delete(filter *models.Filter) error {
+	f.db.Lock()
+	defer f.db.Unlock()
+
+	if _, ok := f.db.filters[filter.FilterId]; !ok {
+		return dao.ErrNotFound
+	}
+	delete(f.db.filters, filter.FilterId)
+	return nil
+}
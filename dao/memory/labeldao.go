@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type LabelDAO struct {
+	db *database
+}
+
+// FindByBoardAndID -> Find a Label by its id, only if it belongs to given board
+func (l *LabelDAO) FindByBoardAndID(boardID, labelID bson.ObjectId) (models.Label, error) {
+	l.db.RLock()
+	defer l.db.RUnlock()
+
+	label, ok := l.db.labels[labelID]
+	if !ok || label.BoardId != boardID {
+		return models.Label{}, dao.ErrNotFound
+	}
+	return label, nil
+}
+
+// FindByBoardID -> Labels of given board, sorted by name
+func (l *LabelDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Label, error) {
+	l.db.RLock()
+	defer l.db.RUnlock()
+
+	labels := []models.Label{}
+	for _, label := range l.db.labels {
+		if label.BoardId == boardID {
+			labels = append(labels, label)
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].Name != labels[j].Name {
+			return labels[i].Name < labels[j].Name
+		}
+		return labels[i].LabelId < labels[j].LabelId
+	})
+	return labels, nil
+}
+
+// Insert a Label
+func (l *LabelDAO) Insert(label *models.Label) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	if _, ok := l.db.labels[label.LabelId]; ok {
+		return dao.ErrDuplicate
+	}
+	l.db.labels[label.LabelId] = *label
+	return nil
+}
+
+// Update a Label
+func (l *LabelDAO) Update(label *models.Label) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	if _, ok := l.db.labels[label.LabelId]; !ok {
+		return dao.ErrNotFound
+	}
+	l.db.labels[label.LabelId] = *label
+	return nil
+}
+
+// Delete a Label and detach it from every task of its board
+func (l *LabelDAO) Delete(label *models.Label) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	if _, ok := l.db.labels[label.LabelId]; !ok {
+		return dao.ErrNotFound
+	}
+	delete(l.db.labels, label.LabelId)
+	for id, task := range l.db.tasks {
+		if task.BoardId == label.BoardId {
+			task.Labels = dao.RemoveID(task.Labels, label.LabelId)
+			l.db.tasks[id] = task
+		}
+	}
+	return nil
+}
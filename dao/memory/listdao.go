@@ -0,0 +1,174 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ListDAO struct {
+	db *database
+}
+
+// listIDsByBoardID -> IDs of lists attached to given board, sorted by order, caller must hold the lock
+func (d *database) listIDsByBoardID(boardID bson.ObjectId) []bson.ObjectId {
+	var entries []ordered
+	for _, list := range d.lists {
+		if list.BoardId == boardID {
+			entries = append(entries, ordered{id: list.ListId, order: list.Order})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return lessOrdered(entries[i], entries[j]) })
+
+	ids := make([]bson.ObjectId, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.id
+	}
+	return ids
+}
+
+// reorderLists -> Give orders 1..n to given lists, caller must hold the lock
+func (d *database) reorderLists(ids []bson.ObjectId) {
+	for i, id := range ids {
+		if list, ok := d.lists[id]; ok {
+			list.Order = i + 1
+			d.lists[id] = list
+		}
+	}
+}
+
+// FindByBoardAndID -> Find a List by its id, only if it belongs to given board
+func (l *ListDAO) FindByBoardAndID(boardID, listID bson.ObjectId) (models.List, error) {
+	l.db.RLock()
+	defer l.db.RUnlock()
+
+	list, ok := l.db.lists[listID]
+	if !ok || list.BoardId != boardID {
+		return models.List{}, dao.ErrNotFound
+	}
+	return cloneList(list), nil
+}
+
+// FindByBoardID -> Find every List attached to given board, sorted by order
+func (l *ListDAO) FindByBoardID(boardID bson.ObjectId) ([]models.List, error) {
+	l.db.RLock()
+	defer l.db.RUnlock()
+
+	ids := l.db.listIDsByBoardID(boardID)
+	lists := make([]models.List, len(ids))
+	for i, id := range ids {
+		lists[i] = cloneList(l.db.lists[id])
+	}
+	return lists, nil
+}
+
+// Search -> Lists of the query boards holding at least one of its terms, best first
+func (l *ListDAO) Search(query dao.SearchQuery) ([]dao.ListMatch, error) {
+	l.db.RLock()
+	defer l.db.RUnlock()
+
+	lists := []models.List{}
+	for _, boardID := range query.BoardIDs {
+		for _, id := range l.db.listIDsByBoardID(boardID) {
+			lists = append(lists, cloneList(l.db.lists[id]))
+		}
+	}
+	return query.RankLists(lists), nil
+}
+
+// FindIDsByBoardID -> Retrieve IDs of lists attached to given board, sorted by order
+func (l *ListDAO) FindIDsByBoardID(boardID bson.ObjectId) ([]bson.ObjectId, error) {
+	l.db.RLock()
+	defer l.db.RUnlock()
+
+	return l.db.listIDsByBoardID(boardID), nil
+}
+
+// CountByBoardID -> Count lists attached to given board
+func (l *ListDAO) CountByBoardID(boardID bson.ObjectId) (int, error) {
+	l.db.RLock()
+	defer l.db.RUnlock()
+
+	return len(l.db.listIDsByBoardID(boardID)), nil
+}
+
+// Insert a List
+func (l *ListDAO) Insert(list *models.List) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	if _, ok := l.db.lists[list.ListId]; ok {
+		return dao.ErrDuplicate
+	}
+	l.db.lists[list.ListId] = cloneList(*list)
+	return nil
+}
+
+// Update a List, except its order
+func (l *ListDAO) Update(list *models.List) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	stored, ok := l.db.lists[list.ListId]
+	if !ok {
+		return dao.ErrNotFound
+	}
+	updated := cloneList(*list)
+	updated.Order = stored.Order
+	l.db.lists[list.ListId] = updated
+	return nil
+}
+
+// Delete a List along with its tasks
+func (l *ListDAO) Delete(list *models.List) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	if _, ok := l.db.lists[list.ListId]; !ok {
+		return dao.ErrNotFound
+	}
+	delete(l.db.lists, list.ListId)
+	for id, task := range l.db.tasks {
+		if task.ListId == list.ListId {
+			l.db.deleteTask(id)
+		}
+	}
+	return nil
+}
+
+// Reorder lists of a board: given ids (the full set of board's lists) get orders 1..n
+func (l *ListDAO) Reorder(ids []bson.ObjectId) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	l.db.reorderLists(ids)
+	return nil
+}
+
+// Move a list to given position (starting at 1) of its board, renumbering its siblings
+func (l *ListDAO) Move(list *models.List, position int) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	if _, ok := l.db.lists[list.ListId]; !ok {
+		return dao.ErrNotFound
+	}
+
+	ids := dao.InsertAt(dao.RemoveID(l.db.listIDsByBoardID(list.BoardId), list.ListId), list.ListId, position)
+	l.db.reorderLists(ids)
+
+	// Reflect changes on the moved list
+	list.Order = l.db.lists[list.ListId].Order
+	return nil
+}
+
+// Renumber lists of given board so that their orders are contiguous (1..n), e.g. after a deletion
+func (l *ListDAO) Renumber(boardID bson.ObjectId) error {
+	l.db.Lock()
+	defer l.db.Unlock()
+
+	l.db.reorderLists(l.db.listIDsByBoardID(boardID))
+	return nil
+}
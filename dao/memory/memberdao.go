@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type MemberDAO struct {
+	db *database
+}
+
+// FindByBoardAndUser -> Find membership of given user on given board
+func (m *MemberDAO) FindByBoardAndUser(boardID, userID bson.ObjectId) (models.BoardMember, error) {
+	m.db.RLock()
+	defer m.db.RUnlock()
+
+	member, ok := m.db.members[boardID][userID]
+	if !ok {
+		return models.BoardMember{}, dao.ErrNotFound
+	}
+	return member, nil
+}
+
+// FindByBoardID -> Members of given board, oldest first
+func (m *MemberDAO) FindByBoardID(boardID bson.ObjectId) ([]models.BoardMember, error) {
+	m.db.RLock()
+	defer m.db.RUnlock()
+
+	members := []models.BoardMember{}
+	for _, member := range m.db.members[boardID] {
+		members = append(members, member)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if !members[i].CreatedAt.Equal(members[j].CreatedAt) {
+			return members[i].CreatedAt.Before(members[j].CreatedAt)
+		}
+		return members[i].UserId < members[j].UserId
+	})
+	return members, nil
+}
+
+// FindBoardIDsByUserID -> IDs of boards given user is a member of
+func (m *MemberDAO) FindBoardIDsByUserID(userID bson.ObjectId) ([]bson.ObjectId, error) {
+	m.db.RLock()
+	defer m.db.RUnlock()
+
+	ids := []bson.ObjectId{}
+	for boardID, members := range m.db.members {
+		if _, ok := members[userID]; ok {
+			ids = append(ids, boardID)
+		}
+	}
+	return ids, nil
+}
+
+// Insert a membership, a user is a member of a board at most once
+func (m *MemberDAO) Insert(member *models.BoardMember) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	members, ok := m.db.members[member.BoardId]
+	if !ok {
+		members = make(map[bson.ObjectId]models.BoardMember)
+		m.db.members[member.BoardId] = members
+	}
+	if _, ok := members[member.UserId]; ok {
+		return dao.ErrDuplicate
+	}
+	members[member.UserId] = *member
+	return nil
+}
+
+// Update a membership
+func (m *MemberDAO) Update(member *models.BoardMember) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if _, ok := m.db.members[member.BoardId][member.UserId]; !ok {
+		return dao.ErrNotFound
+	}
+	m.db.members[member.BoardId][member.UserId] = *member
+	return nil
+}
+
+// Delete a membership
+func (m *MemberDAO) Delete(member *models.BoardMember) error {
+	m.db.Lock()
+	defer m.db.Unlock()
+
+	if _, ok := m.db.members[member.BoardId][member.UserId]; !ok {
+		return dao.ErrNotFound
+	}
+	delete(m.db.members[member.BoardId], member.UserId)
+	return nil
+}
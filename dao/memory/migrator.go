@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+)
+
+// Migrator -> In-memory storage starts empty with the current schema, there is never anything to migrate
+type Migrator struct{}
+
+// Pending -> Always empty
+func (m *Migrator) Pending() ([]dao.Migration, error) {
+	return []dao.Migration{}, nil
+}
+
+// Migrate -> Nothing to apply
+func (m *Migrator) Migrate() ([]dao.Migration, error) {
+	return []dao.Migration{}, nil
+}
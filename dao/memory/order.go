@@ -0,0 +1,18 @@
+package memory
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ordered -> Entity which can be sorted by order, ties broken by ID (ObjectIDs grow with creation time)
+type ordered struct {
+	id    bson.ObjectId
+	order int
+}
+
+func lessOrdered(a, b ordered) bool {
+	if a.order != b.order {
+		return a.order < b.order
+	}
+	return a.id < b.id
+}
@@ -0,0 +1,215 @@
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// database -> In-memory collections shared by every memory DAO, guarded by a single lock
+// so that multi-entity operations (moves, renumbering, cascades) are atomic
+type database struct {
+	sync.RWMutex
+	collections
+}
+
+// collections -> Every collection of the in-memory store
+type collections struct {
+	boards map[bson.ObjectId]models.Board
+	lists  map[bson.ObjectId]models.List
+	tasks  map[bson.ObjectId]models.Task
+	users  map[bson.ObjectId]models.User
+	labels map[bson.ObjectId]models.Label
+	// Saved task filters, keyed by filter
+	filters map[bson.ObjectId]models.Filter
+	// Comments, keyed by task then comment
+	comments map[bson.ObjectId]map[bson.ObjectId]models.Comment
+	// Memberships, keyed by board then user
+	members map[bson.ObjectId]map[bson.ObjectId]models.BoardMember
+	apiKeys map[bson.ObjectId]models.ApiKey
+	// Activities, keyed by board, oldest first
+	activities map[bson.ObjectId][]models.Activity
+	// Audit log, oldest first
+	audit []models.AuditEntry
+}
+
+// NewStore -> Create an empty dao.Store kept in process memory, useful for development and tests
+func NewStore() dao.Store {
+	db := &database{collections: collections{
+		boards:     make(map[bson.ObjectId]models.Board),
+		lists:      make(map[bson.ObjectId]models.List),
+		tasks:      make(map[bson.ObjectId]models.Task),
+		users:      make(map[bson.ObjectId]models.User),
+		labels:     make(map[bson.ObjectId]models.Label),
+		filters:    make(map[bson.ObjectId]models.Filter),
+		comments:   make(map[bson.ObjectId]map[bson.ObjectId]models.Comment),
+		members:    make(map[bson.ObjectId]map[bson.ObjectId]models.BoardMember),
+		apiKeys:    make(map[bson.ObjectId]models.ApiKey),
+		activities: make(map[bson.ObjectId][]models.Activity),
+	}}
+
+	return dao.Store{
+		Boards:     &BoardDAO{db: db},
+		Lists:      &ListDAO{db: db},
+		Tasks:      &TaskDAO{db: db},
+		Comments:   &CommentDAO{db: db},
+		Labels:     &LabelDAO{db: db},
+		Filters:    &FilterDAO{db: db},
+		Activity:   &ActivityDAO{db: db},
+		Audit:      &AuditDAO{db: db},
+		Users:      &UserDAO{db: db},
+		Members:    &MemberDAO{db: db},
+		ApiKeys:    &ApiKeyDAO{db: db},
+		Migrator:   &Migrator{},
+		Transactor: db,
+	}
+}
+
+// Atomically -> Run fn, every collection is restored as it was before if it fails. Nothing else may use the store while fn runs
+func (d *database) Atomically(fn func() error) error {
+	d.RLock()
+	saved := d.collections.clone()
+	d.RUnlock()
+
+	if err := fn(); err != nil {
+		d.Lock()
+		d.collections = saved
+		d.Unlock()
+		return err
+	}
+	return nil
+}
+
+// clone -> Copy of every collection, sharing no map nor slice with them
+func (c collections) clone() collections {
+	clone := collections{
+		boards:     make(map[bson.ObjectId]models.Board, len(c.boards)),
+		lists:      make(map[bson.ObjectId]models.List, len(c.lists)),
+		tasks:      make(map[bson.ObjectId]models.Task, len(c.tasks)),
+		users:      make(map[bson.ObjectId]models.User, len(c.users)),
+		labels:     make(map[bson.ObjectId]models.Label, len(c.labels)),
+		filters:    make(map[bson.ObjectId]models.Filter, len(c.filters)),
+		comments:   make(map[bson.ObjectId]map[bson.ObjectId]models.Comment, len(c.comments)),
+		members:    make(map[bson.ObjectId]map[bson.ObjectId]models.BoardMember, len(c.members)),
+		apiKeys:    make(map[bson.ObjectId]models.ApiKey, len(c.apiKeys)),
+		activities: make(map[bson.ObjectId][]models.Activity, len(c.activities)),
+		audit:      make([]models.AuditEntry, len(c.audit)),
+	}
+	for id, board := range c.boards {
+		clone.boards[id] = board
+	}
+	for id, list := range c.lists {
+		clone.lists[id] = cloneList(list)
+	}
+	for id, task := range c.tasks {
+		clone.tasks[id] = cloneTask(task)
+	}
+	for id, user := range c.users {
+		clone.users[id] = user
+	}
+	for id, label := range c.labels {
+		clone.labels[id] = label
+	}
+	for id, filter := range c.filters {
+		clone.filters[id] = filter
+	}
+	for taskID, comments := range c.comments {
+		clone.comments[taskID] = make(map[bson.ObjectId]models.Comment, len(comments))
+		for id, comment := range comments {
+			clone.comments[taskID][id] = comment
+		}
+	}
+	for boardID, members := range c.members {
+		clone.members[boardID] = make(map[bson.ObjectId]models.BoardMember, len(members))
+		for userID, member := range members {
+			clone.members[boardID][userID] = member
+		}
+	}
+	for id, apiKey := range c.apiKeys {
+		clone.apiKeys[id] = cloneApiKey(apiKey)
+	}
+	for boardID, activities := range c.activities {
+		clone.activities[boardID] = make([]models.Activity, len(activities))
+		for i, activity := range activities {
+			clone.activities[boardID][i] = cloneActivity(activity)
+		}
+	}
+	for i, entry := range c.audit {
+		clone.audit[i] = cloneAuditEntry(entry)
+	}
+	return clone
+}
+
+// Entities are copied in and out of the store, so that callers never share slices with stored data
+
+func cloneList(list models.List) models.List {
+	list.Tasks = append([]models.Task{}, list.Tasks...)
+	list.Summary = nil
+	return list
+}
+
+func cloneTask(task models.Task) models.Task {
+	task.Labels = append([]bson.ObjectId{}, task.Labels...)
+	task.Assignees = append([]bson.ObjectId{}, task.Assignees...)
+	task.Watchers = append([]bson.ObjectId{}, task.Watchers...)
+	checklists := make([]models.Checklist, len(task.Checklists))
+	for i, checklist := range task.Checklists {
+		checklist.Items = append([]models.ChecklistItem{}, checklist.Items...)
+		checklists[i] = checklist
+	}
+	task.Checklists = checklists
+	task.StartAt, task.DueAt = cloneTime(task.StartAt), cloneTime(task.DueAt)
+	return task
+}
+
+func cloneApiKey(apiKey models.ApiKey) models.ApiKey {
+	apiKey.ExpiresAt, apiKey.LastUsedAt = cloneTime(apiKey.ExpiresAt), cloneTime(apiKey.LastUsedAt)
+	return apiKey
+}
+
+func cloneActivity(activity models.Activity) models.Activity {
+	activity.Before, activity.After = cloneSummary(activity.Before), cloneSummary(activity.After)
+	return activity
+}
+
+func cloneAuditEntry(entry models.AuditEntry) models.AuditEntry {
+	entry.Before, entry.After = cloneSnapshot(entry.Before), cloneSnapshot(entry.After)
+	entry.ChangedFields = append([]string{}, entry.ChangedFields...)
+	return entry
+}
+
+// cloneSnapshot -> Copy of an audit snapshot, its values are never modified once recorded
+func cloneSnapshot(snapshot models.Snapshot) models.Snapshot {
+	if snapshot == nil {
+		return nil
+	}
+	clone := make(models.Snapshot, len(snapshot))
+	for key, value := range snapshot {
+		clone[key] = value
+	}
+	return clone
+}
+
+// cloneSummary -> Copy of an activity summary, its values are never modified once recorded
+func cloneSummary(summary models.ActivitySummary) models.ActivitySummary {
+	if summary == nil {
+		return nil
+	}
+	clone := make(models.ActivitySummary, len(summary))
+	for key, value := range summary {
+		clone[key] = value
+	}
+	return clone
+}
+
+// cloneTime -> Copy of a nullable timestamp, not shared with the stored entity
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	clone := *t
+	return &clone
+}
@@ -0,0 +1,409 @@
+package memory
+
+import (
+	"sort"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type TaskDAO struct {
+	db *database
+}
+
+// taskIDsByListID -> IDs of tasks attached to given list, sorted by order, caller must hold the lock
+func (d *database) taskIDsByListID(listID bson.ObjectId) []bson.ObjectId {
+	var entries []ordered
+	for _, task := range d.tasks {
+		if task.ListId == listID {
+			entries = append(entries, ordered{id: task.TaskId, order: task.Order})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return lessOrdered(entries[i], entries[j]) })
+
+	ids := make([]bson.ObjectId, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.id
+	}
+	return ids
+}
+
+// reorderTasks -> Give orders 1..n to given tasks, caller must hold the lock
+func (d *database) reorderTasks(ids []bson.ObjectId) {
+	for i, id := range ids {
+		if task, ok := d.tasks[id]; ok {
+			task.Order = i + 1
+			d.tasks[id] = task
+		}
+	}
+}
+
+// deleteTask -> Delete a task along with its comments, caller must hold the lock
+func (d *database) deleteTask(taskID bson.ObjectId) {
+	delete(d.tasks, taskID)
+	delete(d.comments, taskID)
+}
+
+// findTasks -> Tasks matching given predicate, sorted by order, caller must hold the lock
+func (d *database) findTasks(match func(task models.Task) bool) []models.Task {
+	tasks := []models.Task{}
+	for _, task := range d.tasks {
+		if match(task) {
+			tasks = append(tasks, cloneTask(task))
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return lessOrdered(ordered{tasks[i].TaskId, tasks[i].Order}, ordered{tasks[j].TaskId, tasks[j].Order})
+	})
+	return tasks
+}
+
+// FindByListAndID -> Find a Task by its id, only if it is attached to given list
+func (t *TaskDAO) FindByListAndID(listID, taskID bson.ObjectId) (models.Task, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	task, ok := t.db.tasks[taskID]
+	if !ok || task.ListId != listID {
+		return models.Task{}, dao.ErrNotFound
+	}
+	return cloneTask(task), nil
+}
+
+// FindByListID -> Find every Task attached to given list, sorted by order
+func (t *TaskDAO) FindByListID(listID bson.ObjectId) ([]models.Task, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	return t.db.findTasks(func(task models.Task) bool { return task.ListId == listID }), nil
+}
+
+// FindByBoardID -> Find every Task attached to given board, sorted by order
+func (t *TaskDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Task, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	return t.db.findTasks(func(task models.Task) bool { return task.BoardId == boardID }), nil
+}
+
+// FindByAssignee -> Find every Task given user is assigned to, across boards
+func (t *TaskDAO) FindByAssignee(userID bson.ObjectId) ([]models.Task, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	return t.db.findTasks(func(task models.Task) bool { return dao.HasID(task.Assignees, userID) }), nil
+}
+
+// FindDue -> Find Tasks matching given due date query, sorted by due date
+func (t *TaskDAO) FindDue(query dao.DueQuery) ([]models.Task, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	tasks := t.db.findTasks(func(task models.Task) bool {
+		return task.IsDue(query.Before) &&
+			(query.From == nil || !task.DueAt.Before(*query.From)) &&
+			(query.BoardID == "" || task.BoardId == query.BoardID) &&
+			(query.Assignee == "" || dao.HasID(task.Assignees, query.Assignee))
+	})
+	sort.SliceStable(tasks, func(i, j int) bool { return tasks[i].DueAt.Before(*tasks[j].DueAt) })
+	return tasks, nil
+}
+
+// Find -> Find Tasks matching given query, sorted as requested by the query, restricted to given page
+func (t *TaskDAO) Find(query dao.TaskQuery, page dao.Page) ([]models.Task, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	// Tasks come sorted by order, which breaks ties of the requested field
+	tasks := t.db.findTasks(query.Matches)
+	less := taskLess(query.Sort)
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if query.Descending {
+			return less(tasks[j], tasks[i])
+		}
+		return less(tasks[i], tasks[j])
+	})
+
+	if page.Offset >= len(tasks) {
+		return []models.Task{}, nil
+	}
+	tasks = tasks[page.Offset:]
+	if page.Limit < len(tasks) {
+		tasks = tasks[:page.Limit]
+	}
+	return tasks, nil
+}
+
+// taskLess -> Comparison of tasks on given sort field
+func taskLess(field string) func(a, b models.Task) bool {
+	switch field {
+	case dao.TaskSortTitle:
+		return func(a, b models.Task) bool { return a.Title < b.Title }
+	case dao.TaskSortPoints:
+		return func(a, b models.Task) bool { return a.Points < b.Points }
+	case dao.TaskSortCreatedAt:
+		return func(a, b models.Task) bool { return a.CreatedAt.Before(b.CreatedAt) }
+	}
+	return func(a, b models.Task) bool { return a.Order < b.Order }
+}
+
+// Search -> Tasks of the query boards holding at least one of its terms, best first
+func (t *TaskDAO) Search(query dao.SearchQuery) ([]dao.TaskMatch, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	return query.RankTasks(t.db.findTasks(func(task models.Task) bool { return dao.HasID(query.BoardIDs, task.BoardId) })), nil
+}
+
+// Count -> Number of Tasks matching given query
+func (t *TaskDAO) Count(query dao.TaskQuery) (int, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	count := 0
+	for _, task := range t.db.tasks {
+		if query.Matches(task) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountByListID -> Count tasks attached to given list
+func (t *TaskDAO) CountByListID(listID bson.ObjectId) (int, error) {
+	t.db.RLock()
+	defer t.db.RUnlock()
+
+	return len(t.db.taskIDsByListID(listID)), nil
+}
+
+// Insert a Task
+func (t *TaskDAO) Insert(task *models.Task) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	if _, ok := t.db.tasks[task.TaskId]; ok {
+		return dao.ErrDuplicate
+	}
+	t.db.tasks[task.TaskId] = cloneTask(*task)
+	return nil
+}
+
+// Update a Task, except its list, order, checklists, assignees and watchers
+func (t *TaskDAO) Update(task *models.Task) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	stored, ok := t.db.tasks[task.TaskId]
+	if !ok {
+		return dao.ErrNotFound
+	}
+	updated := cloneTask(*task)
+	updated.ListId, updated.Order, updated.Checklists = stored.ListId, stored.Order, stored.Checklists
+	updated.Assignees, updated.Watchers = stored.Assignees, stored.Watchers
+	t.db.tasks[task.TaskId] = updated
+	return nil
+}
+
+// UpdateChecklists -> Apply change to the stored task then save its checklists and update tracking, under the lock
+func (t *TaskDAO) UpdateChecklists(task *models.Task, change func(task *models.Task) error) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	stored, ok := t.db.tasks[task.TaskId]
+	if !ok {
+		return dao.ErrNotFound
+	}
+	updated := cloneTask(stored)
+	if err := change(&updated); err != nil {
+		return err
+	}
+	stored.Checklists = updated.Checklists
+	stored.UpdatedAt, stored.UpdatedBy = task.UpdatedAt, task.UpdatedBy
+	t.db.tasks[task.TaskId] = cloneTask(stored)
+	*task = cloneTask(stored)
+	return nil
+}
+
+// AddPerson -> Add user to given people of the stored task unless it already is one of them, under the lock
+func (t *TaskDAO) AddPerson(task *models.Task, people dao.TaskPeople, userID bson.ObjectId) (bool, error) {
+	return t.changePeople(task, people, func(ids *[]bson.ObjectId) bool {
+		if dao.HasID(*ids, userID) {
+			return false
+		}
+		*ids = append(*ids, userID)
+		return true
+	})
+}
+
+// RemovePerson -> Remove user from given people of the stored task, under the lock
+func (t *TaskDAO) RemovePerson(task *models.Task, people dao.TaskPeople, userID bson.ObjectId) (bool, error) {
+	return t.changePeople(task, people, func(ids *[]bson.ObjectId) bool {
+		if !dao.HasID(*ids, userID) {
+			return false
+		}
+		*ids = dao.RemoveID(*ids, userID)
+		return true
+	})
+}
+
+// changePeople -> Apply change to given people of the stored task, saving update tracking along when they changed
+func (t *TaskDAO) changePeople(task *models.Task, people dao.TaskPeople, change func(ids *[]bson.ObjectId) bool) (bool, error) {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	stored, ok := t.db.tasks[task.TaskId]
+	if !ok {
+		return false, dao.ErrNotFound
+	}
+	stored = cloneTask(stored)
+	changed := change(people.Of(&stored))
+	if changed {
+		stored.UpdatedAt, stored.UpdatedBy = task.UpdatedAt, task.UpdatedBy
+		t.db.tasks[task.TaskId] = stored
+	}
+	*task = cloneTask(stored)
+	return changed, nil
+}
+
+// Delete a Task
+func (t *TaskDAO) Delete(task *models.Task) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	if _, ok := t.db.tasks[task.TaskId]; !ok {
+		return dao.ErrNotFound
+	}
+	t.db.deleteTask(task.TaskId)
+	return nil
+}
+
+// Move a task to given position (starting at 1) of target list, renumbering source and target lists at once
+func (t *TaskDAO) Move(task *models.Task, targetListID bson.ObjectId, position int) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	stored, ok := t.db.tasks[task.TaskId]
+	if !ok {
+		return dao.ErrNotFound
+	}
+	sourceListID := stored.ListId
+
+	target := dao.InsertAt(dao.RemoveID(t.db.taskIDsByListID(targetListID), task.TaskId), task.TaskId, position)
+	stored.ListId = targetListID
+	stored.UpdatedAt, stored.UpdatedBy = task.UpdatedAt, task.UpdatedBy
+	t.db.tasks[task.TaskId] = stored
+
+	if sourceListID != targetListID {
+		t.db.reorderTasks(t.db.taskIDsByListID(sourceListID))
+	}
+	t.db.reorderTasks(target)
+
+	// Reflect changes on the moved task
+	task.ListId = targetListID
+	task.Order = t.db.tasks[task.TaskId].Order
+	return nil
+}
+
+// Renumber tasks of given list so that their orders are contiguous (1..n), e.g. after a deletion
+func (t *TaskDAO) Renumber(listID bson.ObjectId) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	t.db.reorderTasks(t.db.taskIDsByListID(listID))
+	return nil
+}
+
+// UpdateAll -> Update given tasks at once, none of them is updated if one does not exist
+func (t *TaskDAO) UpdateAll(tasks []models.Task) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	for _, task := range tasks {
+		if _, ok := t.db.tasks[task.TaskId]; !ok {
+			return dao.ErrNotFound
+		}
+	}
+	for _, task := range tasks {
+		stored := t.db.tasks[task.TaskId]
+		updated := cloneTask(task)
+		updated.ListId, updated.Order, updated.Checklists = stored.ListId, stored.Order, stored.Checklists
+		t.db.tasks[task.TaskId] = updated
+	}
+	return nil
+}
+
+// MoveAll -> Move given tasks at the end of target list, in given order, renumbering source and target lists at once
+func (t *TaskDAO) MoveAll(tasks []models.Task, targetListID bson.ObjectId) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	for _, task := range tasks {
+		if _, ok := t.db.tasks[task.TaskId]; !ok {
+			return dao.ErrNotFound
+		}
+	}
+
+	target := t.db.taskIDsByListID(targetListID)
+	sources := map[bson.ObjectId]bool{}
+	for _, task := range tasks {
+		stored := t.db.tasks[task.TaskId]
+		sources[stored.ListId] = true
+		target = append(dao.RemoveID(target, task.TaskId), task.TaskId)
+		stored.ListId = targetListID
+		stored.UpdatedAt, stored.UpdatedBy = task.UpdatedAt, task.UpdatedBy
+		t.db.tasks[task.TaskId] = stored
+	}
+
+	for listID := range sources {
+		if listID != targetListID {
+			t.db.reorderTasks(t.db.taskIDsByListID(listID))
+		}
+	}
+	t.db.reorderTasks(target)
+
+	// Reflect changes on the moved tasks
+	for i := range tasks {
+		tasks[i].ListId = targetListID
+		tasks[i].Order = t.db.tasks[tasks[i].TaskId].Order
+	}
+	return nil
+}
+
+// DeleteAll -> Delete given tasks along with their comments at once, none of them is deleted if one does not exist
+func (t *TaskDAO) DeleteAll(tasks []models.Task) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	for _, task := range tasks {
+		if _, ok := t.db.tasks[task.TaskId]; !ok {
+			return dao.ErrNotFound
+		}
+	}
+
+	lists := map[bson.ObjectId]bool{}
+	for _, task := range tasks {
+		lists[t.db.tasks[task.TaskId].ListId] = true
+		t.db.deleteTask(task.TaskId)
+	}
+	for listID := range lists {
+		t.db.reorderTasks(t.db.taskIDsByListID(listID))
+	}
+	return nil
+}
+
+// RemoveUser -> Unassign given user from every task of a board, and stop it watching them
+func (t *TaskDAO) RemoveUser(boardID, userID bson.ObjectId) error {
+	t.db.Lock()
+	defer t.db.Unlock()
+
+	for id, task := range t.db.tasks {
+		if task.BoardId == boardID {
+			task.Assignees = dao.RemoveID(task.Assignees, userID)
+			task.Watchers = dao.RemoveID(task.Watchers, userID)
+			t.db.tasks[id] = task
+		}
+	}
+	return nil
+}
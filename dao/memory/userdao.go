@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type UserDAO struct {
+	db *database
+}
+
+// FindByID -> Find a User by its id
+func (u *UserDAO) FindByID(userID bson.ObjectId) (models.User, error) {
+	u.db.RLock()
+	defer u.db.RUnlock()
+
+	user, ok := u.db.users[userID]
+	if !ok {
+		return models.User{}, dao.ErrNotFound
+	}
+	return user, nil
+}
+
+// FindByEmail -> Find a User by its email
+func (u *UserDAO) FindByEmail(email string) (models.User, error) {
+	u.db.RLock()
+	defer u.db.RUnlock()
+
+	for _, user := range u.db.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return models.User{}, dao.ErrNotFound
+}
+
+// Insert a User, its id and email must be unique
+func (u *UserDAO) Insert(user *models.User) error {
+	u.db.Lock()
+	defer u.db.Unlock()
+
+	for id, existing := range u.db.users {
+		if id == user.UserId || existing.Email == user.Email {
+			return dao.ErrDuplicate
+		}
+	}
+	u.db.users[user.UserId] = *user
+	return nil
+}
@@ -0,0 +1,48 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ActivityDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	ActivityCollection = "activities"
+)
+
+// Create an ActivityDAO structure and set DAO's database, return new struct
+func NewActivityDAO(db *mgo.Database) *ActivityDAO {
+	return &ActivityDAO{Database: db}
+}
+
+// activitySelector -> Mongo selector equivalent to given query
+func activitySelector(query dao.ActivityQuery) bson.M {
+	selector := bson.M{"boardId": query.BoardID}
+	if query.EntityID != "" {
+		selector["entityType"], selector["entityId"] = query.EntityType, query.EntityID
+	}
+	return selector
+}
+
+// Find -> Activities selected by given query, newest first, restricted to given page
+func (a *ActivityDAO) Find(query dao.ActivityQuery, page dao.Page) ([]models.Activity, error) {
+	activities := []models.Activity{}
+	err := prepareQuery(a.Database, ActivityCollection).Find(activitySelector(query)).
+		Sort("-createdAt", "-_id").Skip(page.Offset).Limit(page.Limit).All(&activities)
+	return activities, err
+}
+
+// Count -> Number of activities selected by given query
+func (a *ActivityDAO) Count(query dao.ActivityQuery) (int, error) {
+	return prepareQuery(a.Database, ActivityCollection).Find(activitySelector(query)).Count()
+}
+
+// Insert an Activity
+func (a *ActivityDAO) Insert(activity *models.Activity) error {
+	return prepareQuery(a.Database, ActivityCollection).Insert(activity)
+}
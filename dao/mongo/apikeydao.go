@@ -0,0 +1,59 @@
+package mongo
+
+import (
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ApiKeyDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	ApiKeyCollection = "apikeys"
+)
+
+// Create an ApiKeyDAO structure and set DAO's database, return new struct
+func NewApiKeyDAO(db *mgo.Database) *ApiKeyDAO {
+	return &ApiKeyDAO{Database: db}
+}
+
+// FindByHash -> Find an API key from the hash of its value
+func (a *ApiKeyDAO) FindByHash(hash string) (models.ApiKey, error) {
+	var apiKey models.ApiKey
+	err := prepareQuery(a.Database, ApiKeyCollection).Find(bson.M{"hash": hash}).One(&apiKey)
+	return apiKey, translateError(err)
+}
+
+// FindByUserAndID -> Find an API key by its id, only if it belongs to given user
+func (a *ApiKeyDAO) FindByUserAndID(userID, apiKeyID bson.ObjectId) (models.ApiKey, error) {
+	var apiKey models.ApiKey
+	err := prepareQuery(a.Database, ApiKeyCollection).Find(bson.M{"_id": apiKeyID, "userId": userID}).One(&apiKey)
+	return apiKey, translateError(err)
+}
+
+// FindByUserID -> API keys of given user, oldest first
+func (a *ApiKeyDAO) FindByUserID(userID bson.ObjectId) ([]models.ApiKey, error) {
+	apiKeys := []models.ApiKey{}
+	err := prepareQuery(a.Database, ApiKeyCollection).Find(bson.M{"userId": userID}).Sort("_id").All(&apiKeys)
+	return apiKeys, err
+}
+
+// Insert an API key, uniqueness of hashes is enforced by an index (see migrations)
+func (a *ApiKeyDAO) Insert(apiKey *models.ApiKey) error {
+	return translateError(prepareQuery(a.Database, ApiKeyCollection).Insert(apiKey))
+}
+
+// Delete an API key
+func (a *ApiKeyDAO) Delete(apiKey *models.ApiKey) error {
+	return translateError(prepareQuery(a.Database, ApiKeyCollection).RemoveId(apiKey.ApiKeyId))
+}
+
+// Touch -> Record that an API key has been used at given time
+func (a *ApiKeyDAO) Touch(apiKeyID bson.ObjectId, usedAt time.Time) error {
+	update := bson.M{"$set": bson.M{"lastUsedAt": usedAt}}
+	return translateError(prepareQuery(a.Database, ApiKeyCollection).UpdateId(apiKeyID, update))
+}
@@ -0,0 +1,65 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type AuditDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	AuditCollection = "audit"
+)
+
+// Create an AuditDAO structure and set DAO's database, return new struct
+func NewAuditDAO(db *mgo.Database) *AuditDAO {
+	return &AuditDAO{Database: db}
+}
+
+// auditSelector -> Mongo selector equivalent to given query
+func auditSelector(query dao.AuditQuery) bson.M {
+	selector := bson.M{}
+	if query.EntityType != "" {
+		selector["entityType"] = query.EntityType
+	}
+	if query.EntityID != "" {
+		selector["entityId"] = query.EntityID
+	}
+	if query.ActorID != "" {
+		selector["actorId"] = query.ActorID
+	}
+
+	createdAt := bson.M{}
+	if query.From != nil {
+		createdAt["$gte"] = *query.From
+	}
+	if query.To != nil {
+		createdAt["$lt"] = *query.To
+	}
+	if len(createdAt) > 0 {
+		selector["createdAt"] = createdAt
+	}
+	return selector
+}
+
+// Find -> Audit entries selected by given query, newest first, restricted to given page
+func (a *AuditDAO) Find(query dao.AuditQuery, page dao.Page) ([]models.AuditEntry, error) {
+	entries := []models.AuditEntry{}
+	err := prepareQuery(a.Database, AuditCollection).Find(auditSelector(query)).
+		Sort("-createdAt", "-_id").Skip(page.Offset).Limit(page.Limit).All(&entries)
+	return entries, err
+}
+
+// Count -> Number of audit entries selected by given query
+func (a *AuditDAO) Count(query dao.AuditQuery) (int, error) {
+	return prepareQuery(a.Database, AuditCollection).Find(auditSelector(query)).Count()
+}
+
+// Insert an AuditEntry
+func (a *AuditDAO) Insert(entry *models.AuditEntry) error {
+	return prepareQuery(a.Database, AuditCollection).Insert(entry)
+}
@@ -0,0 +1,85 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type BoardDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	BoardCollection = "boards"
+)
+
+// Create a BoardDAO structure and set DAO's database, return new struct
+func NewBoardDAO(db *mgo.Database) *BoardDAO {
+	b := &BoardDAO{}
+	b.SetDb(db)
+
+	return b
+}
+
+func (b *BoardDAO) SetDb(db *mgo.Database) {
+	b.Database = db
+}
+
+// FindAll -> Find every Board from the database
+func (b *BoardDAO) FindAll() ([]models.Board, error) {
+	boards := []models.Board{}
+	err := prepareQuery(b.Database, BoardCollection).Find(bson.M{}).Sort("createdAt").All(&boards)
+	return boards, err
+}
+
+// FindByID -> Find a Board by its id
+func (b *BoardDAO) FindByID(boardID bson.ObjectId) (models.Board, error) {
+	var board models.Board
+	err := prepareQuery(b.Database, BoardCollection).FindId(boardID).One(&board)
+	return board, translateError(err)
+}
+
+// FindByIDs -> Find Boards having given ids, oldest first
+func (b *BoardDAO) FindByIDs(boardIDs []bson.ObjectId) ([]models.Board, error) {
+	boards := []models.Board{}
+	err := prepareQuery(b.Database, BoardCollection).Find(bson.M{"_id": bson.M{"$in": boardIDs}}).Sort("createdAt").All(&boards)
+	return boards, err
+}
+
+// Insert a board to the database
+func (b *BoardDAO) Insert(board *models.Board) error {
+	return prepareQuery(b.Database, BoardCollection).Insert(&board)
+}
+
+// Update - Update a Board Entity
+func (b *BoardDAO) Update(board *models.Board) error {
+	return translateError(prepareQuery(b.Database, BoardCollection).UpdateId(board.BoardId, board))
+}
+
+// Delete a board from the database, then everything attached to it (members, labels, activity, comments, tasks and lists)
+func (b *BoardDAO) Delete(board *models.Board) error {
+	if err := prepareQuery(b.Database, BoardCollection).RemoveId(board.BoardId); err != nil {
+		return translateError(err)
+	}
+	if _, err := prepareQuery(b.Database, MemberCollection).RemoveAll(bson.M{"boardId": board.BoardId}); err != nil {
+		return err
+	}
+	if _, err := prepareQuery(b.Database, LabelCollection).RemoveAll(bson.M{"boardId": board.BoardId}); err != nil {
+		return err
+	}
+	if _, err := prepareQuery(b.Database, FilterCollection).RemoveAll(bson.M{"boardId": board.BoardId}); err != nil {
+		return err
+	}
+	if _, err := prepareQuery(b.Database, ActivityCollection).RemoveAll(bson.M{"boardId": board.BoardId}); err != nil {
+		return err
+	}
+	if _, err := prepareQuery(b.Database, CommentCollection).RemoveAll(bson.M{"boardId": board.BoardId}); err != nil {
+		return err
+	}
+	if _, err := prepareQuery(b.Database, TaskCollection).RemoveAll(bson.M{"boardId": board.BoardId}); err != nil {
+		return err
+	}
+	_, err := prepareQuery(b.Database, ListCollection).RemoveAll(bson.M{"boardId": board.BoardId})
+	return err
+}
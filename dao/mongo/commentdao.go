@@ -0,0 +1,77 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type CommentDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	CommentCollection = "comments"
+)
+
+// Create a CommentDAO structure and set DAO's database, return new struct
+func NewCommentDAO(db *mgo.Database) *CommentDAO {
+	return &CommentDAO{Database: db}
+}
+
+// FindByTaskAndID -> Find a Comment by its id, only if it is attached to given task
+func (c *CommentDAO) FindByTaskAndID(taskID, commentID bson.ObjectId) (models.Comment, error) {
+	var comment models.Comment
+	err := prepareQuery(c.Database, CommentCollection).Find(bson.M{"_id": commentID, "taskId": taskID}).One(&comment)
+	return comment, translateError(err)
+}
+
+// FindByTaskID -> Comments of given task, oldest first, restricted to given page
+func (c *CommentDAO) FindByTaskID(taskID bson.ObjectId, page dao.Page) ([]models.Comment, error) {
+	comments := []models.Comment{}
+	err := prepareQuery(c.Database, CommentCollection).Find(bson.M{"taskId": taskID}).
+		Sort("createdAt", "_id").Skip(page.Offset).Limit(page.Limit).All(&comments)
+	return comments, err
+}
+
+// CountByTaskID -> Number of comments of given task
+func (c *CommentDAO) CountByTaskID(taskID bson.ObjectId) (int, error) {
+	return prepareQuery(c.Database, CommentCollection).Find(bson.M{"taskId": taskID}).Count()
+}
+
+// CountByTaskIDs -> Number of comments of each given task, tasks without comments are absent from the map
+func (c *CommentDAO) CountByTaskIDs(taskIDs []bson.ObjectId) (map[bson.ObjectId]int, error) {
+	var results []struct {
+		TaskID bson.ObjectId `bson:"_id"`
+		Count  int           `bson:"count"`
+	}
+	pipeline := []bson.M{
+		{"$match": bson.M{"taskId": bson.M{"$in": taskIDs}}},
+		{"$group": bson.M{"_id": "$taskId", "count": bson.M{"$sum": 1}}},
+	}
+	if err := prepareQuery(c.Database, CommentCollection).Pipe(pipeline).All(&results); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[bson.ObjectId]int, len(results))
+	for _, result := range results {
+		counts[result.TaskID] = result.Count
+	}
+	return counts, nil
+}
+
+// Insert a comment
+func (c *CommentDAO) Insert(comment *models.Comment) error {
+	return translateError(prepareQuery(c.Database, CommentCollection).Insert(comment))
+}
+
+// Update a comment
+func (c *CommentDAO) Update(comment *models.Comment) error {
+	return translateError(prepareQuery(c.Database, CommentCollection).UpdateId(comment.CommentId, comment))
+}
+
+// Delete a comment
+func (c *CommentDAO) Delete(comment *models.Comment) error {
+	return translateError(prepareQuery(c.Database, CommentCollection).RemoveId(comment.CommentId))
+}
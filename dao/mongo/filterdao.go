@@ -0,0 +1,49 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type FilterDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	FilterCollection = "filters"
+)
+
+// Create a FilterDAO structure and set DAO's database, return new struct
+func NewFilterDAO(db *mgo.Database) *FilterDAO {
+	return &FilterDAO{Database: db}
+}
+
+// FindByBoardAndID -> Find a Filter by its id, only if it belongs to given board
+func (f *FilterDAO) FindByBoardAndID(boardID, filterID bson.ObjectId) (models.Filter, error) {
+	var filter models.Filter
+	err := prepareQuery(f.Database, FilterCollection).Find(bson.M{"_id": filterID, "boardId": boardID}).One(&filter)
+	return filter, translateError(err)
+}
+
+// FindByBoardID -> Filters of given board, sorted by name
+func (f *FilterDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Filter, error) {
+	filters := []models.Filter{}
+	err := prepareQuery(f.Database, FilterCollection).Find(bson.M{"boardId": boardID}).Sort("name", "_id").All(&filters)
+	return filters, err
+}
+
+// Insert a Filter
+func (f *FilterDAO) Insert(filter *models.Filter) error {
+	return translateError(prepareQuery(f.Database, FilterCollection).Insert(filter))
+}
+
+// Update a Filter
+func (f *FilterDAO) Update(filter *models.Filter) error {
+	return translateError(prepareQuery(f.Database, FilterCollection).UpdateId(filter.FilterId, filter))
+}
+
+// Delete a Filter
+func (f *FilterDAO) Delete(filter *models.Filter) error {
+	return translateError(prepareQuery(f.Database, FilterCollection).RemoveId(filter.FilterId))
+}
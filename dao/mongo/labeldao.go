@@ -0,0 +1,54 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type LabelDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	LabelCollection = "labels"
+)
+
+// Create a LabelDAO structure and set DAO's database, return new struct
+func NewLabelDAO(db *mgo.Database) *LabelDAO {
+	return &LabelDAO{Database: db}
+}
+
+// FindByBoardAndID -> Find a Label by its id, only if it belongs to given board
+func (l *LabelDAO) FindByBoardAndID(boardID, labelID bson.ObjectId) (models.Label, error) {
+	var label models.Label
+	err := prepareQuery(l.Database, LabelCollection).Find(bson.M{"_id": labelID, "boardId": boardID}).One(&label)
+	return label, translateError(err)
+}
+
+// FindByBoardID -> Labels of given board, sorted by name
+func (l *LabelDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Label, error) {
+	labels := []models.Label{}
+	err := prepareQuery(l.Database, LabelCollection).Find(bson.M{"boardId": boardID}).Sort("name", "_id").All(&labels)
+	return labels, err
+}
+
+// Insert a Label
+func (l *LabelDAO) Insert(label *models.Label) error {
+	return translateError(prepareQuery(l.Database, LabelCollection).Insert(label))
+}
+
+// Update a Label
+func (l *LabelDAO) Update(label *models.Label) error {
+	return translateError(prepareQuery(l.Database, LabelCollection).UpdateId(label.LabelId, label))
+}
+
+// Delete a Label and detach it from every task of its board
+func (l *LabelDAO) Delete(label *models.Label) error {
+	if err := prepareQuery(l.Database, LabelCollection).RemoveId(label.LabelId); err != nil {
+		return translateError(err)
+	}
+	selector := bson.M{"boardId": label.BoardId, "labels": label.LabelId}
+	_, err := prepareQuery(l.Database, TaskCollection).UpdateAll(selector, bson.M{"$pull": bson.M{"labels": label.LabelId}})
+	return err
+}
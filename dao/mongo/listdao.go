@@ -0,0 +1,172 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ListDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	ListCollection = "lists"
+)
+
+// Create a ListDAO structure and set DAO's database, return new struct
+func NewListDAO(db *mgo.Database) *ListDAO {
+	l := &ListDAO{}
+	l.SetDb(db)
+
+	return l
+}
+
+func (l *ListDAO) SetDb(db *mgo.Database) {
+	l.Database = db
+}
+
+// Delete a list from the database, then tasks attached to it (Mongo offers no multi-document transaction here)
+func (l *ListDAO) Delete(list *models.List) error {
+	if err := prepareQuery(l.Database, ListCollection).RemoveId(list.ListId); err != nil {
+		return translateError(err)
+	}
+
+	var taskIDs []bson.ObjectId
+	if err := prepareQuery(l.Database, TaskCollection).Find(bson.M{"listId": list.ListId}).Distinct("_id", &taskIDs); err != nil {
+		return err
+	}
+	if _, err := prepareQuery(l.Database, CommentCollection).RemoveAll(bson.M{"taskId": bson.M{"$in": taskIDs}}); err != nil {
+		return err
+	}
+	_, err := prepareQuery(l.Database, TaskCollection).RemoveAll(bson.M{"listId": list.ListId})
+	return err
+}
+
+// FindByBoardAndID -> Find a List by its id, only if it belongs to given board
+func (l *ListDAO) FindByBoardAndID(boardID, listID bson.ObjectId) (models.List, error) {
+	var list models.List
+	err := prepareQuery(l.Database, ListCollection).Find(bson.M{"_id": listID, "boardId": boardID}).One(&list)
+	return list, translateError(err)
+}
+
+// FindByBoardID -> Find every List attached to given board, sorted by order
+func (l *ListDAO) FindByBoardID(boardID bson.ObjectId) ([]models.List, error) {
+	lists := []models.List{}
+	err := prepareQuery(l.Database, ListCollection).Find(bson.M{"boardId": boardID}).Sort("order").All(&lists)
+	return lists, err
+}
+
+// CountByBoardID -> Count lists attached to given board
+func (l *ListDAO) CountByBoardID(boardID bson.ObjectId) (int, error) {
+	return prepareQuery(l.Database, ListCollection).Find(bson.M{"boardId": boardID}).Count()
+}
+
+// Search -> Lists of the query boards holding at least one of its terms, ranked by the text index of the collection,
+// or like other backends when it does not exist
+func (l *ListDAO) Search(query dao.SearchQuery) ([]dao.ListMatch, error) {
+	if len(query.BoardIDs) == 0 || len(query.Terms) == 0 {
+		return []dao.ListMatch{}, nil
+	}
+
+	var docs []struct {
+		models.List `bson:",inline"`
+		Score       float64 `bson:"score"`
+	}
+	err := prepareQuery(l.Database, ListCollection).Find(textSearchSelector(query)).
+		Select(bson.M{"score": textScore}).Sort("$textScore:score", "order", "_id").Limit(query.Limit).All(&docs)
+	if textIndexMissing(err) {
+		lists := []models.List{}
+		if err := prepareQuery(l.Database, ListCollection).Find(regexSearchSelector(query, "name")).Sort("order", "_id").All(&lists); err != nil {
+			return nil, err
+		}
+		return query.RankLists(lists), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]dao.ListMatch, len(docs))
+	for i, doc := range docs {
+		matches[i] = dao.ListMatch{List: doc.List, Score: doc.Score}
+	}
+	return matches, nil
+}
+
+// FindIDsByBoardID -> Retrieve IDs of lists attached to given board, sorted by order
+func (l *ListDAO) FindIDsByBoardID(boardID bson.ObjectId) ([]bson.ObjectId, error) {
+	var docs []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err := prepareQuery(l.Database, ListCollection).Find(bson.M{"boardId": boardID}).Sort("order").Select(bson.M{"_id": 1}).All(&docs)
+
+	ids := make([]bson.ObjectId, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids, err
+}
+
+// Insert a list to the database
+func (l *ListDAO) Insert(list *models.List) error {
+	err := prepareQuery(l.Database, ListCollection).Insert(&list)
+	return err
+}
+
+// Update - Update a List Entity, except its order which is only modified by Move, Reorder and Renumber
+func (l *ListDAO) Update(list *models.List) error {
+	update, err := entityUpdate(list, []string{"order"}, []string{"createdBy", "updatedBy"})
+	if err != nil {
+		return err
+	}
+	return translateError(prepareQuery(l.Database, ListCollection).UpdateId(list.ListId, update))
+}
+
+// DeleteFromBoardID deletes all lists which are attached to given boardId
+func (l *ListDAO) DeleteFromBoardID(boardID bson.ObjectId) error {
+	_, err := prepareQuery(l.Database, ListCollection).RemoveAll(bson.M{"boardId": boardID})
+	return err
+}
+
+// Reorder lists of a board: given ids (the full set of board's lists) get orders 1..n in a single bulk operation
+func (l *ListDAO) Reorder(ids []bson.ObjectId) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	bulk := prepareQuery(l.Database, ListCollection).Bulk()
+	queueRenumber(bulk, ids)
+	_, err := bulk.Run()
+	return err
+}
+
+// Move a list to given position (starting at 1) of its board, renumbering its siblings
+func (l *ListDAO) Move(list *models.List, position int) error {
+	ids, err := l.FindIDsByBoardID(list.BoardId)
+	if err != nil {
+		return err
+	}
+	ids = dao.InsertAt(dao.RemoveID(ids, list.ListId), list.ListId, position)
+
+	if err := l.Reorder(ids); err != nil {
+		return err
+	}
+
+	// Reflect changes on the moved list
+	for i, id := range ids {
+		if id == list.ListId {
+			list.Order = i + 1
+		}
+	}
+	return nil
+}
+
+// Renumber lists of given board so that their orders are contiguous (1..n), e.g. after a deletion
+func (l *ListDAO) Renumber(boardID bson.ObjectId) error {
+	ids, err := l.FindIDsByBoardID(boardID)
+	if err != nil {
+		return err
+	}
+	return l.Reorder(ids)
+}
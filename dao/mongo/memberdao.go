@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type MemberDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	MemberCollection = "members"
+)
+
+// Create a MemberDAO structure and set DAO's database, return new struct
+func NewMemberDAO(db *mgo.Database) *MemberDAO {
+	return &MemberDAO{Database: db}
+}
+
+func memberSelector(boardID, userID bson.ObjectId) bson.M {
+	return bson.M{"boardId": boardID, "userId": userID}
+}
+
+// FindByBoardAndUser -> Find membership of given user on given board
+func (m *MemberDAO) FindByBoardAndUser(boardID, userID bson.ObjectId) (models.BoardMember, error) {
+	var member models.BoardMember
+	err := prepareQuery(m.Database, MemberCollection).Find(memberSelector(boardID, userID)).One(&member)
+	return member, translateError(err)
+}
+
+// FindByBoardID -> Members of given board, oldest first
+func (m *MemberDAO) FindByBoardID(boardID bson.ObjectId) ([]models.BoardMember, error) {
+	members := []models.BoardMember{}
+	err := prepareQuery(m.Database, MemberCollection).Find(bson.M{"boardId": boardID}).Sort("createdAt").All(&members)
+	return members, err
+}
+
+// FindBoardIDsByUserID -> IDs of boards given user is a member of
+func (m *MemberDAO) FindBoardIDsByUserID(userID bson.ObjectId) ([]bson.ObjectId, error) {
+	ids := []bson.ObjectId{}
+	err := prepareQuery(m.Database, MemberCollection).Find(bson.M{"userId": userID}).Distinct("boardId", &ids)
+	return ids, err
+}
+
+// Insert a membership, uniqueness of (board, user) is enforced by an index (see migrations)
+func (m *MemberDAO) Insert(member *models.BoardMember) error {
+	return translateError(prepareQuery(m.Database, MemberCollection).Insert(member))
+}
+
+// Update a membership
+func (m *MemberDAO) Update(member *models.BoardMember) error {
+	update := bson.M{"$set": bson.M{"role": member.Role}}
+	return translateError(prepareQuery(m.Database, MemberCollection).Update(memberSelector(member.BoardId, member.UserId), update))
+}
+
+// Delete a membership
+func (m *MemberDAO) Delete(member *models.BoardMember) error {
+	return translateError(prepareQuery(m.Database, MemberCollection).Remove(memberSelector(member.BoardId, member.UserId)))
+}
@@ -0,0 +1,424 @@
+package mongo
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// MigrationCollection -> Collection recording applied migration versions
+	MigrationCollection = "migrations"
+)
+
+// migration -> Versioned change of collections (indexes, backfills of documents created before a field existed)
+type migration struct {
+	dao.Migration
+	up func(db *mgo.Database) error
+}
+
+// migrations -> Every change, in version order. Never edit an applied migration: append a new one.
+// Backfills must be idempotent, a migration interrupted before being recorded runs again
+var migrations = []migration{
+	{dao.Migration{Version: 1, Description: "Index lists by board and tasks by list and board"}, createIndexes},
+	{dao.Migration{Version: 2, Description: "Backfill boardId of tasks from their list"}, backfillTaskBoardID},
+	{dao.Migration{Version: 3, Description: "Backfill order of lists and tasks"}, backfillOrders},
+	{dao.Migration{Version: 4, Description: "Backfill creation and update timestamps of boards"}, backfillBoardTimestamps},
+	{dao.Migration{Version: 5, Description: "Unique index on users email"}, createUserEmailIndex},
+	{dao.Migration{Version: 6, Description: "Index board members by board and user"}, createMemberIndexes},
+	{dao.Migration{Version: 7, Description: "Unique index on API keys hash, index API keys by user"}, createApiKeyIndexes},
+	{dao.Migration{Version: 8, Description: "Index comments by task and board"}, createCommentIndexes},
+	{dao.Migration{Version: 9, Description: "Index labels by board, backfill labels of tasks"}, createLabelIndexes},
+	{dao.Migration{Version: 10, Description: "Backfill checklists of tasks"}, backfillTaskChecklists},
+	{dao.Migration{Version: 11, Description: "Index tasks by assignee, backfill assignees and watchers of tasks"}, createAssigneeIndex},
+	{dao.Migration{Version: 12, Description: "Index tasks by board and due date, backfill dueComplete of tasks"}, createDueIndex},
+	{dao.Migration{Version: 13, Description: "Backfill creation and update timestamps of lists, tasks and labels"}, backfillTracking},
+	{dao.Migration{Version: 14, Description: "Index activities by board and by entity"}, createActivityIndexes},
+	{dao.Migration{Version: 15, Description: "Index audit log by time, by entity and by actor"}, createAuditIndexes},
+	{dao.Migration{Version: 16, Description: "Text indexes on task titles and descriptions, and on list names"}, createTextIndexes},
+	{dao.Migration{Version: 17, Description: "Index filters by board and name"}, createFilterIndex},
+	{dao.Migration{Version: 18, Description: "Make creators owners of boards without owner, remove owner of boards"}, unsetBoardOwner},
+}
+
+// backfills -> Changes of migrations depending on dao.MigrationConfig, run before the migration of the same version
+var backfills = map[int]func(db *mgo.Database, config dao.MigrationConfig) error{
+	18: backfillBoardOwners,
+}
+
+// migrationRecord -> Document of the migrations collection
+type migrationRecord struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"appliedAt"`
+}
+
+type Migrator struct {
+	Database *mgo.Database
+	Config   dao.MigrationConfig
+}
+
+// Create a Migrator structure and set its database and configuration, return new struct
+func NewMigrator(db *mgo.Database, config dao.MigrationConfig) *Migrator {
+	return &Migrator{Database: db, Config: config}
+}
+
+func (m *Migrator) pending() ([]migration, error) {
+	var records []migrationRecord
+	if err := prepareQuery(m.Database, MigrationCollection).Find(nil).All(&records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]bool, len(records))
+	for _, record := range records {
+		applied[record.Version] = true
+	}
+
+	pending := []migration{}
+	for _, migration := range migrations {
+		if !applied[migration.Version] {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Pending -> Migrations which have not been applied yet, in version order
+func (m *Migrator) Pending() ([]dao.Migration, error) {
+	pending, err := m.pending()
+	result := make([]dao.Migration, len(pending))
+	for i, migration := range pending {
+		result[i] = migration.Migration
+	}
+	return result, err
+}
+
+// Migrate -> Apply pending migrations in version order, recording each version once applied
+func (m *Migrator) Migrate() ([]dao.Migration, error) {
+	pending, err := m.pending()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []dao.Migration{}
+	for _, migration := range pending {
+		if backfill, ok := backfills[migration.Version]; ok {
+			if err := backfill(m.Database, m.Config); err != nil {
+				return applied, err
+			}
+		}
+		if err := migration.up(m.Database); err != nil {
+			return applied, err
+		}
+		record := migrationRecord{Version: migration.Version, Description: migration.Description, AppliedAt: time.Now().UTC()}
+		if err := prepareQuery(m.Database, MigrationCollection).Insert(&record); err != nil {
+			return applied, err
+		}
+		applied = append(applied, migration.Migration)
+	}
+	return applied, nil
+}
+
+// ---- Migrations ---- //
+
+func createIndexes(db *mgo.Database) error {
+	indexes := map[string][]mgo.Index{
+		ListCollection: {{Key: []string{"boardId", "order"}}},
+		TaskCollection: {{Key: []string{"listId", "order"}}, {Key: []string{"boardId"}}},
+	}
+	for collection, collectionIndexes := range indexes {
+		for _, index := range collectionIndexes {
+			if err := prepareQuery(db, collection).EnsureIndex(index); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func backfillTaskBoardID(db *mgo.Database) error {
+	var listIDs []bson.ObjectId
+	missing := bson.M{"boardId": bson.M{"$exists": false}}
+	if err := prepareQuery(db, TaskCollection).Find(missing).Distinct("listId", &listIDs); err != nil {
+		return err
+	}
+
+	for _, listID := range listIDs {
+		var list struct {
+			BoardId bson.ObjectId `bson:"boardId"`
+		}
+		err := prepareQuery(db, ListCollection).FindId(listID).Select(bson.M{"boardId": 1}).One(&list)
+		if err == mgo.ErrNotFound {
+			// Orphan tasks of a deleted list are left untouched
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		selector := bson.M{"listId": listID, "boardId": bson.M{"$exists": false}}
+		if _, err := prepareQuery(db, TaskCollection).UpdateAll(selector, bson.M{"$set": bson.M{"boardId": list.BoardId}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func backfillOrders(db *mgo.Database) error {
+	if err := backfillCollectionOrders(db, ListCollection, "boardId"); err != nil {
+		return err
+	}
+	return backfillCollectionOrders(db, TaskCollection, "listId")
+}
+
+// backfillCollectionOrders -> Renumber documents of every parent (field parentField) having documents without order:
+// ordered documents keep their relative order, the ones without order are appended by creation date
+func backfillCollectionOrders(db *mgo.Database, collection, parentField string) error {
+	missing := bson.M{"$or": []bson.M{{"order": bson.M{"$exists": false}}, {"order": bson.M{"$lt": 1}}}}
+	var parentIDs []bson.ObjectId
+	if err := prepareQuery(db, collection).Find(missing).Distinct(parentField, &parentIDs); err != nil {
+		return err
+	}
+
+	for _, parentID := range parentIDs {
+		var docs []struct {
+			ID    bson.ObjectId `bson:"_id"`
+			Order int           `bson:"order"`
+		}
+		if err := prepareQuery(db, collection).Find(bson.M{parentField: parentID}).Select(bson.M{"order": 1}).All(&docs); err != nil {
+			return err
+		}
+
+		sort.SliceStable(docs, func(i, j int) bool {
+			a, b := docs[i], docs[j]
+			if (a.Order < 1) != (b.Order < 1) {
+				return b.Order < 1
+			}
+			if a.Order != b.Order {
+				return a.Order < b.Order
+			}
+			return a.ID < b.ID
+		})
+
+		ids := make([]bson.ObjectId, len(docs))
+		for i, doc := range docs {
+			ids[i] = doc.ID
+		}
+		bulk := prepareQuery(db, collection).Bulk()
+		queueRenumber(bulk, ids)
+		if _, err := bulk.Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func backfillBoardTimestamps(db *mgo.Database) error {
+	var boards []struct {
+		ID        bson.ObjectId `bson:"_id"`
+		UpdatedAt time.Time     `bson:"updatedAt"`
+	}
+	missing := bson.M{"createdAt": bson.M{"$exists": false}}
+	if err := prepareQuery(db, BoardCollection).Find(missing).Select(bson.M{"updatedAt": 1}).All(&boards); err != nil {
+		return err
+	}
+
+	for _, board := range boards {
+		// Best guess for the creation date is the one embedded in the ObjectID
+		createdAt := board.ID.Time().UTC()
+		set := bson.M{"createdAt": createdAt}
+		if board.UpdatedAt.IsZero() {
+			set["updatedAt"] = createdAt
+		}
+		if err := prepareQuery(db, BoardCollection).UpdateId(board.ID, bson.M{"$set": set}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createUserEmailIndex(db *mgo.Database) error {
+	return prepareQuery(db, UserCollection).EnsureIndex(mgo.Index{Key: []string{"email"}, Unique: true})
+}
+
+func createMemberIndexes(db *mgo.Database) error {
+	members := prepareQuery(db, MemberCollection)
+	if err := members.EnsureIndex(mgo.Index{Key: []string{"boardId", "userId"}, Unique: true}); err != nil {
+		return err
+	}
+	return members.EnsureIndex(mgo.Index{Key: []string{"userId"}})
+}
+
+func createApiKeyIndexes(db *mgo.Database) error {
+	apiKeys := prepareQuery(db, ApiKeyCollection)
+	if err := apiKeys.EnsureIndex(mgo.Index{Key: []string{"hash"}, Unique: true}); err != nil {
+		return err
+	}
+	return apiKeys.EnsureIndex(mgo.Index{Key: []string{"userId"}})
+}
+
+func createCommentIndexes(db *mgo.Database) error {
+	comments := prepareQuery(db, CommentCollection)
+	if err := comments.EnsureIndex(mgo.Index{Key: []string{"taskId", "createdAt"}}); err != nil {
+		return err
+	}
+	return comments.EnsureIndex(mgo.Index{Key: []string{"boardId"}})
+}
+
+func createLabelIndexes(db *mgo.Database) error {
+	if err := prepareQuery(db, LabelCollection).EnsureIndex(mgo.Index{Key: []string{"boardId", "name"}}); err != nil {
+		return err
+	}
+	if err := prepareQuery(db, TaskCollection).EnsureIndex(mgo.Index{Key: []string{"labels"}}); err != nil {
+		return err
+	}
+	missing := bson.M{"labels": bson.M{"$exists": false}}
+	_, err := prepareQuery(db, TaskCollection).UpdateAll(missing, bson.M{"$set": bson.M{"labels": []bson.ObjectId{}}})
+	return err
+}
+
+func backfillTaskChecklists(db *mgo.Database) error {
+	missing := bson.M{"checklists": bson.M{"$exists": false}}
+	_, err := prepareQuery(db, TaskCollection).UpdateAll(missing, bson.M{"$set": bson.M{"checklists": []bson.M{}}})
+	return err
+}
+
+func createAssigneeIndex(db *mgo.Database) error {
+	tasks := prepareQuery(db, TaskCollection)
+	if err := tasks.EnsureIndex(mgo.Index{Key: []string{"assignees"}}); err != nil {
+		return err
+	}
+	for _, field := range []string{"assignees", "watchers"} {
+		missing := bson.M{field: bson.M{"$exists": false}}
+		if _, err := tasks.UpdateAll(missing, bson.M{"$set": bson.M{field: []bson.ObjectId{}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createDueIndex(db *mgo.Database) error {
+	tasks := prepareQuery(db, TaskCollection)
+	if err := tasks.EnsureIndex(mgo.Index{Key: []string{"boardId", "dueAt"}}); err != nil {
+		return err
+	}
+	_, err := tasks.UpdateAll(bson.M{"dueComplete": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"dueComplete": false}})
+	return err
+}
+
+func backfillTracking(db *mgo.Database) error {
+	for _, collection := range []string{ListCollection, TaskCollection, LabelCollection} {
+		var docs []struct {
+			ID bson.ObjectId `bson:"_id"`
+		}
+		missing := bson.M{"createdAt": bson.M{"$exists": false}}
+		if err := prepareQuery(db, collection).Find(missing).Select(bson.M{"_id": 1}).All(&docs); err != nil {
+			return err
+		}
+
+		for _, doc := range docs {
+			// Best guess for the creation date is the one embedded in the ObjectID, authors are unknown
+			createdAt := doc.ID.Time().UTC()
+			set := bson.M{"createdAt": createdAt, "updatedAt": createdAt}
+			if err := prepareQuery(db, collection).UpdateId(doc.ID, bson.M{"$set": set}); err != nil {
+				return err
+			}
+		}
+	}
+	return prepareQuery(db, TaskCollection).EnsureIndex(mgo.Index{Key: []string{"listId", "createdAt"}})
+}
+
+func createActivityIndexes(db *mgo.Database) error {
+	activities := prepareQuery(db, ActivityCollection)
+	if err := activities.EnsureIndex(mgo.Index{Key: []string{"boardId", "-createdAt"}}); err != nil {
+		return err
+	}
+	return activities.EnsureIndex(mgo.Index{Key: []string{"boardId", "entityType", "entityId", "-createdAt"}})
+}
+
+func createAuditIndexes(db *mgo.Database) error {
+	audit := prepareQuery(db, AuditCollection)
+	for _, key := range [][]string{{"-createdAt"}, {"entityId", "-createdAt"}, {"actorId", "-createdAt"}} {
+		if err := audit.EnsureIndex(mgo.Index{Key: key}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createTextIndexes(db *mgo.Database) error {
+	// No language: words are neither stemmed nor dropped as stop words, matching other backends
+	tasks := mgo.Index{
+		Key:             []string{"$text:title", "$text:description"},
+		Weights:         map[string]int{"title": dao.SearchWeightTitle, "description": dao.SearchWeightDescription},
+		DefaultLanguage: "none",
+	}
+	if err := prepareQuery(db, TaskCollection).EnsureIndex(tasks); err != nil {
+		return err
+	}
+	lists := mgo.Index{Key: []string{"$text:name"}, Weights: map[string]int{"name": dao.SearchWeightTitle}, DefaultLanguage: "none"}
+	return prepareQuery(db, ListCollection).EnsureIndex(lists)
+}
+
+func createFilterIndex(db *mgo.Database) error {
+	return prepareQuery(db, FilterCollection).EnsureIndex(mgo.Index{Key: []string{"boardId", "name"}})
+}
+
+// backfillBoardOwners -> Give an owner to boards which have none: their creator, or the configured board owner when
+// the creator is unknown. Fails when a board would be left without owner, so that it does not become unreachable
+func backfillBoardOwners(db *mgo.Database, config dao.MigrationConfig) error {
+	var boards []struct {
+		ID        bson.ObjectId `bson:"_id"`
+		CreatedBy bson.ObjectId `bson:"createdBy,omitempty"`
+	}
+	if err := prepareQuery(db, BoardCollection).Find(nil).Select(bson.M{"createdBy": 1}).All(&boards); err != nil {
+		return err
+	}
+
+	users := prepareQuery(db, UserCollection)
+	members := prepareQuery(db, MemberCollection)
+	for _, board := range boards {
+		owned, err := members.Find(bson.M{"boardId": board.ID, "role": models.RoleOwner}).Count()
+		if err != nil {
+			return err
+		}
+		if owned > 0 {
+			continue
+		}
+
+		owner := config.BoardOwner
+		if board.CreatedBy != "" {
+			if exists, err := users.FindId(board.CreatedBy).Count(); err != nil {
+				return err
+			} else if exists > 0 {
+				owner = board.CreatedBy
+			}
+		}
+		if owner == "" {
+			return fmt.Errorf("board %s has no owner and no known creator, configure the user owning such boards", board.ID.Hex())
+		}
+		if owner == config.BoardOwner {
+			if exists, err := users.FindId(owner).Count(); err != nil {
+				return err
+			} else if exists == 0 {
+				return fmt.Errorf("configured board owner %s is not a user", owner.Hex())
+			}
+		}
+
+		// The owner may already be a member of the board with another role
+		member := models.NewBoardMember(board.ID, owner, models.RoleOwner)
+		if _, err := members.Upsert(memberSelector(board.ID, owner), bson.M{"$set": bson.M{"role": member.Role}, "$setOnInsert": bson.M{"createdAt": member.CreatedAt}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unsetBoardOwner(db *mgo.Database) error {
+	_, err := prepareQuery(db, BoardCollection).UpdateAll(bson.M{"owner": bson.M{"$exists": true}}, bson.M{"$unset": bson.M{"owner": ""}})
+	return err
+}
@@ -0,0 +1,13 @@
+package mongo
+
+import (
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// queueRenumber -> Queue order updates in bulk, so that documents identified by ids get orders 1..n, following slice order
+func queueRenumber(bulk *mgo.Bulk, ids []bson.ObjectId) {
+	for i, id := range ids {
+		bulk.Update(bson.M{"_id": id}, bson.M{"$set": bson.M{"order": i + 1}})
+	}
+}
@@ -0,0 +1,40 @@
+package mongo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Code of the error returned by MongoDB to $text queries on a collection without text index
+const errCodeIndexNotFound = 27
+
+// textScore -> Projection of the relevance computed by a $text query
+var textScore = bson.M{"$meta": "textScore"}
+
+// textSearchSelector -> Selector of documents of the query boards holding one of its terms, through the collection's text index.
+// Terms are joined back rather than using the raw text, which could hold $text operators (phrases, negations)
+func textSearchSelector(query dao.SearchQuery) bson.M {
+	return bson.M{"boardId": bson.M{"$in": query.BoardIDs}, "$text": bson.M{"$search": strings.Join(query.Terms, " ")}}
+}
+
+// regexSearchSelector -> Selector of documents of the query boards holding one of its terms in given fields, without index
+func regexSearchSelector(query dao.SearchQuery, fields ...string) bson.M {
+	conditions := []bson.M{}
+	for _, term := range query.Terms {
+		for _, field := range fields {
+			conditions = append(conditions, bson.M{field: bson.RegEx{Pattern: regexp.QuoteMeta(term), Options: "i"}})
+		}
+	}
+	return bson.M{"boardId": bson.M{"$in": query.BoardIDs}, "$or": conditions}
+}
+
+// textIndexMissing -> Whether err is MongoDB refusing a $text query because the collection has no text index
+// (e.g. migrations have not been applied), searches then fall back to regular expressions
+func textIndexMissing(err error) bool {
+	queryErr, ok := err.(*mgo.QueryError)
+	return ok && queryErr.Code == errCodeIndexNotFound
+}
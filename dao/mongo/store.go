@@ -0,0 +1,40 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"gopkg.in/mgo.v2"
+)
+
+// NewStore -> Create a dao.Store backed by given Mongo database, migrated with given configuration
+func NewStore(db *mgo.Database, migration dao.MigrationConfig) dao.Store {
+	return dao.Store{
+		Boards:   NewBoardDAO(db),
+		Lists:    NewListDAO(db),
+		Tasks:    NewTaskDAO(db),
+		Comments: NewCommentDAO(db),
+		Labels:   NewLabelDAO(db),
+		Filters:  NewFilterDAO(db),
+		Activity: NewActivityDAO(db),
+		Audit:    NewAuditDAO(db),
+		Users:    NewUserDAO(db),
+		Members:  NewMemberDAO(db),
+		ApiKeys:  NewApiKeyDAO(db),
+		Migrator: NewMigrator(db, migration),
+	}
+}
+
+// Prepare base queries by Initiating connection to the Collection
+func prepareQuery(db *mgo.Database, collection string) *mgo.Collection {
+	return db.C(collection)
+}
+
+// translateError -> Convert mgo errors to their dao equivalent, so that handlers stay backend agnostic
+func translateError(err error) error {
+	if err == mgo.ErrNotFound {
+		return dao.ErrNotFound
+	}
+	if mgo.IsDup(err) {
+		return dao.ErrDuplicate
+	}
+	return err
+}
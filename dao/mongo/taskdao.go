@@ -0,0 +1,518 @@
+package mongo
+
+import (
+	"regexp"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type TaskDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	TaskCollection = "tasks"
+)
+
+// Create a TaskDAO structure and set DAO's database, return new struct
+func NewTaskDAO(db *mgo.Database) *TaskDAO {
+	t := &TaskDAO{}
+	t.SetDb(db)
+
+	return t
+}
+
+func (t *TaskDAO) SetDb(db *mgo.Database) {
+	t.Database = db
+}
+
+// FindByListID -> Find every task attached to given list
+func (t *TaskDAO) FindByListID(listID bson.ObjectId) ([]models.Task, error) {
+	tasks := []models.Task{}
+	err := prepareQuery(t.Database, TaskCollection).Find(bson.M{"listId": listID}).Sort("order").All(&tasks)
+	return tasks, err
+}
+
+// FindByBoardID -> Find every task attached to given board, sorted by order
+func (t *TaskDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Task, error) {
+	tasks := []models.Task{}
+	err := prepareQuery(t.Database, TaskCollection).Find(bson.M{"boardId": boardID}).Sort("order").All(&tasks)
+	return tasks, err
+}
+
+// FindByAssignee -> Find every task given user is assigned to, across boards
+func (t *TaskDAO) FindByAssignee(userID bson.ObjectId) ([]models.Task, error) {
+	tasks := []models.Task{}
+	err := prepareQuery(t.Database, TaskCollection).Find(bson.M{"assignees": userID}).Sort("order", "_id").All(&tasks)
+	return tasks, err
+}
+
+// FindDue -> Find tasks matching given due date query, sorted by due date
+func (t *TaskDAO) FindDue(query dao.DueQuery) ([]models.Task, error) {
+	dueAt := bson.M{"$lt": query.Before}
+	if query.From != nil {
+		dueAt["$gte"] = *query.From
+	}
+	selector := bson.M{"dueAt": dueAt, "dueComplete": bson.M{"$ne": true}}
+	if query.BoardID != "" {
+		selector["boardId"] = query.BoardID
+	}
+	if query.Assignee != "" {
+		selector["assignees"] = query.Assignee
+	}
+
+	tasks := []models.Task{}
+	err := prepareQuery(t.Database, TaskCollection).Find(selector).Sort("dueAt", "_id").All(&tasks)
+	return tasks, err
+}
+
+// taskSortFields -> Document field of each sort field of task queries
+var taskSortFields = map[string]string{
+	dao.TaskSortOrder:     "order",
+	dao.TaskSortTitle:     "title",
+	dao.TaskSortPoints:    "points",
+	dao.TaskSortCreatedAt: "createdAt",
+}
+
+// taskSelector -> Mongo selector equivalent to given query
+func taskSelector(query dao.TaskQuery) bson.M {
+	selector := bson.M{}
+	if query.ListID != "" {
+		selector["listId"] = query.ListID
+	}
+	if query.BoardID != "" {
+		selector["boardId"] = query.BoardID
+	}
+	if query.Status != nil {
+		selector["status"] = *query.Status
+	}
+
+	points := bson.M{}
+	if query.PointsMin != nil {
+		points[comparison("$gt", !query.PointsMinExclusive)] = *query.PointsMin
+	}
+	if query.PointsMax != nil {
+		points[comparison("$lt", !query.PointsMaxExclusive)] = *query.PointsMax
+	}
+	if len(points) > 0 {
+		selector["points"] = points
+	}
+
+	if query.Title != "" {
+		selector["title"] = bson.RegEx{Pattern: regexp.QuoteMeta(query.Title), Options: "i"}
+	}
+	if len(query.Labels) > 0 {
+		selector["labels"] = bson.M{"$all": query.Labels}
+	}
+
+	// Null matches tasks without due date, comparisons never do
+	dueAt := bson.M{}
+	if query.DueAfter != nil {
+		dueAt["$gt"] = *query.DueAfter
+	}
+	if query.DueBefore != nil {
+		dueAt["$lt"] = *query.DueBefore
+	}
+	if query.NoDueDate {
+		dueAt["$eq"] = nil
+	}
+	if len(dueAt) > 0 {
+		selector["dueAt"] = dueAt
+	}
+	return selector
+}
+
+// comparison -> Mongo comparison operator ($gt, $lt), or its inclusive variant ($gte, $lte)
+func comparison(operator string, inclusive bool) string {
+	if inclusive {
+		return operator + "e"
+	}
+	return operator
+}
+
+// Find -> Find tasks matching given query, sorted as requested by the query, restricted to given page
+func (t *TaskDAO) Find(query dao.TaskQuery, page dao.Page) ([]models.Task, error) {
+	field, ok := taskSortFields[query.Sort]
+	if !ok {
+		field = taskSortFields[dao.TaskSortOrder]
+	}
+	if query.Descending {
+		field = "-" + field
+	}
+
+	tasks := []models.Task{}
+	err := prepareQuery(t.Database, TaskCollection).Find(taskSelector(query)).
+		Sort(field, "order", "_id").Skip(page.Offset).Limit(page.Limit).All(&tasks)
+	return tasks, err
+}
+
+// Count -> Number of tasks matching given query
+func (t *TaskDAO) Count(query dao.TaskQuery) (int, error) {
+	return prepareQuery(t.Database, TaskCollection).Find(taskSelector(query)).Count()
+}
+
+// Search -> Tasks of the query boards holding at least one of its terms, ranked by the text index of the collection,
+// or like other backends when it does not exist
+func (t *TaskDAO) Search(query dao.SearchQuery) ([]dao.TaskMatch, error) {
+	if len(query.BoardIDs) == 0 || len(query.Terms) == 0 {
+		return []dao.TaskMatch{}, nil
+	}
+
+	var docs []struct {
+		models.Task `bson:",inline"`
+		Score       float64 `bson:"score"`
+	}
+	err := prepareQuery(t.Database, TaskCollection).Find(textSearchSelector(query)).
+		Select(bson.M{"score": textScore}).Sort("$textScore:score", "order", "_id").Limit(query.Limit).All(&docs)
+	if textIndexMissing(err) {
+		tasks := []models.Task{}
+		if err := prepareQuery(t.Database, TaskCollection).Find(regexSearchSelector(query, "title", "description")).Sort("order", "_id").All(&tasks); err != nil {
+			return nil, err
+		}
+		return query.RankTasks(tasks), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]dao.TaskMatch, len(docs))
+	for i, doc := range docs {
+		matches[i] = dao.TaskMatch{Task: doc.Task, Score: doc.Score}
+	}
+	return matches, nil
+}
+
+// CountByListID -> Count tasks attached to given list
+func (t *TaskDAO) CountByListID(listID bson.ObjectId) (int, error) {
+	return prepareQuery(t.Database, TaskCollection).Find(bson.M{"listId": listID}).Count()
+}
+
+// findIDsByListID -> Retrieve IDs of tasks attached to given list, sorted by order
+func (t *TaskDAO) findIDsByListID(listID bson.ObjectId) ([]bson.ObjectId, error) {
+	var docs []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err := prepareQuery(t.Database, TaskCollection).Find(bson.M{"listId": listID}).Sort("order").Select(bson.M{"_id": 1}).All(&docs)
+
+	ids := make([]bson.ObjectId, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ID
+	}
+	return ids, err
+}
+
+// FindByListAndID -> Find a task by its id, only if it is attached to given list
+func (t *TaskDAO) FindByListAndID(listID, taskID bson.ObjectId) (models.Task, error) {
+	var task models.Task
+	err := prepareQuery(t.Database, TaskCollection).Find(bson.M{"_id": taskID, "listId": listID}).One(&task)
+
+	return task, translateError(err)
+}
+
+func (t *TaskDAO) Delete(task *models.Task) error {
+	if err := prepareQuery(t.Database, TaskCollection).RemoveId(task.TaskId); err != nil {
+		return translateError(err)
+	}
+	_, err := prepareQuery(t.Database, CommentCollection).RemoveAll(bson.M{"taskId": task.TaskId})
+	return err
+}
+
+// entityUpdate -> Update setting every field of an entity but its id and given excluded ones, and removing given optional
+// fields the entity does not have
+func entityUpdate(entity interface{}, excluded, optional []string) (bson.M, error) {
+	var fields bson.M
+	raw, err := bson.Marshal(entity)
+	if err == nil {
+		err = bson.Unmarshal(raw, &fields)
+	}
+	if err != nil {
+		return nil, err
+	}
+	delete(fields, "_id")
+	for _, field := range excluded {
+		delete(fields, field)
+	}
+
+	update := bson.M{"$set": fields}
+	unset := bson.M{}
+	for _, field := range optional {
+		if _, ok := fields[field]; !ok {
+			unset[field] = ""
+		}
+	}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update, nil
+}
+
+// taskUpdate -> Update setting every field of a task but its list and order, which are only modified by Move and MoveAll,
+// its checklists, which are only modified by UpdateChecklists, and its people, only modified by AddPerson and RemovePerson
+func taskUpdate(task *models.Task) (bson.M, error) {
+	return entityUpdate(task, []string{"listId", "order", "checklists", string(dao.Assignees), string(dao.Watchers)}, []string{"startAt", "dueAt", "createdBy", "updatedBy"})
+}
+
+// trackedUpdate -> Update setting given fields along with the last modification of task (see models.Tracking)
+func trackedUpdate(set bson.M, task *models.Task) bson.M {
+	set["updatedAt"] = task.UpdatedAt
+	update := bson.M{"$set": set}
+	if task.UpdatedBy != "" {
+		set["updatedBy"] = task.UpdatedBy
+	} else {
+		update["$unset"] = bson.M{"updatedBy": ""}
+	}
+	return update
+}
+
+// Update a task, except its list, order, checklists, assignees and watchers
+func (t *TaskDAO) Update(task *models.Task) error {
+	update, err := taskUpdate(task)
+	if err != nil {
+		return err
+	}
+	return translateError(prepareQuery(t.Database, TaskCollection).UpdateId(task.TaskId, update))
+}
+
+// UpdateChecklists -> Apply change to the stored task then save its checklists and update tracking, only if the stored
+// checklists are still the ones change was applied to, applying it again otherwise
+func (t *TaskDAO) UpdateChecklists(task *models.Task, change func(task *models.Task) error) error {
+	tasks := prepareQuery(t.Database, TaskCollection)
+	for attempt := 0; attempt < dao.UpdateAttempts; attempt++ {
+		var raw bson.Raw
+		if err := tasks.FindId(task.TaskId).One(&raw); err != nil {
+			return translateError(err)
+		}
+		var stored struct {
+			Checklists bson.Raw `bson:"checklists"`
+		}
+		var updated models.Task
+		if err := raw.Unmarshal(&stored); err != nil {
+			return err
+		}
+		if err := raw.Unmarshal(&updated); err != nil {
+			return err
+		}
+		if err := change(&updated); err != nil {
+			return err
+		}
+
+		// Compare stored checklists as they are, they may be missing on tasks created before checklists existed
+		selector := bson.M{"_id": task.TaskId, "checklists": stored.Checklists}
+		if stored.Checklists.Kind == 0 {
+			selector["checklists"] = bson.M{"$exists": false}
+		}
+		err := tasks.Update(selector, trackedUpdate(bson.M{"checklists": updated.Checklists}, task))
+		if err == mgo.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		updated.UpdatedAt, updated.UpdatedBy = task.UpdatedAt, task.UpdatedBy
+		*task = updated
+		return nil
+	}
+	return dao.ErrConflict
+}
+
+// AddPerson -> Add user to given people of the stored task with $addToSet, unless it already is one of them
+func (t *TaskDAO) AddPerson(task *models.Task, people dao.TaskPeople, userID bson.ObjectId) (bool, error) {
+	selector := bson.M{"_id": task.TaskId, string(people): bson.M{"$ne": userID}}
+	return t.changePeople(task, selector, bson.M{"$addToSet": bson.M{string(people): userID}})
+}
+
+// RemovePerson -> Remove user from given people of the stored task with $pull
+func (t *TaskDAO) RemovePerson(task *models.Task, people dao.TaskPeople, userID bson.ObjectId) (bool, error) {
+	selector := bson.M{"_id": task.TaskId, string(people): userID}
+	return t.changePeople(task, selector, bson.M{"$pull": bson.M{string(people): userID}})
+}
+
+// changePeople -> Apply change along with update tracking to the task matching selector, set task to the saved task.
+// Nothing is changed when no task matches selector, unless the task does not exist it is not an error
+func (t *TaskDAO) changePeople(task *models.Task, selector, change bson.M) (bool, error) {
+	tasks := prepareQuery(t.Database, TaskCollection)
+	update := trackedUpdate(bson.M{}, task)
+	for operator, fields := range change {
+		update[operator] = fields
+	}
+
+	var saved models.Task
+	_, err := tasks.Find(selector).Apply(mgo.Change{Update: update, ReturnNew: true}, &saved)
+	if err == mgo.ErrNotFound {
+		if err := tasks.FindId(task.TaskId).One(&saved); err != nil {
+			return false, translateError(err)
+		}
+		*task = saved
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	*task = saved
+	return true, nil
+}
+
+func (t *TaskDAO) Insert(task *models.Task) error {
+	return prepareQuery(t.Database, TaskCollection).Insert(&task)
+}
+
+// DeleteFromListID deletes all tasks which are attached to given listId
+func (t *TaskDAO) DeleteFromListID(listID bson.ObjectId) error {
+	_, err := prepareQuery(t.Database, TaskCollection).RemoveAll(bson.M{"listId": listID})
+	return err
+}
+
+// DeleteFromBoardID deletes all tasks which are attached to given boardId
+func (t *TaskDAO) DeleteFromBoardID(boardID bson.ObjectId) error {
+	_, err := prepareQuery(t.Database, TaskCollection).RemoveAll(bson.M{"boardId": boardID})
+	return err
+}
+
+// Move a task to given position (starting at 1) of target list, source and target lists' tasks are renumbered in a single bulk operation
+func (t *TaskDAO) Move(task *models.Task, targetListID bson.ObjectId, position int) error {
+	sourceIDs, err := t.findIDsByListID(task.ListId)
+	if err != nil {
+		return err
+	}
+	sourceIDs = dao.RemoveID(sourceIDs, task.TaskId)
+
+	targetIDs := sourceIDs
+	if targetListID != task.ListId {
+		if targetIDs, err = t.findIDsByListID(targetListID); err != nil {
+			return err
+		}
+	}
+	targetIDs = dao.InsertAt(targetIDs, task.TaskId, position)
+
+	bulk := prepareQuery(t.Database, TaskCollection).Bulk()
+	bulk.Update(bson.M{"_id": task.TaskId}, trackedUpdate(bson.M{"listId": targetListID}, task))
+	if targetListID != task.ListId {
+		queueRenumber(bulk, sourceIDs)
+	}
+	queueRenumber(bulk, targetIDs)
+
+	if _, err := bulk.Run(); err != nil {
+		return err
+	}
+
+	// Reflect changes on the moved task
+	for i, id := range targetIDs {
+		if id == task.TaskId {
+			task.Order = i + 1
+		}
+	}
+	task.ListId = targetListID
+	return nil
+}
+
+// Renumber tasks of given list so that their orders are contiguous (1..n), e.g. after a deletion
+func (t *TaskDAO) Renumber(listID bson.ObjectId) error {
+	ids, err := t.findIDsByListID(listID)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+
+	bulk := prepareQuery(t.Database, TaskCollection).Bulk()
+	queueRenumber(bulk, ids)
+	_, err = bulk.Run()
+	return err
+}
+
+// UpdateAll -> Update given tasks in a single bulk operation, which is not atomic: it stops at the first failing task
+func (t *TaskDAO) UpdateAll(tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	bulk := prepareQuery(t.Database, TaskCollection).Bulk()
+	for i := range tasks {
+		update, err := taskUpdate(&tasks[i])
+		if err != nil {
+			return err
+		}
+		bulk.Update(bson.M{"_id": tasks[i].TaskId}, update)
+	}
+	_, err := bulk.Run()
+	return err
+}
+
+// MoveAll -> Move given tasks at the end of target list, in given order, tasks are moved and lists renumbered in a single bulk operation
+func (t *TaskDAO) MoveAll(tasks []models.Task, targetListID bson.ObjectId) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	targetIDs, err := t.findIDsByListID(targetListID)
+	if err != nil {
+		return err
+	}
+
+	bulk := prepareQuery(t.Database, TaskCollection).Bulk()
+	sources := map[bson.ObjectId][]bson.ObjectId{}
+	for i, task := range tasks {
+		if _, ok := sources[task.ListId]; !ok && task.ListId != targetListID {
+			if sources[task.ListId], err = t.findIDsByListID(task.ListId); err != nil {
+				return err
+			}
+		}
+		targetIDs = append(dao.RemoveID(targetIDs, task.TaskId), task.TaskId)
+		bulk.Update(bson.M{"_id": task.TaskId}, trackedUpdate(bson.M{"listId": targetListID}, &tasks[i]))
+	}
+
+	for _, ids := range sources {
+		for _, task := range tasks {
+			ids = dao.RemoveID(ids, task.TaskId)
+		}
+		queueRenumber(bulk, ids)
+	}
+	queueRenumber(bulk, targetIDs)
+
+	if _, err := bulk.Run(); err != nil {
+		return err
+	}
+
+	// Reflect changes on the moved tasks
+	for i := range tasks {
+		for order, id := range targetIDs {
+			if id == tasks[i].TaskId {
+				tasks[i].Order = order + 1
+			}
+		}
+		tasks[i].ListId = targetListID
+	}
+	return nil
+}
+
+// DeleteAll -> Delete given tasks along with their comments, then renumber the lists they leave
+func (t *TaskDAO) DeleteAll(tasks []models.Task) error {
+	ids := make([]bson.ObjectId, len(tasks))
+	lists := map[bson.ObjectId]bool{}
+	for i, task := range tasks {
+		ids[i] = task.TaskId
+		lists[task.ListId] = true
+	}
+
+	if _, err := prepareQuery(t.Database, TaskCollection).RemoveAll(bson.M{"_id": bson.M{"$in": ids}}); err != nil {
+		return err
+	}
+	if _, err := prepareQuery(t.Database, CommentCollection).RemoveAll(bson.M{"taskId": bson.M{"$in": ids}}); err != nil {
+		return err
+	}
+	for listID := range lists {
+		if err := t.Renumber(listID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RemoveUser -> Unassign given user from every task of a board, and stop it watching them
+func (t *TaskDAO) RemoveUser(boardID, userID bson.ObjectId) error {
+	selector := bson.M{"boardId": boardID, "$or": []bson.M{{"assignees": userID}, {"watchers": userID}}}
+	update := bson.M{"$pull": bson.M{"assignees": userID, "watchers": userID}}
+	_, err := prepareQuery(t.Database, TaskCollection).UpdateAll(selector, update)
+	return err
+}
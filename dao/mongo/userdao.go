@@ -0,0 +1,39 @@
+package mongo
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type UserDAO struct {
+	Database *mgo.Database
+}
+
+const (
+	UserCollection = "users"
+)
+
+// Create a UserDAO structure and set DAO's database, return new struct
+func NewUserDAO(db *mgo.Database) *UserDAO {
+	return &UserDAO{Database: db}
+}
+
+// FindByID -> Find a User by its id
+func (u *UserDAO) FindByID(userID bson.ObjectId) (models.User, error) {
+	var user models.User
+	err := prepareQuery(u.Database, UserCollection).FindId(userID).One(&user)
+	return user, translateError(err)
+}
+
+// FindByEmail -> Find a User by its email
+func (u *UserDAO) FindByEmail(email string) (models.User, error) {
+	var user models.User
+	err := prepareQuery(u.Database, UserCollection).Find(bson.M{"email": email}).One(&user)
+	return user, translateError(err)
+}
+
+// Insert a User to the database, email uniqueness is enforced by an index (see migrations)
+func (u *UserDAO) Insert(user *models.User) error {
+	return translateError(prepareQuery(u.Database, UserCollection).Insert(user))
+}
@@ -0,0 +1,41 @@
+package dao
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Helpers shared by Store implementations to compute orderings, orders start at 1
+
+// InsertAt -> Insert id in the ordered slice ids at given position (starting at 1), position is clamped to slice boundaries
+func InsertAt(ids []bson.ObjectId, id bson.ObjectId, position int) []bson.ObjectId {
+	if position < 1 || position > len(ids)+1 {
+		position = len(ids) + 1
+	}
+	index := position - 1
+
+	result := make([]bson.ObjectId, 0, len(ids)+1)
+	result = append(result, ids[:index]...)
+	result = append(result, id)
+	return append(result, ids[index:]...)
+}
+
+// HasID -> Whether ids holds given id
+func HasID(ids []bson.ObjectId, id bson.ObjectId) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveID -> Return a copy of ids without given id
+func RemoveID(ids []bson.ObjectId, id bson.ObjectId) []bson.ObjectId {
+	result := make([]bson.ObjectId, 0, len(ids))
+	for _, v := range ids {
+		if v != id {
+			result = append(result, v)
+		}
+	}
+	return result
+}
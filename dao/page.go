@@ -0,0 +1,7 @@
+package dao
+
+// Page -> Window of a paginated listing: skip Offset entities, then return at most Limit of them
+type Page struct {
+	Offset int
+	Limit  int
+}
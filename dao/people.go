@@ -0,0 +1,22 @@
+package dao
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// TaskPeople -> Users attached to a task by AddPerson and RemovePerson: its assignees or its watchers
+type TaskPeople string
+
+const (
+	Assignees TaskPeople = "assignees"
+	Watchers  TaskPeople = "watchers"
+)
+
+// Of -> Given people of a task
+func (p TaskPeople) Of(task *models.Task) *[]bson.ObjectId {
+	if p == Watchers {
+		return &task.Watchers
+	}
+	return &task.Assignees
+}
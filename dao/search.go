@@ -0,0 +1,106 @@
+package dao
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Weights of searched fields: a term found in a task title or a list name counts more than in a task description
+const (
+	SearchWeightTitle       = 2
+	SearchWeightDescription = 1
+)
+
+// SearchQuery -> Full-text search of tasks (title, description) and lists (name) of given boards.
+// Entities match when they hold at least one of the terms, case insensitively
+type SearchQuery struct {
+	BoardIDs []bson.ObjectId
+	// Distinct lower case words searched, see SearchTerms
+	Terms []string
+	// Maximum number of matches, best first
+	Limit int
+}
+
+// TaskMatch -> Task matching a search, along with its relevance (the higher the better)
+type TaskMatch struct {
+	Task  models.Task
+	Score float64
+}
+
+// ListMatch -> List matching a search, along with its relevance (the higher the better)
+type ListMatch struct {
+	List  models.List
+	Score float64
+}
+
+// FoldCase -> Lower case text, rune by rune so that positions in the folded text match the original ones
+func FoldCase(text string) string {
+	return strings.Map(unicode.ToLower, text)
+}
+
+// SearchTerms -> Distinct lower case words (letters and digits) of given text, in order of appearance
+func SearchTerms(text string) []string {
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, word := range strings.FieldsFunc(FoldCase(text), func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// score -> Relevance of given text for the query terms: occurrences of each term, times the weight of the field
+func (q SearchQuery) score(text string, weight float64) float64 {
+	folded := FoldCase(text)
+	score := 0.0
+	for _, term := range q.Terms {
+		score += weight * float64(strings.Count(folded, term))
+	}
+	return score
+}
+
+// TaskScore -> Relevance of given task for the query, 0 when it does not match
+func (q SearchQuery) TaskScore(task models.Task) float64 {
+	return q.score(task.Title, SearchWeightTitle) + q.score(task.Description, SearchWeightDescription)
+}
+
+// ListScore -> Relevance of given list for the query, 0 when it does not match
+func (q SearchQuery) ListScore(list models.List) float64 {
+	return q.score(list.Name, SearchWeightTitle)
+}
+
+// RankTasks -> Matches among given tasks, best first (by order on equal relevance), restricted to the query limit
+func (q SearchQuery) RankTasks(tasks []models.Task) []TaskMatch {
+	matches := []TaskMatch{}
+	for _, task := range tasks {
+		if score := q.TaskScore(task); score > 0 {
+			matches = append(matches, TaskMatch{Task: task, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+	return matches
+}
+
+// RankLists -> Matches among given lists, best first (by order on equal relevance), restricted to the query limit
+func (q SearchQuery) RankLists(lists []models.List) []ListMatch {
+	matches := []ListMatch{}
+	for _, list := range lists {
+		if score := q.ListScore(list); score > 0 {
+			matches = append(matches, ListMatch{List: list, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > q.Limit {
+		matches = matches[:q.Limit]
+	}
+	return matches
+}
@@ -0,0 +1,106 @@
+package sqlstore
+
+import (
+	"encoding/json"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ActivityDAO struct {
+	db *DB
+}
+
+const activityColumns = "id, board_id, actor_id, verb, entity_type, entity_id, before_summary, after_summary, created_at"
+
+func scanActivity(row scanner) (models.Activity, error) {
+	var activity models.Activity
+	var id, boardID, actorID, entityID objectID
+	var before, after *string
+	err := row.Scan(&id, &boardID, &actorID, &activity.Verb, &activity.EntityType, &entityID, &before, &after, &activity.CreatedAt)
+	if err != nil {
+		return activity, err
+	}
+	activity.ActivityId, activity.BoardId, activity.ActorId = bson.ObjectId(id), bson.ObjectId(boardID), bson.ObjectId(actorID)
+	activity.EntityId, activity.CreatedAt = bson.ObjectId(entityID), activity.CreatedAt.UTC()
+
+	if activity.Before, err = decodeSummary(before); err != nil {
+		return activity, err
+	}
+	activity.After, err = decodeSummary(after)
+	return activity, err
+}
+
+// decodeSummary -> Activity summary from the JSON document stored in a nullable column
+func decodeSummary(encoded *string) (models.ActivitySummary, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+	var summary models.ActivitySummary
+	err := json.Unmarshal([]byte(*encoded), &summary)
+	return summary, err
+}
+
+// encodeSummary -> JSON document stored for an activity summary, NULL when there is none
+func encodeSummary(summary models.ActivitySummary) (interface{}, error) {
+	if summary == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(summary)
+	return string(encoded), err
+}
+
+// activityWhere -> SQL condition equivalent to given query, along with its arguments
+func activityWhere(query dao.ActivityQuery) (string, []interface{}) {
+	if query.EntityID == "" {
+		return "board_id = ?", []interface{}{query.BoardID.Hex()}
+	}
+	return "board_id = ? AND entity_type = ? AND entity_id = ?", []interface{}{query.BoardID.Hex(), query.EntityType, query.EntityID.Hex()}
+}
+
+// Find -> Activities selected by given query, newest first, restricted to given page
+func (a *ActivityDAO) Find(query dao.ActivityQuery, page dao.Page) ([]models.Activity, error) {
+	where, args := activityWhere(query)
+	rows, err := a.db.conn().query("SELECT "+activityColumns+" FROM activities WHERE "+where+" ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	activities := []models.Activity{}
+	for rows.Next() {
+		activity, err := scanActivity(rows)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, activity)
+	}
+	return activities, rows.Err()
+}
+
+// Count -> Number of activities selected by given query
+func (a *ActivityDAO) Count(query dao.ActivityQuery) (int, error) {
+	var count int
+	where, args := activityWhere(query)
+	err := a.db.conn().queryRow("SELECT COUNT(*) FROM activities WHERE "+where, args...).Scan(&count)
+	return count, err
+}
+
+// Insert an Activity
+func (a *ActivityDAO) Insert(activity *models.Activity) error {
+	before, err := encodeSummary(activity.Before)
+	if err != nil {
+		return err
+	}
+	after, err := encodeSummary(activity.After)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.db.conn().exec("INSERT INTO activities ("+activityColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		activity.ActivityId.Hex(), activity.BoardId.Hex(), activity.ActorId.Hex(), activity.Verb, activity.EntityType, activity.EntityId.Hex(),
+		before, after, activity.CreatedAt)
+	return translateError(err)
+}
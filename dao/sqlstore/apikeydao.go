@@ -0,0 +1,83 @@
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ApiKeyDAO struct {
+	db *DB
+}
+
+const apiKeyColumns = "id, user_id, name, scope, prefix, hash, expires_at, last_used_at, created_at"
+
+func scanApiKey(row scanner) (models.ApiKey, error) {
+	var apiKey models.ApiKey
+	var id, userID objectID
+	err := row.Scan(&id, &userID, &apiKey.Name, &apiKey.Scope, &apiKey.Prefix, &apiKey.Hash,
+		&apiKey.ExpiresAt, &apiKey.LastUsedAt, &apiKey.CreatedAt)
+	apiKey.ApiKeyId, apiKey.UserId = bson.ObjectId(id), bson.ObjectId(userID)
+	apiKey.ExpiresAt, apiKey.LastUsedAt = utcOrNil(apiKey.ExpiresAt), utcOrNil(apiKey.LastUsedAt)
+	apiKey.CreatedAt = apiKey.CreatedAt.UTC()
+	return apiKey, err
+}
+
+// utcOrNil -> Convert a nullable timestamp to UTC
+func utcOrNil(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// FindByHash -> Find an API key from the hash of its value
+func (a *ApiKeyDAO) FindByHash(hash string) (models.ApiKey, error) {
+	apiKey, err := scanApiKey(a.db.conn().queryRow("SELECT "+apiKeyColumns+" FROM api_keys WHERE hash = ?", hash))
+	return apiKey, translateError(err)
+}
+
+// FindByUserAndID -> Find an API key by its id, only if it belongs to given user
+func (a *ApiKeyDAO) FindByUserAndID(userID, apiKeyID bson.ObjectId) (models.ApiKey, error) {
+	apiKey, err := scanApiKey(a.db.conn().queryRow("SELECT "+apiKeyColumns+" FROM api_keys WHERE id = ? AND user_id = ?", apiKeyID.Hex(), userID.Hex()))
+	return apiKey, translateError(err)
+}
+
+// FindByUserID -> API keys of given user, oldest first
+func (a *ApiKeyDAO) FindByUserID(userID bson.ObjectId) ([]models.ApiKey, error) {
+	rows, err := a.db.conn().query("SELECT "+apiKeyColumns+" FROM api_keys WHERE user_id = ? ORDER BY id", userID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys := []models.ApiKey{}
+	for rows.Next() {
+		apiKey, err := scanApiKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, apiKey)
+	}
+	return apiKeys, rows.Err()
+}
+
+// Insert an API key, uniqueness of hashes is enforced by a constraint
+func (a *ApiKeyDAO) Insert(apiKey *models.ApiKey) error {
+	_, err := a.db.conn().exec("INSERT INTO api_keys ("+apiKeyColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		apiKey.ApiKeyId.Hex(), apiKey.UserId.Hex(), apiKey.Name, apiKey.Scope, apiKey.Prefix, apiKey.Hash,
+		apiKey.ExpiresAt, apiKey.LastUsedAt, apiKey.CreatedAt)
+	return translateError(err)
+}
+
+// Delete an API key
+func (a *ApiKeyDAO) Delete(apiKey *models.ApiKey) error {
+	return a.db.conn().execAffecting("DELETE FROM api_keys WHERE id = ?", apiKey.ApiKeyId.Hex())
+}
+
+// Touch -> Record that an API key has been used at given time
+func (a *ApiKeyDAO) Touch(apiKeyID bson.ObjectId, usedAt time.Time) error {
+	return a.db.conn().execAffecting("UPDATE api_keys SET last_used_at = ? WHERE id = ?", usedAt, apiKeyID.Hex())
+}
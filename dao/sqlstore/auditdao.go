@@ -0,0 +1,132 @@
+package sqlstore
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type AuditDAO struct {
+	db *DB
+}
+
+const auditColumns = "id, action, entity_type, entity_id, board_id, actor_id, api_key_id, request_id, client_ip, " +
+	"before_snapshot, after_snapshot, changed_fields, created_at"
+
+func scanAuditEntry(row scanner) (models.AuditEntry, error) {
+	var entry models.AuditEntry
+	var id, entityID, actorID objectID
+	var boardID, apiKeyID nullObjectID
+	var before, after *string
+	var changed string
+	err := row.Scan(&id, &entry.Action, &entry.EntityType, &entityID, &boardID, &actorID, &apiKeyID, &entry.RequestId, &entry.ClientIP,
+		&before, &after, &changed, &entry.CreatedAt)
+	if err != nil {
+		return entry, err
+	}
+	entry.AuditId, entry.EntityId, entry.BoardId = bson.ObjectId(id), bson.ObjectId(entityID), bson.ObjectId(boardID)
+	entry.ActorId, entry.ApiKeyId, entry.CreatedAt = bson.ObjectId(actorID), bson.ObjectId(apiKeyID), entry.CreatedAt.UTC()
+
+	if entry.Before, err = decodeSnapshot(before); err != nil {
+		return entry, err
+	}
+	if entry.After, err = decodeSnapshot(after); err != nil {
+		return entry, err
+	}
+	err = json.Unmarshal([]byte(changed), &entry.ChangedFields)
+	return entry, err
+}
+
+// decodeSnapshot -> Audit snapshot from the JSON document stored in a nullable column
+func decodeSnapshot(encoded *string) (models.Snapshot, error) {
+	if encoded == nil {
+		return nil, nil
+	}
+	var snapshot models.Snapshot
+	err := json.Unmarshal([]byte(*encoded), &snapshot)
+	return snapshot, err
+}
+
+// encodeSnapshot -> JSON document stored for an audit snapshot, NULL when there is none
+func encodeSnapshot(snapshot models.Snapshot) (interface{}, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	encoded, err := json.Marshal(snapshot)
+	return string(encoded), err
+}
+
+// auditWhere -> SQL condition equivalent to given query, along with its arguments
+func auditWhere(query dao.AuditQuery) (string, []interface{}) {
+	conditions := []string{"1 = 1"}
+	args := []interface{}{}
+	if query.EntityType != "" {
+		conditions, args = append(conditions, "entity_type = ?"), append(args, query.EntityType)
+	}
+	if query.EntityID != "" {
+		conditions, args = append(conditions, "entity_id = ?"), append(args, query.EntityID.Hex())
+	}
+	if query.ActorID != "" {
+		conditions, args = append(conditions, "actor_id = ?"), append(args, query.ActorID.Hex())
+	}
+	if query.From != nil {
+		conditions, args = append(conditions, "created_at >= ?"), append(args, query.From.UTC())
+	}
+	if query.To != nil {
+		conditions, args = append(conditions, "created_at < ?"), append(args, query.To.UTC())
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// Find -> Audit entries selected by given query, newest first, restricted to given page
+func (a *AuditDAO) Find(query dao.AuditQuery, page dao.Page) ([]models.AuditEntry, error) {
+	where, args := auditWhere(query)
+	rows, err := a.db.conn().query("SELECT "+auditColumns+" FROM audit_log WHERE "+where+" ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?",
+		append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// Count -> Number of audit entries selected by given query
+func (a *AuditDAO) Count(query dao.AuditQuery) (int, error) {
+	var count int
+	where, args := auditWhere(query)
+	err := a.db.conn().queryRow("SELECT COUNT(*) FROM audit_log WHERE "+where, args...).Scan(&count)
+	return count, err
+}
+
+// Insert an AuditEntry
+func (a *AuditDAO) Insert(entry *models.AuditEntry) error {
+	before, err := encodeSnapshot(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := encodeSnapshot(entry.After)
+	if err != nil {
+		return err
+	}
+	changed, err := json.Marshal(entry.ChangedFields)
+	if err != nil {
+		return err
+	}
+
+	_, err = a.db.conn().exec("INSERT INTO audit_log ("+auditColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		entry.AuditId.Hex(), entry.Action, entry.EntityType, entry.EntityId.Hex(), hexOrNil(entry.BoardId), entry.ActorId.Hex(),
+		hexOrNil(entry.ApiKeyId), entry.RequestId, entry.ClientIP, before, after, string(changed), entry.CreatedAt)
+	return translateError(err)
+}
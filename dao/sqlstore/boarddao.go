@@ -0,0 +1,77 @@
+package sqlstore
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type BoardDAO struct {
+	db *DB
+}
+
+const boardColumns = "id, name, description, " + trackingColumns
+
+func scanBoard(row scanner) (models.Board, error) {
+	var board models.Board
+	var id objectID
+	var tracking trackingRow
+	err := row.Scan(append([]interface{}{&id, &board.Name, &board.Description}, tracking.dest()...)...)
+	board.BoardId, board.Tracking = bson.ObjectId(id), tracking.tracking()
+	return board, err
+}
+
+// FindAll -> Find every Board, oldest first
+func (b *BoardDAO) FindAll() ([]models.Board, error) {
+	return b.findBoards("1 = 1")
+}
+
+// FindByIDs -> Find Boards having given ids, oldest first
+func (b *BoardDAO) FindByIDs(boardIDs []bson.ObjectId) ([]models.Board, error) {
+	if len(boardIDs) == 0 {
+		return []models.Board{}, nil
+	}
+	marks, args := placeholders(boardIDs)
+	return b.findBoards("id IN ("+marks+")", args...)
+}
+
+func (b *BoardDAO) findBoards(where string, args ...interface{}) ([]models.Board, error) {
+	rows, err := b.db.conn().query("SELECT "+boardColumns+" FROM boards WHERE "+where+" ORDER BY created_at, id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	boards := []models.Board{}
+	for rows.Next() {
+		board, err := scanBoard(rows)
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, board)
+	}
+	return boards, rows.Err()
+}
+
+// FindByID -> Find a Board by its id
+func (b *BoardDAO) FindByID(boardID bson.ObjectId) (models.Board, error) {
+	board, err := scanBoard(b.db.conn().queryRow("SELECT "+boardColumns+" FROM boards WHERE id = ?", boardID.Hex()))
+	return board, translateError(err)
+}
+
+// Insert a Board
+func (b *BoardDAO) Insert(board *models.Board) error {
+	_, err := b.db.conn().exec("INSERT INTO boards ("+boardColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		append([]interface{}{board.BoardId.Hex(), board.Name, board.Description}, trackingValues(board.Tracking)...)...)
+	return translateError(err)
+}
+
+// Update a Board
+func (b *BoardDAO) Update(board *models.Board) error {
+	return b.db.conn().execAffecting("UPDATE boards SET name = ?, description = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+		board.Name, board.Description, board.UpdatedAt, hexOrNil(board.UpdatedBy), board.BoardId.Hex())
+}
+
+// Delete a Board, its lists, their tasks and its members are removed along with it by the database (ON DELETE CASCADE)
+func (b *BoardDAO) Delete(board *models.Board) error {
+	return b.db.conn().execAffecting("DELETE FROM boards WHERE id = ?", board.BoardId.Hex())
+}
@@ -0,0 +1,100 @@
+package sqlstore
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type CommentDAO struct {
+	db *DB
+}
+
+const commentColumns = "id, task_id, board_id, author_id, body, created_at, updated_at"
+
+func scanComment(row scanner) (models.Comment, error) {
+	var comment models.Comment
+	var id, taskID, boardID, authorID objectID
+	err := row.Scan(&id, &taskID, &boardID, &authorID, &comment.Body, &comment.CreatedAt, &comment.UpdatedAt)
+	comment.CommentId, comment.TaskId = bson.ObjectId(id), bson.ObjectId(taskID)
+	comment.BoardId, comment.AuthorId = bson.ObjectId(boardID), bson.ObjectId(authorID)
+	comment.CreatedAt, comment.UpdatedAt = comment.CreatedAt.UTC(), comment.UpdatedAt.UTC()
+	return comment, err
+}
+
+// FindByTaskAndID -> Find a Comment by its id, only if it is attached to given task
+func (c *CommentDAO) FindByTaskAndID(taskID, commentID bson.ObjectId) (models.Comment, error) {
+	comment, err := scanComment(c.db.conn().queryRow("SELECT "+commentColumns+" FROM comments WHERE id = ? AND task_id = ?", commentID.Hex(), taskID.Hex()))
+	return comment, translateError(err)
+}
+
+// FindByTaskID -> Comments of given task, oldest first, restricted to given page
+func (c *CommentDAO) FindByTaskID(taskID bson.ObjectId, page dao.Page) ([]models.Comment, error) {
+	rows, err := c.db.conn().query("SELECT "+commentColumns+" FROM comments WHERE task_id = ? ORDER BY created_at, id LIMIT ? OFFSET ?",
+		taskID.Hex(), page.Limit, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+// CountByTaskID -> Number of comments of given task
+func (c *CommentDAO) CountByTaskID(taskID bson.ObjectId) (int, error) {
+	var count int
+	err := c.db.conn().queryRow("SELECT COUNT(*) FROM comments WHERE task_id = ?", taskID.Hex()).Scan(&count)
+	return count, err
+}
+
+// CountByTaskIDs -> Number of comments of each given task, tasks without comments are absent from the map
+func (c *CommentDAO) CountByTaskIDs(taskIDs []bson.ObjectId) (map[bson.ObjectId]int, error) {
+	counts := make(map[bson.ObjectId]int)
+	if len(taskIDs) == 0 {
+		return counts, nil
+	}
+
+	marks, args := placeholders(taskIDs)
+	rows, err := c.db.conn().query("SELECT task_id, COUNT(*) FROM comments WHERE task_id IN ("+marks+") GROUP BY task_id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID objectID
+		var count int
+		if err := rows.Scan(&taskID, &count); err != nil {
+			return nil, err
+		}
+		counts[bson.ObjectId(taskID)] = count
+	}
+	return counts, rows.Err()
+}
+
+// Insert a comment
+func (c *CommentDAO) Insert(comment *models.Comment) error {
+	_, err := c.db.conn().exec("INSERT INTO comments ("+commentColumns+") VALUES (?, ?, ?, ?, ?, ?, ?)",
+		comment.CommentId.Hex(), comment.TaskId.Hex(), comment.BoardId.Hex(), comment.AuthorId.Hex(),
+		comment.Body, comment.CreatedAt, comment.UpdatedAt)
+	return translateError(err)
+}
+
+// Update a comment
+func (c *CommentDAO) Update(comment *models.Comment) error {
+	return c.db.conn().execAffecting("UPDATE comments SET body = ?, updated_at = ? WHERE id = ?",
+		comment.Body, comment.UpdatedAt, comment.CommentId.Hex())
+}
+
+// Delete a comment
+func (c *CommentDAO) Delete(comment *models.Comment) error {
+	return c.db.conn().execAffecting("DELETE FROM comments WHERE id = ?", comment.CommentId.Hex())
+}
@@ -0,0 +1,65 @@
+package sqlstore
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type FilterDAO struct {
+	db *DB
+}
+
+const filterColumns = "id, board_id, name, query, " + trackingColumns
+
+func scanFilter(row scanner) (models.Filter, error) {
+	var filter models.Filter
+	var id, boardID objectID
+	var tracking trackingRow
+	err := row.Scan(append([]interface{}{&id, &boardID, &filter.Name, &filter.Query}, tracking.dest()...)...)
+	filter.FilterId, filter.BoardId = bson.ObjectId(id), bson.ObjectId(boardID)
+	filter.Tracking = tracking.tracking()
+	return filter, err
+}
+
+// FindByBoardAndID -> Find a Filter by its id, only if it belongs to given board
+func (f *FilterDAO) FindByBoardAndID(boardID, filterID bson.ObjectId) (models.Filter, error) {
+	filter, err := scanFilter(f.db.conn().queryRow("SELECT "+filterColumns+" FROM filters WHERE id = ? AND board_id = ?", filterID.Hex(), boardID.Hex()))
+	return filter, translateError(err)
+}
+
+// FindByBoardID -> Filters of given board, sorted by name
+func (f *FilterDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Filter, error) {
+	rows, err := f.db.conn().query("SELECT "+filterColumns+" FROM filters WHERE board_id = ? ORDER BY name, id", boardID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filters := []models.Filter{}
+	for rows.Next() {
+		filter, err := scanFilter(rows)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, rows.Err()
+}
+
+// Insert a Filter
+func (f *FilterDAO) Insert(filter *models.Filter) error {
+	_, err := f.db.conn().exec("INSERT INTO filters ("+filterColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		append([]interface{}{filter.FilterId.Hex(), filter.BoardId.Hex(), filter.Name, filter.Query}, trackingValues(filter.Tracking)...)...)
+	return translateError(err)
+}
+
+// Update a Filter
+func (f *FilterDAO) Update(filter *models.Filter) error {
+	return f.db.conn().execAffecting("UPDATE filters SET name = ?, query = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+		filter.Name, filter.Query, filter.UpdatedAt, hexOrNil(filter.UpdatedBy), filter.FilterId.Hex())
+}
+
+// Delete a Filter
+func (f *FilterDAO) Delete(filter *models.Filter) error {
+	return f.db.conn().execAffecting("DELETE FROM filters WHERE id = ?", filter.FilterId.Hex())
+}
@@ -0,0 +1,50 @@
+package sqlstore
+
+import (
+	"fmt"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// objectID -> Scan destination for identifiers, which are stored as hexadecimal strings
+// so that they match the ObjectIDs used in URLs
+type objectID bson.ObjectId
+
+// Scan -> Implements sql.Scanner
+func (o *objectID) Scan(value interface{}) error {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into an ObjectID", value)
+	}
+
+	if !bson.IsObjectIdHex(s) {
+		return fmt.Errorf("invalid ObjectID %q", s)
+	}
+	*o = objectID(bson.ObjectIdHex(s))
+	return nil
+}
+
+// nullObjectID -> Scan destination for nullable identifiers, NULL is scanned as an empty ObjectID
+type nullObjectID bson.ObjectId
+
+// Scan -> Implements sql.Scanner
+func (n *nullObjectID) Scan(value interface{}) error {
+	if value == nil {
+		*n = ""
+		return nil
+	}
+	return (*objectID)(n).Scan(value)
+}
+
+// hexOrNil -> Value stored for a nullable identifier, NULL for an empty ObjectID
+func hexOrNil(id bson.ObjectId) interface{} {
+	if id == "" {
+		return nil
+	}
+	return id.Hex()
+}
@@ -0,0 +1,65 @@
+package sqlstore
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type LabelDAO struct {
+	db *DB
+}
+
+const labelColumns = "id, board_id, name, color, " + trackingColumns
+
+func scanLabel(row scanner) (models.Label, error) {
+	var label models.Label
+	var id, boardID objectID
+	var tracking trackingRow
+	err := row.Scan(append([]interface{}{&id, &boardID, &label.Name, &label.Color}, tracking.dest()...)...)
+	label.LabelId, label.BoardId = bson.ObjectId(id), bson.ObjectId(boardID)
+	label.Tracking = tracking.tracking()
+	return label, err
+}
+
+// FindByBoardAndID -> Find a Label by its id, only if it belongs to given board
+func (l *LabelDAO) FindByBoardAndID(boardID, labelID bson.ObjectId) (models.Label, error) {
+	label, err := scanLabel(l.db.conn().queryRow("SELECT "+labelColumns+" FROM labels WHERE id = ? AND board_id = ?", labelID.Hex(), boardID.Hex()))
+	return label, translateError(err)
+}
+
+// FindByBoardID -> Labels of given board, sorted by name
+func (l *LabelDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Label, error) {
+	rows, err := l.db.conn().query("SELECT "+labelColumns+" FROM labels WHERE board_id = ? ORDER BY name, id", boardID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	labels := []models.Label{}
+	for rows.Next() {
+		label, err := scanLabel(rows)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
+
+// Insert a Label
+func (l *LabelDAO) Insert(label *models.Label) error {
+	_, err := l.db.conn().exec("INSERT INTO labels ("+labelColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		append([]interface{}{label.LabelId.Hex(), label.BoardId.Hex(), label.Name, label.Color}, trackingValues(label.Tracking)...)...)
+	return translateError(err)
+}
+
+// Update a Label
+func (l *LabelDAO) Update(label *models.Label) error {
+	return l.db.conn().execAffecting("UPDATE labels SET name = ?, color = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+		label.Name, label.Color, label.UpdatedAt, hexOrNil(label.UpdatedBy), label.LabelId.Hex())
+}
+
+// Delete a Label, task labels referencing it are deleted by the database (ON DELETE CASCADE)
+func (l *LabelDAO) Delete(label *models.Label) error {
+	return l.db.conn().execAffecting("DELETE FROM labels WHERE id = ?", label.LabelId.Hex())
+}
@@ -0,0 +1,149 @@
+package sqlstore
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type ListDAO struct {
+	db *DB
+}
+
+const listColumns = "id, board_id, name, position, " + trackingColumns
+
+func scanList(row scanner) (models.List, error) {
+	list := models.NewList()
+	var id, boardID objectID
+	var tracking trackingRow
+	err := row.Scan(append([]interface{}{&id, &boardID, &list.Name, &list.Order}, tracking.dest()...)...)
+	list.ListId, list.BoardId = bson.ObjectId(id), bson.ObjectId(boardID)
+	list.Tracking = tracking.tracking()
+	return list, err
+}
+
+func (c conn) listIDsByBoardID(boardID bson.ObjectId) ([]bson.ObjectId, error) {
+	return c.queryIDs("SELECT id FROM lists WHERE board_id = ? ORDER BY position, id", boardID.Hex())
+}
+
+// FindByBoardAndID -> Find a List by its id, only if it belongs to given board
+func (l *ListDAO) FindByBoardAndID(boardID, listID bson.ObjectId) (models.List, error) {
+	list, err := scanList(l.db.conn().queryRow("SELECT "+listColumns+" FROM lists WHERE id = ? AND board_id = ?", listID.Hex(), boardID.Hex()))
+	return list, translateError(err)
+}
+
+// FindByBoardID -> Find every List attached to given board, sorted by order
+func (l *ListDAO) FindByBoardID(boardID bson.ObjectId) ([]models.List, error) {
+	rows, err := l.db.conn().query("SELECT "+listColumns+" FROM lists WHERE board_id = ? ORDER BY position, id", boardID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := []models.List{}
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, rows.Err()
+}
+
+// Search -> Lists of the query boards holding at least one of its terms, best first
+func (l *ListDAO) Search(query dao.SearchQuery) ([]dao.ListMatch, error) {
+	if len(query.BoardIDs) == 0 || len(query.Terms) == 0 {
+		return []dao.ListMatch{}, nil
+	}
+
+	where, args := searchWhere(query, "name")
+	rows, err := l.db.conn().query("SELECT "+listColumns+" FROM lists WHERE "+where+" ORDER BY position, id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lists := []models.List{}
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return query.RankLists(lists), nil
+}
+
+// FindIDsByBoardID -> Retrieve IDs of lists attached to given board, sorted by order
+func (l *ListDAO) FindIDsByBoardID(boardID bson.ObjectId) ([]bson.ObjectId, error) {
+	return l.db.conn().listIDsByBoardID(boardID)
+}
+
+// CountByBoardID -> Count lists attached to given board
+func (l *ListDAO) CountByBoardID(boardID bson.ObjectId) (int, error) {
+	var count int
+	err := l.db.conn().queryRow("SELECT COUNT(*) FROM lists WHERE board_id = ?", boardID.Hex()).Scan(&count)
+	return count, err
+}
+
+// Insert a List
+func (l *ListDAO) Insert(list *models.List) error {
+	_, err := l.db.conn().exec("INSERT INTO lists ("+listColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		append([]interface{}{list.ListId.Hex(), list.BoardId.Hex(), list.Name, list.Order}, trackingValues(list.Tracking)...)...)
+	return translateError(err)
+}
+
+// Update a List, except its order
+func (l *ListDAO) Update(list *models.List) error {
+	return l.db.conn().execAffecting("UPDATE lists SET name = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+		list.Name, list.UpdatedAt, hexOrNil(list.UpdatedBy), list.ListId.Hex())
+}
+
+// Delete a List, its tasks are removed along with it by the database (ON DELETE CASCADE) in the same statement
+func (l *ListDAO) Delete(list *models.List) error {
+	return l.db.conn().execAffecting("DELETE FROM lists WHERE id = ?", list.ListId.Hex())
+}
+
+// Reorder lists of a board: given ids (the full set of board's lists) get orders 1..n in a single transaction
+func (l *ListDAO) Reorder(ids []bson.ObjectId) error {
+	return l.db.transaction(func(c conn) error {
+		return c.renumber("lists", ids)
+	})
+}
+
+// Move a list to given position (starting at 1) of its board, renumbering its siblings
+func (l *ListDAO) Move(list *models.List, position int) error {
+	return l.db.transaction(func(c conn) error {
+		ids, err := c.listIDsByBoardID(list.BoardId)
+		if err != nil {
+			return err
+		}
+		ids = dao.InsertAt(dao.RemoveID(ids, list.ListId), list.ListId, position)
+		if err := c.renumber("lists", ids); err != nil {
+			return err
+		}
+
+		// Reflect changes on the moved list
+		for i, id := range ids {
+			if id == list.ListId {
+				list.Order = i + 1
+			}
+		}
+		return nil
+	})
+}
+
+// Renumber lists of given board so that their orders are contiguous (1..n), e.g. after a deletion
+func (l *ListDAO) Renumber(boardID bson.ObjectId) error {
+	return l.db.transaction(func(c conn) error {
+		ids, err := c.listIDsByBoardID(boardID)
+		if err != nil {
+			return err
+		}
+		return c.renumber("lists", ids)
+	})
+}
@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type MemberDAO struct {
+	db *DB
+}
+
+const memberColumns = "board_id, user_id, role, created_at"
+
+func scanMember(row scanner) (models.BoardMember, error) {
+	var member models.BoardMember
+	var boardID, userID objectID
+	err := row.Scan(&boardID, &userID, &member.Role, &member.CreatedAt)
+	member.BoardId, member.UserId = bson.ObjectId(boardID), bson.ObjectId(userID)
+	member.CreatedAt = member.CreatedAt.UTC()
+	return member, err
+}
+
+// FindByBoardAndUser -> Find membership of given user on given board
+func (m *MemberDAO) FindByBoardAndUser(boardID, userID bson.ObjectId) (models.BoardMember, error) {
+	member, err := scanMember(m.db.conn().queryRow("SELECT "+memberColumns+" FROM board_members WHERE board_id = ? AND user_id = ?", boardID.Hex(), userID.Hex()))
+	return member, translateError(err)
+}
+
+// FindByBoardID -> Members of given board, oldest first
+func (m *MemberDAO) FindByBoardID(boardID bson.ObjectId) ([]models.BoardMember, error) {
+	rows, err := m.db.conn().query("SELECT "+memberColumns+" FROM board_members WHERE board_id = ? ORDER BY created_at, user_id", boardID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []models.BoardMember{}
+	for rows.Next() {
+		member, err := scanMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, member)
+	}
+	return members, rows.Err()
+}
+
+// FindBoardIDsByUserID -> IDs of boards given user is a member of
+func (m *MemberDAO) FindBoardIDsByUserID(userID bson.ObjectId) ([]bson.ObjectId, error) {
+	return m.db.conn().queryIDs("SELECT board_id FROM board_members WHERE user_id = ?", userID.Hex())
+}
+
+// Insert a membership, uniqueness of (board, user) is enforced by the primary key
+func (m *MemberDAO) Insert(member *models.BoardMember) error {
+	_, err := m.db.conn().exec("INSERT INTO board_members ("+memberColumns+") VALUES (?, ?, ?, ?)",
+		member.BoardId.Hex(), member.UserId.Hex(), member.Role, member.CreatedAt)
+	return translateError(err)
+}
+
+// Update a membership
+func (m *MemberDAO) Update(member *models.BoardMember) error {
+	return m.db.conn().execAffecting("UPDATE board_members SET role = ? WHERE board_id = ? AND user_id = ?",
+		member.Role, member.BoardId.Hex(), member.UserId.Hex())
+}
+
+// Delete a membership
+func (m *MemberDAO) Delete(member *models.BoardMember) error {
+	return m.db.conn().execAffecting("DELETE FROM board_members WHERE board_id = ? AND user_id = ?", member.BoardId.Hex(), member.UserId.Hex())
+}
@@ -0,0 +1,353 @@
+package sqlstore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// migration -> Versioned schema change, statements of a migration are applied in a single transaction
+type migration struct {
+	dao.Migration
+	statements []string
+}
+
+// migrations -> Every schema change, in version order. Never edit an applied migration: append a new one.
+// Statements must run on SQLite and PostgreSQL
+var migrations = []migration{
+	{dao.Migration{Version: 1, Description: "Create boards, lists and tasks tables"}, []string{
+		// Lists and tasks reference their parents, so that deleting a board or a list cascades to its content
+		`CREATE TABLE IF NOT EXISTS boards (
+			id          CHAR(24) PRIMARY KEY,
+			name        VARCHAR(50) NOT NULL,
+			description TEXT NOT NULL,
+			owner       VARCHAR(100) NOT NULL,
+			created_at  TIMESTAMP NOT NULL,
+			updated_at  TIMESTAMP NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS lists (
+			id       CHAR(24) PRIMARY KEY,
+			board_id CHAR(24) NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+			name     VARCHAR(30) NOT NULL,
+			position INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS lists_board_id ON lists (board_id, position)`,
+		`CREATE TABLE IF NOT EXISTS tasks (
+			id          CHAR(24) PRIMARY KEY,
+			list_id     CHAR(24) NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+			board_id    CHAR(24) NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+			title       VARCHAR(200) NOT NULL,
+			description TEXT NOT NULL,
+			status      BOOLEAN NOT NULL,
+			points      DOUBLE PRECISION NOT NULL,
+			position    INTEGER NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS tasks_list_id ON tasks (list_id, position)`,
+		`CREATE INDEX IF NOT EXISTS tasks_board_id ON tasks (board_id)`,
+	}},
+	{dao.Migration{Version: 2, Description: "Create users table"}, []string{
+		`CREATE TABLE users (
+			id            CHAR(24) PRIMARY KEY,
+			email         VARCHAR(254) NOT NULL UNIQUE,
+			name          VARCHAR(100) NOT NULL,
+			password_hash TEXT NOT NULL,
+			created_at    TIMESTAMP NOT NULL
+		)`,
+	}},
+	{dao.Migration{Version: 3, Description: "Create board members table"}, []string{
+		`CREATE TABLE board_members (
+			board_id   CHAR(24) NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+			user_id    CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+			role       VARCHAR(10) NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (board_id, user_id)
+		)`,
+		`CREATE INDEX board_members_user_id ON board_members (user_id)`,
+	}},
+	{dao.Migration{Version: 4, Description: "Create API keys table"}, []string{
+		`CREATE TABLE api_keys (
+			id           CHAR(24) PRIMARY KEY,
+			user_id      CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+			name         VARCHAR(100) NOT NULL,
+			scope        VARCHAR(10) NOT NULL,
+			prefix       VARCHAR(20) NOT NULL,
+			hash         CHAR(64) NOT NULL UNIQUE,
+			expires_at   TIMESTAMP NULL,
+			last_used_at TIMESTAMP NULL,
+			created_at   TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX api_keys_user_id ON api_keys (user_id)`,
+	}},
+	{dao.Migration{Version: 5, Description: "Create comments table"}, []string{
+		`CREATE TABLE comments (
+			id         CHAR(24) PRIMARY KEY,
+			task_id    CHAR(24) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+			board_id   CHAR(24) NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+			author_id  CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+			body       TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX comments_task_id ON comments (task_id, created_at)`,
+	}},
+	{dao.Migration{Version: 6, Description: "Create labels and task labels tables"}, []string{
+		`CREATE TABLE labels (
+			id       CHAR(24) PRIMARY KEY,
+			board_id CHAR(24) NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+			name     VARCHAR(30) NOT NULL,
+			color    CHAR(7) NOT NULL
+		)`,
+		`CREATE INDEX labels_board_id ON labels (board_id, name)`,
+		// Deleting a label detaches it from its tasks
+		`CREATE TABLE task_labels (
+			task_id  CHAR(24) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+			label_id CHAR(24) NOT NULL REFERENCES labels (id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			PRIMARY KEY (task_id, label_id)
+		)`,
+		`CREATE INDEX task_labels_label_id ON task_labels (label_id)`,
+	}},
+	{dao.Migration{Version: 7, Description: "Add checklists to tasks"}, []string{
+		// Checklists are only ever read and written along with their task, they are stored as a JSON document
+		`ALTER TABLE tasks ADD COLUMN checklists TEXT NOT NULL DEFAULT '[]'`,
+	}},
+	{dao.Migration{Version: 8, Description: "Create task assignees and watchers tables"}, []string{
+		`CREATE TABLE task_assignees (
+			task_id  CHAR(24) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+			user_id  CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			PRIMARY KEY (task_id, user_id)
+		)`,
+		`CREATE INDEX task_assignees_user_id ON task_assignees (user_id)`,
+		`CREATE TABLE task_watchers (
+			task_id  CHAR(24) NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+			user_id  CHAR(24) NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+			position INTEGER NOT NULL,
+			PRIMARY KEY (task_id, user_id)
+		)`,
+		`CREATE INDEX task_watchers_user_id ON task_watchers (user_id)`,
+	}},
+	{dao.Migration{Version: 9, Description: "Add start and due dates to tasks"}, []string{
+		`ALTER TABLE tasks ADD COLUMN start_at TIMESTAMP NULL`,
+		`ALTER TABLE tasks ADD COLUMN due_at TIMESTAMP NULL`,
+		`ALTER TABLE tasks ADD COLUMN due_complete BOOLEAN NOT NULL DEFAULT FALSE`,
+		`CREATE INDEX tasks_due_at ON tasks (board_id, due_at)`,
+	}},
+	// Timestamps of rows created before they were recorded are set to the time of the migration, authors are unknown
+	{dao.Migration{Version: 10, Description: "Add creation and update timestamps and authors to boards, lists, tasks and labels"}, []string{
+		`ALTER TABLE boards ADD COLUMN created_by CHAR(24) NULL`,
+		`ALTER TABLE boards ADD COLUMN updated_by CHAR(24) NULL`,
+		`ALTER TABLE lists ADD COLUMN created_at TIMESTAMP NULL`,
+		`ALTER TABLE lists ADD COLUMN updated_at TIMESTAMP NULL`,
+		`ALTER TABLE lists ADD COLUMN created_by CHAR(24) NULL`,
+		`ALTER TABLE lists ADD COLUMN updated_by CHAR(24) NULL`,
+		`UPDATE lists SET created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP`,
+		`ALTER TABLE tasks ADD COLUMN created_at TIMESTAMP NULL`,
+		`ALTER TABLE tasks ADD COLUMN updated_at TIMESTAMP NULL`,
+		`ALTER TABLE tasks ADD COLUMN created_by CHAR(24) NULL`,
+		`ALTER TABLE tasks ADD COLUMN updated_by CHAR(24) NULL`,
+		`UPDATE tasks SET created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP`,
+		`CREATE INDEX tasks_created_at ON tasks (list_id, created_at)`,
+		`ALTER TABLE labels ADD COLUMN created_at TIMESTAMP NULL`,
+		`ALTER TABLE labels ADD COLUMN updated_at TIMESTAMP NULL`,
+		`ALTER TABLE labels ADD COLUMN created_by CHAR(24) NULL`,
+		`ALTER TABLE labels ADD COLUMN updated_by CHAR(24) NULL`,
+		`UPDATE labels SET created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP`,
+	}},
+	{dao.Migration{Version: 11, Description: "Create activities table"}, []string{
+		`CREATE TABLE activities (
+			id             CHAR(24) PRIMARY KEY,
+			board_id       CHAR(24) NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+			actor_id       CHAR(24) NOT NULL,
+			verb           VARCHAR(20) NOT NULL,
+			entity_type    VARCHAR(20) NOT NULL,
+			entity_id      CHAR(24) NOT NULL,
+			before_summary TEXT NULL,
+			after_summary  TEXT NULL,
+			created_at     TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX activities_board_id ON activities (board_id, created_at)`,
+		`CREATE INDEX activities_entity_id ON activities (entity_id, created_at)`,
+	}},
+	{dao.Migration{Version: 12, Description: "Create audit log table"}, []string{
+		`CREATE TABLE audit_log (
+			id              CHAR(24) PRIMARY KEY,
+			action          VARCHAR(20) NOT NULL,
+			entity_type     VARCHAR(20) NOT NULL,
+			entity_id       CHAR(24) NOT NULL,
+			board_id        CHAR(24) NULL,
+			actor_id        CHAR(24) NOT NULL,
+			api_key_id      CHAR(24) NULL,
+			request_id      VARCHAR(64) NOT NULL,
+			client_ip       VARCHAR(64) NOT NULL,
+			before_snapshot TEXT NULL,
+			after_snapshot  TEXT NULL,
+			changed_fields  TEXT NOT NULL,
+			created_at      TIMESTAMP NOT NULL
+		)`,
+		`CREATE INDEX audit_log_created_at ON audit_log (created_at)`,
+		`CREATE INDEX audit_log_entity_id ON audit_log (entity_id, created_at)`,
+		`CREATE INDEX audit_log_actor_id ON audit_log (actor_id, created_at)`,
+	}},
+	{dao.Migration{Version: 13, Description: "Create saved task filters table"}, []string{
+		`CREATE TABLE filters (
+			id         CHAR(24) PRIMARY KEY,
+			board_id   CHAR(24) NOT NULL REFERENCES boards (id) ON DELETE CASCADE,
+			name       VARCHAR(50) NOT NULL,
+			query      VARCHAR(500) NOT NULL,
+			created_at TIMESTAMP NULL,
+			updated_at TIMESTAMP NULL,
+			created_by CHAR(24) NULL,
+			updated_by CHAR(24) NULL
+		)`,
+		`CREATE INDEX filters_board_id ON filters (board_id, name)`,
+	}},
+	// Owners of boards are their members with the owner role, see backfillBoardOwners
+	{dao.Migration{Version: 14, Description: "Make creators owners of boards without owner, drop owner of boards"}, []string{
+		`ALTER TABLE boards DROP COLUMN owner`,
+	}},
+}
+
+// backfills -> Changes of migrations depending on dao.MigrationConfig, run before the statements of the same version
+// and in their transaction
+var backfills = map[int]func(c conn, config dao.MigrationConfig) error{
+	14: backfillBoardOwners,
+}
+
+// backfillBoardOwners -> Give an owner to boards which have none: their creator, or the configured board owner when
+// the creator is unknown. Fails when a board would be left without owner, so that it does not become unreachable
+func backfillBoardOwners(c conn, config dao.MigrationConfig) error {
+	rows, err := c.query(`SELECT b.id, u.id FROM boards b LEFT JOIN users u ON u.id = b.created_by
+		WHERE NOT EXISTS (SELECT 1 FROM board_members m WHERE m.board_id = b.id AND m.role = ?)`, models.RoleOwner)
+	if err != nil {
+		return err
+	}
+	owners := map[bson.ObjectId]bson.ObjectId{}
+	for rows.Next() {
+		var boardID objectID
+		var creator nullObjectID
+		if err := rows.Scan(&boardID, &creator); err != nil {
+			rows.Close()
+			return err
+		}
+		owners[bson.ObjectId(boardID)] = bson.ObjectId(creator)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for boardID, owner := range owners {
+		if owner == "" {
+			owner = config.BoardOwner
+		}
+		if owner == "" {
+			return fmt.Errorf("board %s has no owner and no known creator, configure the user owning such boards", boardID.Hex())
+		}
+		var exists int
+		if err := c.queryRow("SELECT COUNT(*) FROM users WHERE id = ?", owner.Hex()).Scan(&exists); err != nil {
+			return err
+		}
+		if exists == 0 {
+			return fmt.Errorf("configured board owner %s is not a user", owner.Hex())
+		}
+
+		// The owner may already be a member of the board with another role
+		result, err := c.exec("UPDATE board_members SET role = ? WHERE board_id = ? AND user_id = ?", models.RoleOwner, boardID.Hex(), owner.Hex())
+		if err != nil {
+			return err
+		}
+		if updated, err := result.RowsAffected(); err != nil {
+			return err
+		} else if updated > 0 {
+			continue
+		}
+		member := models.NewBoardMember(boardID, owner, models.RoleOwner)
+		if _, err := c.exec("INSERT INTO board_members ("+memberColumns+") VALUES (?, ?, ?, ?)",
+			member.BoardId.Hex(), member.UserId.Hex(), member.Role, member.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MigrationTable -> Table recording applied migration versions
+const MigrationTable = "schema_migrations"
+
+type Migrator struct {
+	db     *DB
+	config dao.MigrationConfig
+}
+
+func (m *Migrator) createTable() error {
+	_, err := m.db.conn().exec(`CREATE TABLE IF NOT EXISTS ` + MigrationTable + ` (
+		version     INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at  TIMESTAMP NOT NULL
+	)`)
+	return err
+}
+
+func (m *Migrator) pending() ([]migration, error) {
+	if err := m.createTable(); err != nil {
+		return nil, err
+	}
+
+	var current int
+	if err := m.db.conn().queryRow("SELECT COALESCE(MAX(version), 0) FROM " + MigrationTable).Scan(&current); err != nil {
+		return nil, err
+	}
+
+	pending := []migration{}
+	for _, migration := range migrations {
+		if migration.Version > current {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Pending -> Migrations which have not been applied yet, in version order
+func (m *Migrator) Pending() ([]dao.Migration, error) {
+	pending, err := m.pending()
+	result := make([]dao.Migration, len(pending))
+	for i, migration := range pending {
+		result[i] = migration.Migration
+	}
+	return result, err
+}
+
+// Migrate -> Apply pending migrations in version order, each one in its own transaction along with its version record
+func (m *Migrator) Migrate() ([]dao.Migration, error) {
+	pending, err := m.pending()
+	if err != nil {
+		return nil, err
+	}
+
+	applied := []dao.Migration{}
+	for _, migration := range pending {
+		err := m.db.transaction(func(c conn) error {
+			if backfill, ok := backfills[migration.Version]; ok {
+				if err := backfill(c, m.config); err != nil {
+					return err
+				}
+			}
+			for _, statement := range migration.statements {
+				if _, err := c.exec(statement); err != nil {
+					return err
+				}
+			}
+			_, err := c.exec("INSERT INTO "+MigrationTable+" (version, description, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Description, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return applied, err
+		}
+		applied = append(applied, migration.Migration)
+	}
+	return applied, nil
+}
@@ -0,0 +1,18 @@
+package sqlstore
+
+import (
+	"database/sql"
+)
+
+// tables -> Every table holding application data, children first
+var tables = []string{"audit_log", "activities", "api_keys", "board_members", "comments", "task_assignees", "task_watchers", "task_labels", "labels", "filters", "tasks", "lists", "boards", "users"}
+
+// Clear -> Delete every row of every table, keeping the schema
+func Clear(db *sql.DB) error {
+	for _, table := range tables {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
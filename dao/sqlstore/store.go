@@ -0,0 +1,249 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Supported database/sql drivers
+const (
+	DriverSQLite   = "sqlite3"
+	DriverPostgres = "postgres"
+)
+
+// querier -> Query methods shared by *sql.DB and *sql.Tx
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// scanner -> Row or Rows, to share scanning code between single and multiple results
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// conn -> Connection (pool or transaction) to run queries on, queries are written with '?' placeholders
+// and rebound to the driver's syntax
+type conn struct {
+	q      querier
+	driver string
+}
+
+// DB -> SQL connection pool shared by every SQL DAO, along with the driver it was opened with
+type DB struct {
+	db     *sql.DB
+	driver string
+	// Transaction every query runs in while Atomically runs, nil otherwise
+	tx *sql.Tx
+	// Number of savepoints created in tx, to name them uniquely
+	savepoints int
+}
+
+// NewStore -> Create a dao.Store backed by given SQL database, the schema is created by its Migrator with given configuration
+func NewStore(db *sql.DB, driver string, migration dao.MigrationConfig) dao.Store {
+	d := &DB{db: db, driver: driver}
+	return dao.Store{
+		Boards:     &BoardDAO{db: d},
+		Lists:      &ListDAO{db: d},
+		Tasks:      &TaskDAO{db: d},
+		Comments:   &CommentDAO{db: d},
+		Labels:     &LabelDAO{db: d},
+		Filters:    &FilterDAO{db: d},
+		Activity:   &ActivityDAO{db: d},
+		Audit:      &AuditDAO{db: d},
+		Users:      &UserDAO{db: d},
+		Members:    &MemberDAO{db: d},
+		ApiKeys:    &ApiKeyDAO{db: d},
+		Migrator:   &Migrator{db: d, config: migration},
+		Transactor: d,
+	}
+}
+
+func (d *DB) conn() conn {
+	if d.tx != nil {
+		return conn{q: d.tx, driver: d.driver}
+	}
+	return conn{q: d.db, driver: d.driver}
+}
+
+// transaction -> Run fn inside a transaction, committed if fn succeeds and rolled back otherwise.
+// While Atomically runs, fn runs inside a savepoint of its transaction instead
+func (d *DB) transaction(fn func(c conn) error) error {
+	if d.tx != nil {
+		return d.savepoint(fn)
+	}
+
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(conn{q: tx, driver: d.driver}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint -> Run fn inside a savepoint of the transaction of Atomically, released if fn succeeds and rolled back to otherwise
+func (d *DB) savepoint(fn func(c conn) error) error {
+	d.savepoints++
+	name := "batch_" + strconv.Itoa(d.savepoints)
+	c := d.conn()
+	if _, err := c.exec("SAVEPOINT " + name); err != nil {
+		return err
+	}
+	if err := fn(c); err != nil {
+		c.exec("ROLLBACK TO SAVEPOINT " + name)
+		return err
+	}
+	_, err := c.exec("RELEASE SAVEPOINT " + name)
+	return err
+}
+
+// Atomically -> Run fn with every query in a single transaction, committed if fn succeeds and rolled back otherwise.
+// Nothing else may use the store while fn runs
+func (d *DB) Atomically(fn func() error) error {
+	tx, err := d.db.Begin()
+	if err != nil {
+		return err
+	}
+	d.tx, d.savepoints = tx, 0
+	defer func() {
+		d.tx = nil
+		// No-op once committed, releases the connection if fn panicked
+		tx.Rollback()
+	}()
+
+	if err := fn(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// rebind -> Replace '?' placeholders with '$1', '$2'... for PostgreSQL
+func (c conn) rebind(query string) string {
+	if c.driver != DriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (c conn) exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.q.Exec(c.rebind(query), args...)
+}
+
+func (c conn) query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.q.Query(c.rebind(query), args...)
+}
+
+func (c conn) queryRow(query string, args ...interface{}) *sql.Row {
+	return c.q.QueryRow(c.rebind(query), args...)
+}
+
+// execAffecting -> Execute query, return dao.ErrNotFound when no row was affected
+func (c conn) execAffecting(query string, args ...interface{}) error {
+	result, err := c.exec(query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return dao.ErrNotFound
+	}
+	return nil
+}
+
+// queryIDs -> Run a query selecting a single id column
+func (c conn) queryIDs(query string, args ...interface{}) ([]bson.ObjectId, error) {
+	rows, err := c.query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []bson.ObjectId{}
+	for rows.Next() {
+		var id objectID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, bson.ObjectId(id))
+	}
+	return ids, rows.Err()
+}
+
+// placeholders -> Comma separated '?' placeholders for n values, and ids as query arguments, for "IN (...)" clauses
+func placeholders(ids []bson.ObjectId) (string, []interface{}) {
+	marks := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		marks[i], args[i] = "?", id.Hex()
+	}
+	return strings.Join(marks, ", "), args
+}
+
+// searchWhere -> SQL condition selecting rows of the query boards holding at least one of its terms in given columns,
+// along with its arguments. Terms only hold letters and digits, they need no escaping
+func searchWhere(query dao.SearchQuery, columns ...string) (string, []interface{}) {
+	marks, args := placeholders(query.BoardIDs)
+	conditions := []string{}
+	for _, term := range query.Terms {
+		for _, column := range columns {
+			conditions, args = append(conditions, "LOWER("+column+") LIKE ?"), append(args, "%"+term+"%")
+		}
+	}
+	return "board_id IN (" + marks + ") AND (" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// renumber -> Give orders 1..n to rows of table identified by ids, following slice order
+func (c conn) renumber(table string, ids []bson.ObjectId) error {
+	for i, id := range ids {
+		if _, err := c.exec("UPDATE "+table+" SET position = ? WHERE id = ?", i+1, id.Hex()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateError -> Convert database/sql errors to their dao equivalent, so that handlers stay backend agnostic
+func translateError(err error) error {
+	if err == sql.ErrNoRows {
+		return dao.ErrNotFound
+	}
+	if isUniqueViolation(err) {
+		return dao.ErrDuplicate
+	}
+	return err
+}
+
+// isUniqueViolation -> Whether err reports a unique constraint violation, for any supported driver
+func isUniqueViolation(err error) bool {
+	switch e := err.(type) {
+	case sqlite3.Error:
+		return e.ExtendedCode == sqlite3.ErrConstraintUnique || e.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+	case *pq.Error:
+		return e.Code == "23505"
+	}
+	return false
+}
@@ -0,0 +1,571 @@
+package sqlstore
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type TaskDAO struct {
+	db *DB
+}
+
+const taskColumns = "id, list_id, board_id, title, description, status, points, position, checklists, start_at, due_at, due_complete, " + trackingColumns
+
+func scanTask(row scanner) (models.Task, error) {
+	var task models.Task
+	var id, listID, boardID objectID
+	var checklists string
+	var tracking trackingRow
+	err := row.Scan(append([]interface{}{&id, &listID, &boardID, &task.Title, &task.Description, &task.Status, &task.Points, &task.Order, &checklists,
+		&task.StartAt, &task.DueAt, &task.DueComplete}, tracking.dest()...)...)
+	if err != nil {
+		return task, err
+	}
+	task.TaskId, task.ListId, task.BoardId = bson.ObjectId(id), bson.ObjectId(listID), bson.ObjectId(boardID)
+	task.Tracking = tracking.tracking()
+	task.StartAt, task.DueAt = utcOrNil(task.StartAt), utcOrNil(task.DueAt)
+	return task, json.Unmarshal([]byte(checklists), &task.Checklists)
+}
+
+// encodeChecklists -> JSON document stored in column checklists
+func encodeChecklists(task *models.Task) (string, error) {
+	checklists := task.Checklists
+	if checklists == nil {
+		checklists = []models.Checklist{}
+	}
+	encoded, err := json.Marshal(checklists)
+	return string(encoded), err
+}
+
+// findTasks -> Tasks matching given condition, sorted by order, along with their relations
+func (c conn) findTasks(where string, args ...interface{}) ([]models.Task, error) {
+	return c.findTasksOrderedBy(where, "position, id", args...)
+}
+
+// findTasksOrderedBy -> Tasks matching given condition, sorted by given columns, along with their relations
+func (c conn) findTasksOrderedBy(where, orderBy string, args ...interface{}) ([]models.Task, error) {
+	rows, err := c.query("SELECT "+taskColumns+" FROM tasks WHERE "+where+" ORDER BY "+orderBy, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []models.Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	return tasks, c.loadTaskRelations(tasks)
+}
+
+// taskRelation -> Table linking tasks to other entities (labels, users), loaded and saved along with tasks
+type taskRelation struct {
+	table  string
+	column string
+	ids    func(task *models.Task) *[]bson.ObjectId
+}
+
+var taskRelations = []taskRelation{
+	{"task_labels", "label_id", func(task *models.Task) *[]bson.ObjectId { return &task.Labels }},
+	{"task_assignees", "user_id", func(task *models.Task) *[]bson.ObjectId { return &task.Assignees }},
+	{"task_watchers", "user_id", func(task *models.Task) *[]bson.ObjectId { return &task.Watchers }},
+}
+
+// loadTaskRelations -> Retrieve labels, assignees and watchers of given tasks, with a single query per relation
+func (c conn) loadTaskRelations(tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]bson.ObjectId, len(tasks))
+	indexes := make(map[bson.ObjectId]int, len(tasks))
+	for i := range tasks {
+		ids[i], indexes[tasks[i].TaskId] = tasks[i].TaskId, i
+	}
+	marks, args := placeholders(ids)
+
+	for _, relation := range taskRelations {
+		for i := range tasks {
+			*relation.ids(&tasks[i]) = []bson.ObjectId{}
+		}
+
+		rows, err := c.query("SELECT task_id, "+relation.column+" FROM "+relation.table+" WHERE task_id IN ("+marks+") ORDER BY task_id, position", args...)
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			var taskID, relatedID objectID
+			if err := rows.Scan(&taskID, &relatedID); err != nil {
+				rows.Close()
+				return err
+			}
+			related := relation.ids(&tasks[indexes[bson.ObjectId(taskID)]])
+			*related = append(*related, bson.ObjectId(relatedID))
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveTaskRelations -> Replace given relations (e.g. labels) of given task
+func (c conn) saveTaskRelations(task *models.Task, relations []taskRelation) error {
+	for _, relation := range relations {
+		if _, err := c.exec("DELETE FROM "+relation.table+" WHERE task_id = ?", task.TaskId.Hex()); err != nil {
+			return err
+		}
+		for i, relatedID := range *relation.ids(task) {
+			_, err := c.exec("INSERT INTO "+relation.table+" (task_id, "+relation.column+", position) VALUES (?, ?, ?)", task.TaskId.Hex(), relatedID.Hex(), i+1)
+			if err != nil {
+				return translateError(err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c conn) taskIDsByListID(listID bson.ObjectId) ([]bson.ObjectId, error) {
+	return c.queryIDs("SELECT id FROM tasks WHERE list_id = ? ORDER BY position, id", listID.Hex())
+}
+
+// FindByListAndID -> Find a Task by its id, only if it is attached to given list
+func (t *TaskDAO) FindByListAndID(listID, taskID bson.ObjectId) (models.Task, error) {
+	c := t.db.conn()
+	task, err := scanTask(c.queryRow("SELECT "+taskColumns+" FROM tasks WHERE id = ? AND list_id = ?", taskID.Hex(), listID.Hex()))
+	if err != nil {
+		return task, translateError(err)
+	}
+
+	tasks := []models.Task{task}
+	err = c.loadTaskRelations(tasks)
+	return tasks[0], err
+}
+
+// FindByListID -> Find every Task attached to given list, sorted by order
+func (t *TaskDAO) FindByListID(listID bson.ObjectId) ([]models.Task, error) {
+	return t.db.conn().findTasks("list_id = ?", listID.Hex())
+}
+
+// FindByBoardID -> Find every Task attached to given board, sorted by order
+func (t *TaskDAO) FindByBoardID(boardID bson.ObjectId) ([]models.Task, error) {
+	return t.db.conn().findTasks("board_id = ?", boardID.Hex())
+}
+
+// FindByAssignee -> Find every Task given user is assigned to, across boards
+func (t *TaskDAO) FindByAssignee(userID bson.ObjectId) ([]models.Task, error) {
+	return t.db.conn().findTasks("id IN (SELECT task_id FROM task_assignees WHERE user_id = ?)", userID.Hex())
+}
+
+// FindDue -> Find Tasks matching given due date query, sorted by due date
+func (t *TaskDAO) FindDue(query dao.DueQuery) ([]models.Task, error) {
+	where, args := "due_at IS NOT NULL AND due_at < ? AND NOT due_complete", []interface{}{query.Before}
+	if query.From != nil {
+		where, args = where+" AND due_at >= ?", append(args, *query.From)
+	}
+	if query.BoardID != "" {
+		where, args = where+" AND board_id = ?", append(args, query.BoardID.Hex())
+	}
+	if query.Assignee != "" {
+		where, args = where+" AND id IN (SELECT task_id FROM task_assignees WHERE user_id = ?)", append(args, query.Assignee.Hex())
+	}
+	return t.db.conn().findTasksOrderedBy(where, "due_at, id", args...)
+}
+
+// taskSortColumns -> Column of each sort field of task queries
+var taskSortColumns = map[string]string{
+	dao.TaskSortOrder:     "position",
+	dao.TaskSortTitle:     "title",
+	dao.TaskSortPoints:    "points",
+	dao.TaskSortCreatedAt: "created_at",
+}
+
+// likeEscaper -> Escapes LIKE wildcards, so that substrings are matched literally (ESCAPE '\')
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// taskWhere -> SQL condition equivalent to given query, along with its arguments
+func taskWhere(query dao.TaskQuery) (string, []interface{}) {
+	conditions, args := []string{}, []interface{}{}
+	if query.ListID != "" {
+		conditions, args = append(conditions, "list_id = ?"), append(args, query.ListID.Hex())
+	}
+	if query.BoardID != "" {
+		conditions, args = append(conditions, "board_id = ?"), append(args, query.BoardID.Hex())
+	}
+	if query.Status != nil {
+		conditions, args = append(conditions, "status = ?"), append(args, *query.Status)
+	}
+	if query.PointsMin != nil {
+		conditions, args = append(conditions, "points "+comparison(">", !query.PointsMinExclusive)+" ?"), append(args, *query.PointsMin)
+	}
+	if query.PointsMax != nil {
+		conditions, args = append(conditions, "points "+comparison("<", !query.PointsMaxExclusive)+" ?"), append(args, *query.PointsMax)
+	}
+	if query.Title != "" {
+		conditions = append(conditions, `LOWER(title) LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+likeEscaper.Replace(strings.ToLower(query.Title))+"%")
+	}
+	for _, labelID := range query.Labels {
+		conditions, args = append(conditions, "id IN (SELECT task_id FROM task_labels WHERE label_id = ?)"), append(args, labelID.Hex())
+	}
+	// Comparisons with NULL are never true, tasks without due date are excluded
+	if query.DueAfter != nil {
+		conditions, args = append(conditions, "due_at > ?"), append(args, *query.DueAfter)
+	}
+	if query.DueBefore != nil {
+		conditions, args = append(conditions, "due_at < ?"), append(args, *query.DueBefore)
+	}
+	if query.NoDueDate {
+		conditions = append(conditions, "due_at IS NULL")
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// comparison -> SQL comparison operator (>, <), or its inclusive variant (>=, <=)
+func comparison(operator string, inclusive bool) string {
+	if inclusive {
+		return operator + "="
+	}
+	return operator
+}
+
+// Find -> Find Tasks matching given query, sorted as requested by the query, restricted to given page
+func (t *TaskDAO) Find(query dao.TaskQuery, page dao.Page) ([]models.Task, error) {
+	column, ok := taskSortColumns[query.Sort]
+	if !ok {
+		column = taskSortColumns[dao.TaskSortOrder]
+	}
+	if query.Descending {
+		column += " DESC"
+	}
+
+	where, args := taskWhere(query)
+	return t.db.conn().findTasksOrderedBy(where, column+", position, id LIMIT ? OFFSET ?", append(args, page.Limit, page.Offset)...)
+}
+
+// Count -> Number of Tasks matching given query
+func (t *TaskDAO) Count(query dao.TaskQuery) (int, error) {
+	var count int
+	where, args := taskWhere(query)
+	err := t.db.conn().queryRow("SELECT COUNT(*) FROM tasks WHERE "+where, args...).Scan(&count)
+	return count, err
+}
+
+// Search -> Tasks of the query boards holding at least one of its terms, best first.
+// Candidates are selected by the database, and ranked like every other backend
+func (t *TaskDAO) Search(query dao.SearchQuery) ([]dao.TaskMatch, error) {
+	if len(query.BoardIDs) == 0 || len(query.Terms) == 0 {
+		return []dao.TaskMatch{}, nil
+	}
+
+	where, args := searchWhere(query, "title", "description")
+	tasks, err := t.db.conn().findTasks(where, args...)
+	if err != nil {
+		return nil, err
+	}
+	return query.RankTasks(tasks), nil
+}
+
+// CountByListID -> Count tasks attached to given list
+func (t *TaskDAO) CountByListID(listID bson.ObjectId) (int, error) {
+	var count int
+	err := t.db.conn().queryRow("SELECT COUNT(*) FROM tasks WHERE list_id = ?", listID.Hex()).Scan(&count)
+	return count, err
+}
+
+// Insert a Task along with its labels, assignees and watchers, in a single transaction
+func (t *TaskDAO) Insert(task *models.Task) error {
+	checklists, err := encodeChecklists(task)
+	if err != nil {
+		return err
+	}
+
+	return t.db.transaction(func(c conn) error {
+		_, err := c.exec("INSERT INTO tasks ("+taskColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			append([]interface{}{task.TaskId.Hex(), task.ListId.Hex(), task.BoardId.Hex(), task.Title, task.Description, task.Status, task.Points, task.Order, checklists,
+				task.StartAt, task.DueAt, task.DueComplete}, trackingValues(task.Tracking)...)...)
+		if err != nil {
+			return translateError(err)
+		}
+		return c.saveTaskRelations(task, taskRelations)
+	})
+}
+
+// updateTask -> Update a Task along with its labels, except its list, order, checklists, assignees and watchers
+func (c conn) updateTask(task *models.Task) error {
+	err := c.execAffecting("UPDATE tasks SET title = ?, description = ?, status = ?, points = ?, start_at = ?, due_at = ?, due_complete = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+		task.Title, task.Description, task.Status, task.Points, task.StartAt, task.DueAt, task.DueComplete,
+		task.UpdatedAt, hexOrNil(task.UpdatedBy), task.TaskId.Hex())
+	if err != nil {
+		return err
+	}
+	return c.saveTaskRelations(task, taskRelations[:1])
+}
+
+// Update a Task along with its labels, in a single transaction
+func (t *TaskDAO) Update(task *models.Task) error {
+	return t.db.transaction(func(c conn) error {
+		return c.updateTask(task)
+	})
+}
+
+// UpdateChecklists -> Apply change to the stored task then save its checklists and update tracking, only if the stored
+// checklists are still the ones change was applied to, applying it again otherwise
+func (t *TaskDAO) UpdateChecklists(task *models.Task, change func(task *models.Task) error) error {
+	c := t.db.conn()
+	for attempt := 0; attempt < dao.UpdateAttempts; attempt++ {
+		tasks, err := c.findTasks("id = ?", task.TaskId.Hex())
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return dao.ErrNotFound
+		}
+		// Change is applied to the very checklists compared when saving
+		var stored string
+		if err := c.queryRow("SELECT checklists FROM tasks WHERE id = ?", task.TaskId.Hex()).Scan(&stored); err != nil {
+			return translateError(err)
+		}
+		updated := tasks[0]
+		updated.Checklists = nil
+		if err := json.Unmarshal([]byte(stored), &updated.Checklists); err != nil {
+			return err
+		}
+
+		if err := change(&updated); err != nil {
+			return err
+		}
+		checklists, err := encodeChecklists(&updated)
+		if err != nil {
+			return err
+		}
+		result, err := c.exec("UPDATE tasks SET checklists = ?, updated_at = ?, updated_by = ? WHERE id = ? AND checklists = ?",
+			checklists, task.UpdatedAt, hexOrNil(task.UpdatedBy), task.TaskId.Hex(), stored)
+		if err != nil {
+			return err
+		}
+		if saved, err := result.RowsAffected(); err != nil {
+			return err
+		} else if saved > 0 {
+			updated.UpdatedAt, updated.UpdatedBy = task.UpdatedAt, task.UpdatedBy
+			*task = updated
+			return nil
+		}
+	}
+	return dao.ErrConflict
+}
+
+// peopleTables -> Table holding each kind of people of tasks
+var peopleTables = map[dao.TaskPeople]string{dao.Assignees: "task_assignees", dao.Watchers: "task_watchers"}
+
+// AddPerson -> Add user at the end of given people of the stored task unless it already is one of them, in a single transaction
+func (t *TaskDAO) AddPerson(task *models.Task, people dao.TaskPeople, userID bson.ObjectId) (bool, error) {
+	table := peopleTables[people]
+	return t.changePeople(task, "INSERT INTO "+table+" (task_id, user_id, position) "+
+		"SELECT id, ?, (SELECT COALESCE(MAX(position), 0) + 1 FROM "+table+" WHERE task_id = tasks.id) FROM tasks "+
+		"WHERE id = ? AND NOT EXISTS (SELECT 1 FROM "+table+" WHERE task_id = tasks.id AND user_id = ?)",
+		userID.Hex(), task.TaskId.Hex(), userID.Hex())
+}
+
+// RemovePerson -> Remove user from given people of the stored task, in a single transaction
+func (t *TaskDAO) RemovePerson(task *models.Task, people dao.TaskPeople, userID bson.ObjectId) (bool, error) {
+	return t.changePeople(task, "DELETE FROM "+peopleTables[people]+" WHERE task_id = ? AND user_id = ?", task.TaskId.Hex(), userID.Hex())
+}
+
+// changePeople -> Run change on people of the task, saving update tracking along when it affected a row, set task to the saved task
+func (t *TaskDAO) changePeople(task *models.Task, change string, args ...interface{}) (bool, error) {
+	changed := false
+	err := t.db.transaction(func(c conn) error {
+		result, err := c.exec(change, args...)
+		if err != nil {
+			return translateError(err)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if changed = affected > 0; changed {
+			err := c.execAffecting("UPDATE tasks SET updated_at = ?, updated_by = ? WHERE id = ?", task.UpdatedAt, hexOrNil(task.UpdatedBy), task.TaskId.Hex())
+			if err != nil {
+				return err
+			}
+		}
+
+		tasks, err := c.findTasks("id = ?", task.TaskId.Hex())
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return dao.ErrNotFound
+		}
+		*task = tasks[0]
+		return nil
+	})
+	return changed && err == nil, err
+}
+
+// UpdateAll -> Update given tasks in a single transaction
+func (t *TaskDAO) UpdateAll(tasks []models.Task) error {
+	return t.db.transaction(func(c conn) error {
+		for i := range tasks {
+			if err := c.updateTask(&tasks[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Delete a Task
+func (t *TaskDAO) Delete(task *models.Task) error {
+	return t.db.conn().execAffecting("DELETE FROM tasks WHERE id = ?", task.TaskId.Hex())
+}
+
+// Move a task to given position (starting at 1) of target list, renumbering source and target lists in a single transaction
+func (t *TaskDAO) Move(task *models.Task, targetListID bson.ObjectId, position int) error {
+	return t.db.transaction(func(c conn) error {
+		sourceIDs, err := c.taskIDsByListID(task.ListId)
+		if err != nil {
+			return err
+		}
+		sourceIDs = dao.RemoveID(sourceIDs, task.TaskId)
+
+		targetIDs := sourceIDs
+		if targetListID != task.ListId {
+			if targetIDs, err = c.taskIDsByListID(targetListID); err != nil {
+				return err
+			}
+		}
+		targetIDs = dao.InsertAt(targetIDs, task.TaskId, position)
+
+		if err := c.execAffecting("UPDATE tasks SET list_id = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+			targetListID.Hex(), task.UpdatedAt, hexOrNil(task.UpdatedBy), task.TaskId.Hex()); err != nil {
+			return err
+		}
+		if targetListID != task.ListId {
+			if err := c.renumber("tasks", sourceIDs); err != nil {
+				return err
+			}
+		}
+		if err := c.renumber("tasks", targetIDs); err != nil {
+			return err
+		}
+
+		// Reflect changes on the moved task
+		for i, id := range targetIDs {
+			if id == task.TaskId {
+				task.Order = i + 1
+			}
+		}
+		task.ListId = targetListID
+		return nil
+	})
+}
+
+// Renumber tasks of given list so that their orders are contiguous (1..n), e.g. after a deletion
+func (t *TaskDAO) Renumber(listID bson.ObjectId) error {
+	return t.db.transaction(func(c conn) error {
+		ids, err := c.taskIDsByListID(listID)
+		if err != nil {
+			return err
+		}
+		return c.renumber("tasks", ids)
+	})
+}
+
+// MoveAll -> Move given tasks at the end of target list, in given order, renumbering source and target lists in a single transaction
+func (t *TaskDAO) MoveAll(tasks []models.Task, targetListID bson.ObjectId) error {
+	return t.db.transaction(func(c conn) error {
+		targetIDs, err := c.taskIDsByListID(targetListID)
+		if err != nil {
+			return err
+		}
+
+		sources := map[bson.ObjectId]bool{}
+		for _, task := range tasks {
+			sources[task.ListId] = true
+			targetIDs = append(dao.RemoveID(targetIDs, task.TaskId), task.TaskId)
+			if err := c.execAffecting("UPDATE tasks SET list_id = ?, updated_at = ?, updated_by = ? WHERE id = ?",
+				targetListID.Hex(), task.UpdatedAt, hexOrNil(task.UpdatedBy), task.TaskId.Hex()); err != nil {
+				return err
+			}
+		}
+
+		for listID := range sources {
+			if listID == targetListID {
+				continue
+			}
+			ids, err := c.taskIDsByListID(listID)
+			if err != nil {
+				return err
+			}
+			if err := c.renumber("tasks", ids); err != nil {
+				return err
+			}
+		}
+		if err := c.renumber("tasks", targetIDs); err != nil {
+			return err
+		}
+
+		// Reflect changes on the moved tasks
+		for i := range tasks {
+			for order, id := range targetIDs {
+				if id == tasks[i].TaskId {
+					tasks[i].Order = order + 1
+				}
+			}
+			tasks[i].ListId = targetListID
+		}
+		return nil
+	})
+}
+
+// DeleteAll -> Delete given tasks (their comments go along) and renumber the lists they leave, in a single transaction
+func (t *TaskDAO) DeleteAll(tasks []models.Task) error {
+	return t.db.transaction(func(c conn) error {
+		lists := map[bson.ObjectId]bool{}
+		for _, task := range tasks {
+			lists[task.ListId] = true
+			if err := c.execAffecting("DELETE FROM tasks WHERE id = ?", task.TaskId.Hex()); err != nil {
+				return err
+			}
+		}
+
+		for listID := range lists {
+			ids, err := c.taskIDsByListID(listID)
+			if err != nil {
+				return err
+			}
+			if err := c.renumber("tasks", ids); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RemoveUser -> Unassign given user from every task of a board, and stop it watching them
+func (t *TaskDAO) RemoveUser(boardID, userID bson.ObjectId) error {
+	return t.db.transaction(func(c conn) error {
+		for _, table := range []string{"task_assignees", "task_watchers"} {
+			_, err := c.exec("DELETE FROM "+table+" WHERE user_id = ? AND task_id IN (SELECT id FROM tasks WHERE board_id = ?)", userID.Hex(), boardID.Hex())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// trackingColumns -> Columns holding models.Tracking, last columns of tracked tables' column lists
+const trackingColumns = "created_at, updated_at, created_by, updated_by"
+
+// trackingRow -> Scan destination of trackingColumns
+type trackingRow struct {
+	createdAt, updatedAt time.Time
+	createdBy, updatedBy nullObjectID
+}
+
+func (t *trackingRow) dest() []interface{} {
+	return []interface{}{&t.createdAt, &t.updatedAt, &t.createdBy, &t.updatedBy}
+}
+
+func (t *trackingRow) tracking() models.Tracking {
+	return models.Tracking{
+		CreatedAt: t.createdAt.UTC(),
+		UpdatedAt: t.updatedAt.UTC(),
+		CreatedBy: bson.ObjectId(t.createdBy),
+		UpdatedBy: bson.ObjectId(t.updatedBy),
+	}
+}
+
+// trackingValues -> Values of trackingColumns, when inserting an entity
+func trackingValues(t models.Tracking) []interface{} {
+	return []interface{}{t.CreatedAt, t.UpdatedAt, hexOrNil(t.CreatedBy), hexOrNil(t.UpdatedBy)}
+}
@@ -0,0 +1,40 @@
+package sqlstore
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+type UserDAO struct {
+	db *DB
+}
+
+const userColumns = "id, email, name, password_hash, created_at"
+
+func scanUser(row scanner) (models.User, error) {
+	var user models.User
+	var id objectID
+	err := row.Scan(&id, &user.Email, &user.Name, &user.PasswordHash, &user.CreatedAt)
+	user.UserId = bson.ObjectId(id)
+	user.CreatedAt = user.CreatedAt.UTC()
+	return user, err
+}
+
+// FindByID -> Find a User by its id
+func (u *UserDAO) FindByID(userID bson.ObjectId) (models.User, error) {
+	user, err := scanUser(u.db.conn().queryRow("SELECT "+userColumns+" FROM users WHERE id = ?", userID.Hex()))
+	return user, translateError(err)
+}
+
+// FindByEmail -> Find a User by its email
+func (u *UserDAO) FindByEmail(email string) (models.User, error) {
+	user, err := scanUser(u.db.conn().queryRow("SELECT "+userColumns+" FROM users WHERE email = ?", email))
+	return user, translateError(err)
+}
+
+// Insert a User, email uniqueness is enforced by the schema
+func (u *UserDAO) Insert(user *models.User) error {
+	_, err := u.db.conn().exec("INSERT INTO users ("+userColumns+") VALUES (?, ?, ?, ?, ?)",
+		user.UserId.Hex(), user.Email, user.Name, user.PasswordHash, user.CreatedAt)
+	return translateError(err)
+}
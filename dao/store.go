@@ -0,0 +1,235 @@
+package dao
+
+import (
+	"errors"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// ErrNotFound is returned by every Store implementation when the requested entity does not exist
+var ErrNotFound = errors.New("not found")
+
+// ErrDuplicate is returned by every Store implementation when an insertion conflicts with a unique key (e.g. user email)
+var ErrDuplicate = errors.New("duplicate key")
+
+// ErrConflict is returned by every Store implementation when an entity kept being modified concurrently, the change was not applied
+var ErrConflict = errors.New("concurrent modification")
+
+// UpdateAttempts -> Times a change is applied again to an entity modified concurrently, before failing with ErrConflict
+const UpdateAttempts = 5
+
+// BoardStore -> Persistence layer for Boards
+type BoardStore interface {
+	// FindAll -> Find every Board, oldest first
+	FindAll() ([]models.Board, error)
+	FindByID(boardID bson.ObjectId) (models.Board, error)
+	// FindByIDs -> Find Boards having given ids, oldest first
+	FindByIDs(boardIDs []bson.ObjectId) ([]models.Board, error)
+	Insert(board *models.Board) error
+	Update(board *models.Board) error
+	// Delete -> Delete a Board along with its lists, their tasks (and comments), its labels, its filters, its members and its activity
+	Delete(board *models.Board) error
+}
+
+// ListStore -> Persistence layer for Lists, lists are always sorted by order
+type ListStore interface {
+	// FindByBoardAndID -> Find a List by its id, only if it belongs to given board
+	FindByBoardAndID(boardID, listID bson.ObjectId) (models.List, error)
+	FindByBoardID(boardID bson.ObjectId) ([]models.List, error)
+	// Search -> Lists of the query boards holding at least one of its terms, best first
+	Search(query SearchQuery) ([]ListMatch, error)
+	FindIDsByBoardID(boardID bson.ObjectId) ([]bson.ObjectId, error)
+	CountByBoardID(boardID bson.ObjectId) (int, error)
+	Insert(list *models.List) error
+	// Update -> Update a List, except its order which is only modified by Reorder, Move and Renumber
+	Update(list *models.List) error
+	// Delete -> Delete a List along with its tasks, atomically when the backend supports it
+	Delete(list *models.List) error
+	// Reorder -> Given ids (the full set of a board's lists) get orders 1..n
+	Reorder(ids []bson.ObjectId) error
+	// Move -> Move a list to given position (starting at 1) of its board, renumbering its siblings
+	Move(list *models.List, position int) error
+	// Renumber -> Make orders of a board's lists contiguous (1..n)
+	Renumber(boardID bson.ObjectId) error
+}
+
+// TaskStore -> Persistence layer for Tasks, tasks are always sorted by order
+type TaskStore interface {
+	// FindByListAndID -> Find a Task by its id, only if it is attached to given list
+	FindByListAndID(listID, taskID bson.ObjectId) (models.Task, error)
+	FindByListID(listID bson.ObjectId) ([]models.Task, error)
+	FindByBoardID(boardID bson.ObjectId) ([]models.Task, error)
+	// FindByAssignee -> Find every Task given user is assigned to, across boards
+	FindByAssignee(userID bson.ObjectId) ([]models.Task, error)
+	// FindDue -> Find Tasks matching given due date query, sorted by due date
+	FindDue(query DueQuery) ([]models.Task, error)
+	// Find -> Tasks matching given query, sorted as requested by the query, restricted to given page
+	Find(query TaskQuery, page Page) ([]models.Task, error)
+	// Search -> Tasks of the query boards holding at least one of its terms (title or description), best first
+	Search(query SearchQuery) ([]TaskMatch, error)
+	Count(query TaskQuery) (int, error)
+	CountByListID(listID bson.ObjectId) (int, error)
+	Insert(task *models.Task) error
+	// Update -> Update a Task, except its list and order which are only modified by Move and MoveAll, its checklists
+	// which are only modified by UpdateChecklists, and its assignees and watchers only modified by AddPerson and RemovePerson
+	Update(task *models.Task) error
+	// UpdateChecklists -> Apply change to the stored task then save its checklists and update tracking only, change is applied
+	// again to the stored task if it was modified concurrently, so that no edit is lost. Task is set to the saved task
+	UpdateChecklists(task *models.Task, change func(task *models.Task) error) error
+	// AddPerson -> Add user to given people of the stored task unless it already is one of them, saving update tracking along
+	// when it is added, the rest of the task is left as stored. Task is set to the saved task, returns whether user was added
+	AddPerson(task *models.Task, people TaskPeople, userID bson.ObjectId) (bool, error)
+	// RemovePerson -> Remove user from given people of the stored task, like AddPerson. Returns whether user was removed
+	RemovePerson(task *models.Task, people TaskPeople, userID bson.ObjectId) (bool, error)
+	// Delete -> Delete a Task along with its comments
+	Delete(task *models.Task) error
+	// Move -> Move a task to given position (starting at 1) of target list, renumbering source and target lists at once.
+	// The update tracking of the task is saved along, renumbered tasks keep theirs
+	Move(task *models.Task, targetListID bson.ObjectId, position int) error
+	// Renumber -> Make orders of a list's tasks contiguous (1..n)
+	Renumber(listID bson.ObjectId) error
+	// UpdateAll -> Update given tasks at once (except their list, order, checklists and people), none of them is updated if one fails (where the backend allows)
+	UpdateAll(tasks []models.Task) error
+	// MoveAll -> Move given tasks at the end of target list, in given order, renumbering source and target lists at once.
+	// The update tracking of given tasks is saved along, renumbered tasks keep theirs
+	MoveAll(tasks []models.Task, targetListID bson.ObjectId) error
+	// DeleteAll -> Delete given tasks along with their comments at once, renumbering the lists they leave
+	DeleteAll(tasks []models.Task) error
+	// RemoveUser -> Unassign given user from every task of a board, and stop it watching them (e.g. when it leaves the board)
+	RemoveUser(boardID, userID bson.ObjectId) error
+}
+
+// LabelStore -> Persistence layer for Board labels
+type LabelStore interface {
+	// FindByBoardAndID -> Find a Label by its id, only if it belongs to given board
+	FindByBoardAndID(boardID, labelID bson.ObjectId) (models.Label, error)
+	// FindByBoardID -> Labels of given board, sorted by name
+	FindByBoardID(boardID bson.ObjectId) ([]models.Label, error)
+	Insert(label *models.Label) error
+	Update(label *models.Label) error
+	// Delete -> Delete a Label and detach it from every task
+	Delete(label *models.Label) error
+}
+
+// FilterStore -> Persistence layer for saved task filters of Boards
+type FilterStore interface {
+	// FindByBoardAndID -> Find a Filter by its id, only if it belongs to given board
+	FindByBoardAndID(boardID, filterID bson.ObjectId) (models.Filter, error)
+	// FindByBoardID -> Filters of given board, sorted by name
+	FindByBoardID(boardID bson.ObjectId) ([]models.Filter, error)
+	Insert(filter *models.Filter) error
+	Update(filter *models.Filter) error
+	Delete(filter *models.Filter) error
+}
+
+// CommentStore -> Persistence layer for Task comments, comments are always sorted oldest first
+type CommentStore interface {
+	// FindByTaskAndID -> Find a Comment by its id, only if it is attached to given task
+	FindByTaskAndID(taskID, commentID bson.ObjectId) (models.Comment, error)
+	FindByTaskID(taskID bson.ObjectId, page Page) ([]models.Comment, error)
+	CountByTaskID(taskID bson.ObjectId) (int, error)
+	// CountByTaskIDs -> Number of comments of each given task, tasks without comments are absent from the map
+	CountByTaskIDs(taskIDs []bson.ObjectId) (map[bson.ObjectId]int, error)
+	Insert(comment *models.Comment) error
+	Update(comment *models.Comment) error
+	Delete(comment *models.Comment) error
+}
+
+// ActivityStore -> Persistence layer for Board activities, activities are always sorted newest first
+type ActivityStore interface {
+	// Find -> Activities selected by given query, restricted to given page
+	Find(query ActivityQuery, page Page) ([]models.Activity, error)
+	Count(query ActivityQuery) (int, error)
+	Insert(activity *models.Activity) error
+}
+
+// AuditStore -> Append-only persistence layer for the audit log, entries are always sorted newest first and never modified
+type AuditStore interface {
+	// Find -> Audit entries selected by given query, restricted to given page
+	Find(query AuditQuery, page Page) ([]models.AuditEntry, error)
+	Count(query AuditQuery) (int, error)
+	Insert(entry *models.AuditEntry) error
+}
+
+// UserStore -> Persistence layer for Users, emails are unique
+type UserStore interface {
+	FindByID(userID bson.ObjectId) (models.User, error)
+	FindByEmail(email string) (models.User, error)
+	// Insert -> Insert a User, returns ErrDuplicate if its email is already registered
+	Insert(user *models.User) error
+}
+
+// ApiKeyStore -> Persistence layer for API keys, keys are looked up by the hash of their value
+type ApiKeyStore interface {
+	// FindByHash -> Find an API key from the hash of its value (see models.HashApiKey)
+	FindByHash(hash string) (models.ApiKey, error)
+	// FindByUserAndID -> Find an API key by its id, only if it belongs to given user
+	FindByUserAndID(userID, apiKeyID bson.ObjectId) (models.ApiKey, error)
+	// FindByUserID -> API keys of given user, oldest first
+	FindByUserID(userID bson.ObjectId) ([]models.ApiKey, error)
+	Insert(apiKey *models.ApiKey) error
+	Delete(apiKey *models.ApiKey) error
+	// Touch -> Record that an API key has been used at given time
+	Touch(apiKeyID bson.ObjectId, usedAt time.Time) error
+}
+
+// MemberStore -> Persistence layer for Board memberships, a user is a member of a board at most once
+type MemberStore interface {
+	FindByBoardAndUser(boardID, userID bson.ObjectId) (models.BoardMember, error)
+	// FindByBoardID -> Members of given board, oldest first
+	FindByBoardID(boardID bson.ObjectId) ([]models.BoardMember, error)
+	// FindBoardIDsByUserID -> IDs of boards given user is a member of
+	FindBoardIDsByUserID(userID bson.ObjectId) ([]bson.ObjectId, error)
+	// Insert -> Insert a membership, returns ErrDuplicate if the user already is a member of the board
+	Insert(member *models.BoardMember) error
+	Update(member *models.BoardMember) error
+	Delete(member *models.BoardMember) error
+}
+
+// Migration -> Versioned change of the storage schema or data (e.g. backfill of a new field), applied once
+type Migration struct {
+	Version     int
+	Description string
+}
+
+// Migrator -> Runs storage migrations, applied versions are recorded by the storage itself
+type Migrator interface {
+	// Pending -> Migrations which have not been applied yet, in version order
+	Pending() ([]Migration, error)
+	// Migrate -> Apply pending migrations in version order, stop at the first failure and return migrations applied so far
+	Migrate() ([]Migration, error)
+}
+
+// Transactor -> Runs many operations of a Store atomically, for backends supporting transactions across entities
+type Transactor interface {
+	// Atomically -> Run fn, every change made through the Store meanwhile is kept if fn succeeds and discarded otherwise.
+	// Nothing else may use the Store while fn runs
+	Atomically(fn func() error) error
+}
+
+// MigrationConfig -> Settings of migrations backfilling data which can not be derived from stored data
+type MigrationConfig struct {
+	// BoardOwner -> User made owner of boards which have neither an owner nor a known creator, e.g. boards created
+	// before memberships existed
+	BoardOwner bson.ObjectId
+}
+
+// Store -> Every persistence layer used by the application, injected into handlers
+type Store struct {
+	Boards   BoardStore
+	Lists    ListStore
+	Tasks    TaskStore
+	Comments CommentStore
+	Labels   LabelStore
+	Filters  FilterStore
+	Activity ActivityStore
+	Audit    AuditStore
+	Users    UserStore
+	Members  MemberStore
+	ApiKeys  ApiKeyStore
+	Migrator Migrator
+	// Transactor -> nil when the backend does not support transactions (MongoDB)
+	Transactor Transactor
+}
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"strings"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Fields tasks can be sorted by, tasks with equal values remain sorted by order
+const (
+	TaskSortOrder     = "order"
+	TaskSortTitle     = "title"
+	TaskSortPoints    = "points"
+	TaskSortCreatedAt = "createdAt"
+)
+
+// TaskQuery -> Selects tasks of a list or of a whole board, every filter being optional, sorted by one field (see TaskSort constants)
+type TaskQuery struct {
+	// At least one of them is set
+	ListID    bson.ObjectId
+	BoardID   bson.ObjectId
+	Status    *bool
+	PointsMin *float64
+	PointsMax *float64
+	// Whether tasks with exactly PointsMin/PointsMax points are excluded
+	PointsMinExclusive bool
+	PointsMaxExclusive bool
+	// Case insensitive substring of the title
+	Title string
+	// Tasks holding every one of these labels
+	Labels []bson.ObjectId
+	// Tasks due strictly after/before these dates, tasks without due date never match them
+	DueAfter  *time.Time
+	DueBefore *time.Time
+	// Tasks without due date
+	NoDueDate  bool
+	Sort       string
+	Descending bool
+}
+
+// Matches -> Whether given task is selected by the query
+func (q TaskQuery) Matches(task models.Task) bool {
+	if (q.ListID != "" && task.ListId != q.ListID) || (q.BoardID != "" && task.BoardId != q.BoardID) {
+		return false
+	}
+	if q.Status != nil && task.Status != *q.Status {
+		return false
+	}
+	if q.PointsMin != nil && (task.Points < *q.PointsMin || (q.PointsMinExclusive && task.Points == *q.PointsMin)) {
+		return false
+	}
+	if q.PointsMax != nil && (task.Points > *q.PointsMax || (q.PointsMaxExclusive && task.Points == *q.PointsMax)) {
+		return false
+	}
+	for _, labelID := range q.Labels {
+		if !HasID(task.Labels, labelID) {
+			return false
+		}
+	}
+	if q.NoDueDate && task.DueAt != nil {
+		return false
+	}
+	if (q.DueAfter != nil || q.DueBefore != nil) && task.DueAt == nil {
+		return false
+	}
+	if (q.DueAfter != nil && !task.DueAt.After(*q.DueAfter)) || (q.DueBefore != nil && !task.DueAt.Before(*q.DueBefore)) {
+		return false
+	}
+	return strings.Contains(strings.ToLower(task.Title), strings.ToLower(q.Title))
+}
@@ -0,0 +1,144 @@
+package dao
+
+import (
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// As -> Store recording given user as the author of the boards, lists, tasks, labels and filters it inserts or updates,
+// along with the time of the change (see models.Tracking). Moving tasks is recorded as a modification of them, while
+// moving lists and renumbering the siblings of moved entities are not
+func (s Store) As(userID bson.ObjectId) Store {
+	t := tracker{userID: userID, now: time.Now}
+	s.Boards = trackedBoards{s.Boards, t}
+	s.Lists = trackedLists{s.Lists, t}
+	s.Tasks = trackedTasks{s.Tasks, t}
+	s.Labels = trackedLabels{s.Labels, t}
+	s.Filters = trackedFilters{s.Filters, t}
+	return s
+}
+
+// tracker -> Author and clock of the changes made through a tracked store
+type tracker struct {
+	userID bson.ObjectId
+	now    func() time.Time
+}
+
+func (t tracker) created(tracking *models.Tracking) {
+	tracking.TrackCreation(t.userID, t.now())
+}
+
+func (t tracker) updated(tracking *models.Tracking) {
+	tracking.TrackUpdate(t.userID, t.now())
+}
+
+type trackedBoards struct {
+	BoardStore
+	tracker tracker
+}
+
+func (b trackedBoards) Insert(board *models.Board) error {
+	b.tracker.created(&board.Tracking)
+	return b.BoardStore.Insert(board)
+}
+
+func (b trackedBoards) Update(board *models.Board) error {
+	b.tracker.updated(&board.Tracking)
+	return b.BoardStore.Update(board)
+}
+
+type trackedLists struct {
+	ListStore
+	tracker tracker
+}
+
+func (l trackedLists) Insert(list *models.List) error {
+	l.tracker.created(&list.Tracking)
+	return l.ListStore.Insert(list)
+}
+
+func (l trackedLists) Update(list *models.List) error {
+	l.tracker.updated(&list.Tracking)
+	return l.ListStore.Update(list)
+}
+
+type trackedTasks struct {
+	TaskStore
+	tracker tracker
+}
+
+func (t trackedTasks) Insert(task *models.Task) error {
+	t.tracker.created(&task.Tracking)
+	return t.TaskStore.Insert(task)
+}
+
+func (t trackedTasks) Update(task *models.Task) error {
+	t.tracker.updated(&task.Tracking)
+	return t.TaskStore.Update(task)
+}
+
+func (t trackedTasks) UpdateChecklists(task *models.Task, change func(task *models.Task) error) error {
+	t.tracker.updated(&task.Tracking)
+	return t.TaskStore.UpdateChecklists(task, change)
+}
+
+func (t trackedTasks) AddPerson(task *models.Task, people TaskPeople, userID bson.ObjectId) (bool, error) {
+	t.tracker.updated(&task.Tracking)
+	return t.TaskStore.AddPerson(task, people, userID)
+}
+
+func (t trackedTasks) RemovePerson(task *models.Task, people TaskPeople, userID bson.ObjectId) (bool, error) {
+	t.tracker.updated(&task.Tracking)
+	return t.TaskStore.RemovePerson(task, people, userID)
+}
+
+func (t trackedTasks) UpdateAll(tasks []models.Task) error {
+	for i := range tasks {
+		t.tracker.updated(&tasks[i].Tracking)
+	}
+	return t.TaskStore.UpdateAll(tasks)
+}
+
+func (t trackedTasks) Move(task *models.Task, targetListID bson.ObjectId, position int) error {
+	t.tracker.updated(&task.Tracking)
+	return t.TaskStore.Move(task, targetListID, position)
+}
+
+func (t trackedTasks) MoveAll(tasks []models.Task, targetListID bson.ObjectId) error {
+	for i := range tasks {
+		t.tracker.updated(&tasks[i].Tracking)
+	}
+	return t.TaskStore.MoveAll(tasks, targetListID)
+}
+
+type trackedLabels struct {
+	LabelStore
+	tracker tracker
+}
+
+func (l trackedLabels) Insert(label *models.Label) error {
+	l.tracker.created(&label.Tracking)
+	return l.LabelStore.Insert(label)
+}
+
+func (l trackedLabels) Update(label *models.Label) error {
+	l.tracker.updated(&label.Tracking)
+	return l.LabelStore.Update(label)
+}
+
+type trackedFilters struct {
+	FilterStore
+	tracker tracker
+}
+
+func (f trackedFilters) Insert(filter *models.Filter) error {
+	f.tracker.created(&filter.Tracking)
+	return f.FilterStore.Insert(filter)
+}
+
+func (f trackedFilters) Update(filter *models.Filter) error {
+	f.tracker.updated(&filter.Tracking)
+	return f.FilterStore.Update(filter)
+}
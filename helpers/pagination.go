@@ -0,0 +1,42 @@
+package helpers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	log "github.com/sirupsen/logrus"
+)
+
+// Page sizes of paginated listings, when query parameter limit is absent and at most
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// GetPage - Retrieve query parameters limit and offset as a page, respond with Bad Request if they are not valid
+func GetPage(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (dao.Page, bool) {
+	page := dao.Page{Limit: DefaultPageLimit}
+	query := r.URL.Query()
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value < 1 || value > MaxPageLimit {
+			handlerLogger.Warnf("User provided invalid limit: %s", limit)
+			RespondWithError(w, http.StatusBadRequest, "limit must be an integer between 1 and "+strconv.Itoa(MaxPageLimit))
+			return page, false
+		}
+		page.Limit = value
+	}
+
+	if offset := query.Get("offset"); offset != "" {
+		value, err := strconv.Atoi(offset)
+		if err != nil || value < 0 {
+			handlerLogger.Warnf("User provided invalid offset: %s", offset)
+			RespondWithError(w, http.StatusBadRequest, "offset must be a positive integer")
+			return page, false
+		}
+		page.Offset = value
+	}
+	return page, true
+}
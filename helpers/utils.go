@@ -3,9 +3,13 @@ package helpers
 import (
 	"net/http"
 	"encoding/json"
-	"fmt"
 )
 
+type ErrorMessage struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
 type ErrorMessages struct {
 	Messages []string `json:"errors"`
 	Code     int      `json:"code"`
@@ -24,8 +28,12 @@ func writeResponse(w http.ResponseWriter, code int, payload []byte) {
 
 // ---- Write Http response with error message and status code ---- //
 func RespondWithError(w http.ResponseWriter, code int, message string) {
-	response := fmt.Sprintf(`{"status": %v, "message": "%s"}`, code, message)
-	writeResponse(w, code, []byte(response))
+	// Encoded rather than formatted, messages may hold quotes (e.g. user input echoed back)
+	response, err := json.Marshal(ErrorMessage{Status: code, Message: message})
+	if err != nil {
+		panic(err)
+	}
+	writeResponse(w, code, response)
 }
 
 // ---- Write Http response With multiple error messages and status code ---- //
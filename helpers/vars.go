@@ -0,0 +1,21 @@
+package helpers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// GetObjectIdVar - Retrieve route parameter key as an ObjectID, respond with Bad Request if it is not a valid ObjectID
+func GetObjectIdVar(w http.ResponseWriter, r *http.Request, key string, handlerLogger *log.Entry) (bson.ObjectId, bool) {
+	idVar := mux.Vars(r)[key]
+
+	if isObjectID := bson.IsObjectIdHex(idVar); !isObjectID {
+		handlerLogger.Warnf("User provided invalid Object ID for parameter %s", key)
+		RespondWithError(w, http.StatusBadRequest, "Invalid ObjectID")
+		return "", false
+	}
+	return bson.ObjectIdHex(idVar), true
+}
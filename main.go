@@ -16,15 +16,46 @@ func init() {
 
 // Application entry point, create Application Configuration with Environment Variables, generate Router + DB connection
 // And serve the application
+// Subcommand "migrate" applies pending storage migrations and exits, "migrate status" lists them
 func main() {
-	a := config.NewApp(
-		helpers.GetEnv("APP_USERNAME", ""),
-		helpers.GetEnv("APP_PASSWORD", ""),
-		helpers.GetEnv("APP_DB_NAME", "taco"),
-		helpers.GetEnv("APP_DB_HOST", "localhost"),
-		helpers.GetEnv("APP_DB_PORT", "27017"))
+	a := config.NewApp(config.StorageConfigFromEnv(), config.AuthConfigFromEnv())
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		defer a.Close()
+		if len(os.Args) > 2 && os.Args[2] == "status" {
+			migrateStatus(a)
+			return
+		}
+		if err := a.Migrate(); err != nil {
+			logrus.Fatalf("Migration failed, got error: %s", err.Error())
+		}
+		return
+	}
+
+	// Pending migrations are applied at startup, unless disabled (e.g. when running "migrate" as a deployment step)
+	if helpers.GetEnv("APP_MIGRATE_ON_START", "true") == "true" {
+		if err := a.Migrate(); err != nil {
+			logrus.Fatalf("Migration failed, got error: %s", err.Error())
+		}
+	}
 
 	// Get Port from Environment Variables and start server (Listen on given Port)
 	port := fmt.Sprintf(":%s", helpers.GetEnv("APP_PORT", "8080"))
 	a.Run(port)
 }
+
+// Print migrations which have not been applied yet
+func migrateStatus(a config.App) {
+	pending, err := a.Store.Migrator.Pending()
+	if err != nil {
+		logrus.Fatalf("Could not retrieve pending migrations, got error: %s", err.Error())
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("Storage is up to date")
+		return
+	}
+	for _, migration := range pending {
+		fmt.Printf("Pending migration %d: %s\n", migration.Version, migration.Description)
+	}
+}
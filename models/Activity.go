@@ -0,0 +1,105 @@
+package models
+
+import (
+	"reflect"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Verbs of activities and actions of audit entries: what happened to the entity
+const (
+	VerbCreated = "created"
+	VerbUpdated = "updated"
+	VerbDeleted = "deleted"
+	VerbMoved   = "moved"
+	// VerbLoggedIn -> A user exchanged its credentials for a token, audit log only
+	VerbLoggedIn = "loggedIn"
+)
+
+// Types of entities activities (lists and tasks) and audit entries (every entity) are recorded for
+const (
+	EntityBoard   = "board"
+	EntityMember  = "member"
+	EntityLabel   = "label"
+	EntityList    = "list"
+	EntityTask    = "task"
+	EntityComment = "comment"
+	EntityApiKey  = "apiKey"
+	EntityFilter  = "filter"
+	EntityUser    = "user"
+)
+
+// ActivitySummary -> Main fields of an entity (e.g. title and list of a task), as recorded by an Activity
+type ActivitySummary map[string]interface{}
+
+// Activity Structure, records a change made by a user (actor) on an entity of a Board
+type Activity struct {
+	ActivityId bson.ObjectId `bson:"_id" json:"activityId"`
+	BoardId    bson.ObjectId `bson:"boardId" json:"boardId"`
+	ActorId    bson.ObjectId `bson:"actorId" json:"actorId"`
+	Verb       string        `bson:"verb" json:"verb"`
+	EntityType string        `bson:"entityType" json:"entityType"`
+	EntityId   bson.ObjectId `bson:"entityId" json:"entityId"`
+	// Summary of the entity before and after the change, only changed fields for updates and moves
+	Before    ActivitySummary `bson:"before,omitempty" json:"before,omitempty"`
+	After     ActivitySummary `bson:"after,omitempty" json:"after,omitempty"`
+	CreatedAt time.Time       `bson:"createdAt" json:"createdAt"`
+}
+
+// Initialize Activity structure with a new ID, and creation timestamp set to now
+func NewActivity(boardID, actorID bson.ObjectId, verb, entityType string, entityID bson.ObjectId) Activity {
+	return Activity{
+		ActivityId: bson.NewObjectId(),
+		BoardId:    boardID,
+		ActorId:    actorID,
+		Verb:       verb,
+		EntityType: entityType,
+		EntityId:   entityID,
+		CreatedAt:  time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
+
+// SetChanges -> Record the entity's summaries before and after the change. When both are given (update, move),
+// only fields which differ are kept. Returns false when nothing changed
+func (a *Activity) SetChanges(before, after ActivitySummary) bool {
+	if before == nil || after == nil {
+		a.Before, a.After = before, after
+		return true
+	}
+
+	a.Before, a.After = ActivitySummary{}, ActivitySummary{}
+	for key, value := range after {
+		if !reflect.DeepEqual(before[key], value) {
+			a.Before[key], a.After[key] = before[key], value
+		}
+	}
+	return len(a.After) > 0
+}
+
+// ActivitySummary -> Fields of a List recorded by activities
+func (l *List) ActivitySummary() ActivitySummary {
+	return ActivitySummary{"name": l.Name, "order": l.Order}
+}
+
+// ActivitySummary -> Fields of a Task recorded by activities, identifiers and dates as strings
+func (t *Task) ActivitySummary() ActivitySummary {
+	assignees := make([]string, len(t.Assignees))
+	for i, id := range t.Assignees {
+		assignees[i] = id.Hex()
+	}
+	var dueAt interface{}
+	if t.DueAt != nil {
+		dueAt = t.DueAt.Format(time.RFC3339Nano)
+	}
+
+	return ActivitySummary{
+		"title":     t.Title,
+		"listId":    t.ListId.Hex(),
+		"order":     t.Order,
+		"status":    t.Status,
+		"points":    t.Points,
+		"dueAt":     dueAt,
+		"assignees": assignees,
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Scopes of an API key: read-only keys may only send safe requests (GET, HEAD, OPTIONS)
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+)
+
+// apiKeyPrefix -> Every API key starts with this prefix, so that leaked keys are easy to recognize
+const apiKeyPrefix = "taco_"
+
+// ApiKey Structure, a revocable credential of a User for scripts and integrations. Only the key's hash is stored
+type ApiKey struct {
+	ApiKeyId bson.ObjectId `bson:"_id" json:"apiKeyId"`
+	UserId   bson.ObjectId `bson:"userId" json:"userId"`
+	Name     string        `bson:"name" json:"name" onCreate:"nonzero,max=100"`
+	Scope    string        `bson:"scope" json:"scope" onCreate:"nonzero,regexp=^(read|write)$"`
+	// First characters of the key, to tell keys apart
+	Prefix     string     `bson:"prefix" json:"prefix"`
+	Hash       string     `bson:"hash" json:"-"`
+	ExpiresAt  *time.Time `bson:"expiresAt,omitempty" json:"expiresAt,omitempty"`
+	LastUsedAt *time.Time `bson:"lastUsedAt,omitempty" json:"lastUsedAt,omitempty"`
+	CreatedAt  time.Time  `bson:"createdAt" json:"createdAt"`
+}
+
+// Initialize ApiKey structure with creation timestamp set to now, and full (write) scope
+func NewApiKey() ApiKey {
+	return ApiKey{Scope: ScopeWrite, CreatedAt: time.Now().UTC().Truncate(time.Millisecond)}
+}
+
+// HashApiKey -> Hash of a key as stored, keys are random enough for a fast hash not to be brute-forced
+func HashApiKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Generate -> Generate a new random key, store its hash and prefix, and return the key (it can not be retrieved later)
+func (k *ApiKey) Generate() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+	k.Prefix = key[:len(apiKeyPrefix)+6]
+	k.Hash = HashApiKey(key)
+	return key, nil
+}
+
+// IsExpired -> Whether the key has an expiration date which is past
+func (k ApiKey) IsExpired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
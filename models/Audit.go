@@ -0,0 +1,80 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Snapshot -> Full JSON representation of an entity, as recorded by an AuditEntry
+type Snapshot map[string]interface{}
+
+// NewSnapshot -> Snapshot of given entity as it is rendered by the API, nil if it can not be encoded as a JSON object
+func NewSnapshot(entity interface{}) Snapshot {
+	encoded, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(encoded, &snapshot); err != nil {
+		return nil
+	}
+	return snapshot
+}
+
+// AuditEntry Structure, append-only record of a mutation: who made it, from which request, and the entity before and after it
+type AuditEntry struct {
+	AuditId    bson.ObjectId `bson:"_id" json:"auditId"`
+	Action     string        `bson:"action" json:"action"`
+	EntityType string        `bson:"entityType" json:"entityType"`
+	EntityId   bson.ObjectId `bson:"entityId" json:"entityId"`
+	// Board the entity belongs to, empty for entities outside of boards (e.g. API keys)
+	BoardId bson.ObjectId `bson:"boardId,omitempty" json:"boardId,omitempty"`
+	// Authenticated principal: the user, and the API key used when authenticated with one
+	ActorId   bson.ObjectId `bson:"actorId" json:"actorId"`
+	ApiKeyId  bson.ObjectId `bson:"apiKeyId,omitempty" json:"apiKeyId,omitempty"`
+	RequestId string        `bson:"requestId" json:"requestId"`
+	ClientIP  string        `bson:"clientIp" json:"clientIp"`
+	// Entity before and after the mutation, nil for creations/deletions respectively
+	Before Snapshot `bson:"before,omitempty" json:"before,omitempty"`
+	After  Snapshot `bson:"after,omitempty" json:"after,omitempty"`
+	// Top-level fields which differ between Before and After, sorted
+	ChangedFields []string  `bson:"changedFields" json:"changedFields"`
+	CreatedAt     time.Time `bson:"createdAt" json:"createdAt"`
+}
+
+// Initialize AuditEntry structure with a new ID, and creation timestamp set to now
+func NewAuditEntry(action, entityType string, boardID, entityID bson.ObjectId) AuditEntry {
+	return AuditEntry{
+		AuditId:       bson.NewObjectId(),
+		Action:        action,
+		EntityType:    entityType,
+		EntityId:      entityID,
+		BoardId:       boardID,
+		ChangedFields: []string{},
+		CreatedAt:     time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
+
+// SetSnapshots -> Record the entity before and after the mutation, along with the fields it changed
+func (e *AuditEntry) SetSnapshots(before, after Snapshot) {
+	e.Before, e.After = before, after
+
+	changed := []string{}
+	for key, value := range after {
+		if previous, ok := before[key]; !ok || !reflect.DeepEqual(previous, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	sort.Strings(changed)
+	e.ChangedFields = changed
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Board Structure, represents Board Document from Database, a Board holds Lists which hold Tasks
+type Board struct {
+	BoardId     bson.ObjectId `bson:"_id" json:"boardId"`
+	Name        string        `bson:"name" json:"name" onCreate:"nonzero,max=50"`
+	Description string        `bson:"description" json:"description" onCreate:"max=500"`
+	Tracking    `bson:",inline"`
+}
+
+// Hydrate a Board structure from a map of string -> interface
+func (b *Board) HydrateFromMap(json map[string]interface{}) {
+	if name, ok := json["name"].(string); ok {
+		b.Name = name
+	}
+
+	if description, ok := json["description"].(string); ok {
+		b.Description = description
+	}
+}
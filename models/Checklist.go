@@ -0,0 +1,76 @@
+package models
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Checklist Structure, a named list of items to check, embedded in a Task
+type Checklist struct {
+	ChecklistId bson.ObjectId   `bson:"_id" json:"checklistId"`
+	Name        string          `bson:"name" json:"name" onCreate:"nonzero,max=100"`
+	Items       []ChecklistItem `bson:"items" json:"items"`
+	// Computed when the checklist is returned by the API, never stored
+	Progress Progress `bson:"-" json:"progress"`
+}
+
+// ChecklistItem Structure, an entry of a Checklist, items are sorted by their position in the checklist
+type ChecklistItem struct {
+	ItemId  bson.ObjectId `bson:"_id" json:"itemId"`
+	Text    string        `bson:"text" json:"text" onCreate:"nonzero,max=200"`
+	Checked bool          `bson:"checked" json:"checked"`
+}
+
+// Progress -> Number of checked items out of the total number of items
+type Progress struct {
+	Checked int `json:"checked"`
+	Total   int `json:"total"`
+}
+
+// NewChecklist -> Initialize Checklist structure with a new ID and no item
+func NewChecklist() Checklist {
+	return Checklist{ChecklistId: bson.NewObjectId(), Items: []ChecklistItem{}}
+}
+
+// NewChecklistItem -> Initialize ChecklistItem structure with a new ID, unchecked
+func NewChecklistItem() ChecklistItem {
+	return ChecklistItem{ItemId: bson.NewObjectId()}
+}
+
+// ComputeProgress -> Count checked items of the checklist
+func (c *Checklist) ComputeProgress() {
+	c.Progress = Progress{Total: len(c.Items)}
+	for _, item := range c.Items {
+		if item.Checked {
+			c.Progress.Checked++
+		}
+	}
+}
+
+// FindItem -> Index of the item with given ID in the checklist, -1 if there is none
+func (c *Checklist) FindItem(itemID bson.ObjectId) int {
+	for i, item := range c.Items {
+		if item.ItemId == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// RemoveItem -> Remove item at given index from the checklist
+func (c *Checklist) RemoveItem(index int) {
+	c.Items = append(c.Items[:index:index], c.Items[index+1:]...)
+}
+
+// MoveItem -> Move item at given index to given position (starting at 1), position is clamped to checklist boundaries
+func (c *Checklist) MoveItem(index, position int) {
+	item := c.Items[index]
+	c.RemoveItem(index)
+	if position < 1 || position > len(c.Items)+1 {
+		position = len(c.Items) + 1
+	}
+
+	items := make([]ChecklistItem, 0, len(c.Items)+1)
+	items = append(items, c.Items[:position-1]...)
+	items = append(items, item)
+	c.Items = append(items, c.Items[position-1:]...)
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Comment Structure, a message of a board member (its author) on a Task
+type Comment struct {
+	CommentId bson.ObjectId `bson:"_id" json:"commentId"`
+	TaskId    bson.ObjectId `bson:"taskId" json:"taskId"`
+	BoardId   bson.ObjectId `bson:"boardId" json:"boardId"`
+	AuthorId  bson.ObjectId `bson:"authorId" json:"authorId"`
+	Body      string        `bson:"body" json:"body" onCreate:"nonzero,max=2000"`
+	CreatedAt time.Time     `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time     `bson:"updatedAt" json:"updatedAt"`
+}
+
+// Initialize Comment structure with creation/update timestamps set to now (millisecond precision, as stored by Mongo)
+func NewComment() Comment {
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	return Comment{CreatedAt: now, UpdatedAt: now}
+}
+
+// Touch -> Refresh Comment's update timestamp
+func (c *Comment) Touch() {
+	c.UpdatedAt = time.Now().UTC().Truncate(time.Millisecond)
+}
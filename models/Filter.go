@@ -0,0 +1,26 @@
+package models
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Filter Structure, a named task filter expression (e.g. status:false label:bug) saved on a Board, shared with its members
+type Filter struct {
+	FilterId bson.ObjectId `bson:"_id" json:"filterId"`
+	BoardId  bson.ObjectId `bson:"boardId" json:"boardId"`
+	Name     string        `bson:"name" json:"name" onCreate:"nonzero,max=50"`
+	// Expression, see package taskfilter
+	Query    string `bson:"query" json:"query" onCreate:"nonzero,max=500"`
+	Tracking `bson:",inline"`
+}
+
+// Hydrate a Filter structure from a map of string -> interface
+func (f *Filter) HydrateFromMap(json map[string]interface{}) {
+	if name, ok := json["name"].(string); ok {
+		f.Name = name
+	}
+
+	if query, ok := json["query"].(string); ok {
+		f.Query = query
+	}
+}
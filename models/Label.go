@@ -0,0 +1,26 @@
+package models
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Label Structure, a tag defined on a Board (e.g. bug, feature) which can be attached to the board's Tasks
+type Label struct {
+	LabelId bson.ObjectId `bson:"_id" json:"labelId"`
+	BoardId bson.ObjectId `bson:"boardId" json:"boardId"`
+	Name    string        `bson:"name" json:"name" onCreate:"nonzero,max=30"`
+	// Hexadecimal RGB color, e.g. #ff0000
+	Color    string `bson:"color" json:"color" onCreate:"regexp=^#[0-9a-fA-F]{6}$"`
+	Tracking `bson:",inline"`
+}
+
+// Hydrate a Label structure from a map of string -> interface
+func (l *Label) HydrateFromMap(json map[string]interface{}) {
+	if name, ok := json["name"].(string); ok {
+		l.Name = name
+	}
+
+	if color, ok := json["color"].(string); ok {
+		l.Color = color
+	}
+}
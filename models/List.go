@@ -3,10 +3,21 @@ package models
 import "gopkg.in/mgo.v2/bson"
 
 type List struct {
-	ListId bson.ObjectId `bson:"_id" json:"listId"`
-	Name   string        `bson:"name" json:"name" onCreate:"nonzero,max=30,regexp=^[a-zA-Z-_ ]*$"`
-	Order  int           `bson:"order" json:"order"`
-	Tasks  []Task        `bson:"tasks" json:"tasks"`
+	ListId   bson.ObjectId `bson:"_id" json:"listId"`
+	BoardId  bson.ObjectId `bson:"boardId" json:"boardId"`
+	Name     string        `bson:"name" json:"name" onCreate:"nonzero,max=30,regexp=^[a-zA-Z-_ ]*$"`
+	Order    int           `bson:"order" json:"order" onCreate:"min=0"`
+	Tasks    []Task        `bson:"tasks" json:"tasks"`
+	Summary  *TaskSummary  `bson:"-" json:"summary,omitempty"`
+	Tracking `bson:",inline"`
+}
+
+// TaskSummary -> Aggregated figures about a List's tasks, computed on read
+type TaskSummary struct {
+	Count      int     `json:"count"`
+	Done       int     `json:"done"`
+	Points     float64 `json:"points"`
+	DonePoints float64 `json:"donePoints"`
 }
 
 // Initialize List structure with empty array of task
@@ -16,9 +27,27 @@ func NewList() List {
 	return list
 }
 
-// Hydrate a List structure from a map of string -> interface
+// Hydrate a List structure from a map of string -> interface, null attributes and attributes of another type are left unchanged
 func (l *List) HydrateFromMap(json map[string]interface{}) {
-	if name, ok := json["name"]; ok {
-		l.Name = name.(string)
+	if name, ok := json["name"].(string); ok {
+		l.Name = name
+	}
+
+	if order, ok := json["order"].(float64); ok {
+		l.Order = int(order)
+	}
+}
+
+// Summarize -> Compute the List's TaskSummary from given tasks
+func (l *List) Summarize(tasks []Task) {
+	summary := TaskSummary{}
+	for _, task := range tasks {
+		summary.Count++
+		summary.Points += task.Points
+		if task.Status {
+			summary.Done++
+			summary.DonePoints += task.Points
+		}
 	}
+	l.Summary = &summary
 }
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Roles a User can have on a Board: owners manage the board and its members, editors edit lists and tasks,
+// viewers have a read-only access
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+// roleRanks -> Roles sorted by privileges, a role grants everything lower ranked roles do
+var roleRanks = map[string]int{RoleViewer: 1, RoleEditor: 2, RoleOwner: 3}
+
+// BoardMember Structure, grants a User a Role on a Board
+type BoardMember struct {
+	BoardId   bson.ObjectId `bson:"boardId" json:"boardId"`
+	UserId    bson.ObjectId `bson:"userId" json:"userId"`
+	Role      string        `bson:"role" json:"role" onCreate:"nonzero,regexp=^(owner|editor|viewer)$"`
+	CreatedAt time.Time     `bson:"createdAt" json:"createdAt"`
+}
+
+// Initialize BoardMember structure granting given role, with creation timestamp set to now
+func NewBoardMember(boardID, userID bson.ObjectId, role string) BoardMember {
+	return BoardMember{BoardId: boardID, UserId: userID, Role: role, CreatedAt: time.Now().UTC().Truncate(time.Millisecond)}
+}
+
+// HasRole -> Whether member's role grants at least the privileges of given role
+func (m BoardMember) HasRole(role string) bool {
+	return roleRanks[m.Role] >= roleRanks[role] && roleRanks[m.Role] > 0
+}
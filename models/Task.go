@@ -1,16 +1,41 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
+	"time"
+
 	"gopkg.in/mgo.v2/bson"
 )
 
+// ErrDueBeforeStart -> Returned by Task.CheckDates when a task is due before it starts
+var ErrDueBeforeStart = errors.New("dueAt must be after startAt")
+
 // Task Structure, represents Task Document from Database
 type Task struct {
 	TaskId      bson.ObjectId `bson:"_id" json:"taskId"`
+	ListId      bson.ObjectId `bson:"listId" json:"listId"`
+	BoardId     bson.ObjectId `bson:"boardId" json:"boardId"`
 	Title       string        `bson:"title" json:"title" onCreate:"nonzero,max=200"`
 	Description string        `bson:"description" json:"description" onCreate:"max=500"`
 	Status      bool          `bson:"status" json:"status"`
 	Points      float64       `bson:"points" json:"points" onCreate:"min=0,max=100"`
+	Order       int           `bson:"order" json:"order"`
+	// Optional schedule of the task, dueComplete marks the deadline as met
+	StartAt     *time.Time `bson:"startAt,omitempty" json:"startAt"`
+	DueAt       *time.Time `bson:"dueAt,omitempty" json:"dueAt"`
+	DueComplete bool       `bson:"dueComplete" json:"dueComplete"`
+	// IDs of the board's labels attached to the task
+	Labels     []bson.ObjectId `bson:"labels" json:"labels"`
+	Checklists []Checklist     `bson:"checklists" json:"checklists"`
+	// IDs of the board members responsible for the task, and of the ones following it
+	Assignees []bson.ObjectId `bson:"assignees" json:"assignees"`
+	Watchers  []bson.ObjectId `bson:"watchers" json:"watchers"`
+	// Checked items out of every item of the task's checklists, computed when the task is returned by the API, never stored
+	Progress Progress `bson:"-" json:"progress"`
+	// Number of comments, computed when the task is returned by the API, never stored
+	CommentCount int `bson:"-" json:"commentCount"`
+	Tracking     `bson:",inline"`
 }
 
 // Set Default Status to a Task Entity
@@ -18,21 +43,132 @@ func (t *Task) SetDefaultStatus() {
 	t.Status = false
 }
 
-// Hydrate a Task structure from a map of string -> interface
+// MarshalJSON -> Encode Task to JSON along with the progress of its checklists
+func (t Task) MarshalJSON() ([]byte, error) {
+	// Alias type without methods, prevents infinite recursion
+	type task Task
+	t.ComputeProgress()
+	return json.Marshal(task(t))
+}
+
+// ComputeProgress -> Count checked items of each checklist of the task, and of the whole task
+func (t *Task) ComputeProgress() {
+	// Do not modify checklists shared with the caller
+	t.Checklists = append([]Checklist{}, t.Checklists...)
+	t.Progress = Progress{}
+	for i := range t.Checklists {
+		t.Checklists[i].ComputeProgress()
+		t.Progress.Checked += t.Checklists[i].Progress.Checked
+		t.Progress.Total += t.Checklists[i].Progress.Total
+	}
+}
+
+// FindChecklist -> Index of the checklist with given ID in the task, -1 if there is none
+func (t *Task) FindChecklist(checklistID bson.ObjectId) int {
+	for i, checklist := range t.Checklists {
+		if checklist.ChecklistId == checklistID {
+			return i
+		}
+	}
+	return -1
+}
+
+// Hydrate a Task structure from a map of string -> interface, null attributes are left unchanged except dates which are removed
 func (t *Task) HydrateFromMap(json map[string]interface{}) {
-	if title, ok := json["title"]; ok {
-		t.Title = title.(string)
+	if title, ok := json["title"].(string); ok {
+		t.Title = title
+	}
+
+	if description, ok := json["description"].(string); ok {
+		t.Description = description
+	}
+
+	if points, ok := json["points"].(float64); ok {
+		t.Points = points
+	}
+
+	if status, ok := json["status"].(bool); ok {
+		t.Status = status
+	}
+
+	if startAt, ok := json["startAt"]; ok {
+		t.StartAt = parseTime(startAt)
+	}
+
+	if dueAt, ok := json["dueAt"]; ok {
+		t.DueAt = parseTime(dueAt)
 	}
 
-	if description, ok := json["description"]; ok {
-		t.Description = description.(string)
+	if dueComplete, ok := json["dueComplete"].(bool); ok {
+		t.DueComplete = dueComplete
 	}
 
-	if points, ok := json["points"]; ok {
-		t.Points = points.(float64)
+	if labels, ok := json["labels"].([]interface{}); ok {
+		t.Labels = []bson.ObjectId{}
+		for _, label := range labels {
+			if id, ok := label.(string); ok && bson.IsObjectIdHex(id) {
+				t.Labels = append(t.Labels, bson.ObjectIdHex(id))
+			}
+		}
 	}
+}
+
+// parseTime -> Timestamp from a JSON value (RFC 3339 string), nil when the value is null or not a valid timestamp
+func parseTime(value interface{}) *time.Time {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+// NormalizeDates -> Store start and due dates in UTC, with the millisecond precision of every storage backend
+func (t *Task) NormalizeDates() {
+	for _, date := range []**time.Time{&t.StartAt, &t.DueAt} {
+		if *date != nil {
+			normalized := (*date).UTC().Truncate(time.Millisecond)
+			*date = &normalized
+		}
+	}
+}
+
+// CheckDates -> Make sure the task is not due before it starts, when it has both dates
+func (t *Task) CheckDates() error {
+	if t.StartAt != nil && t.DueAt != nil && t.DueAt.Before(*t.StartAt) {
+		return ErrDueBeforeStart
+	}
+	return nil
+}
+
+// IsDue -> Whether the task is due before given deadline and its due date has not been marked complete
+func (t *Task) IsDue(before time.Time) bool {
+	return t.DueAt != nil && !t.DueComplete && t.DueAt.Before(before)
+}
+
+// SetDefaultLabels -> Make sure Task's labels are an empty list rather than null when none is attached
+func (t *Task) SetDefaultLabels() {
+	if t.Labels == nil {
+		t.Labels = []bson.ObjectId{}
+	}
+}
+
+// SetDefaultPeople -> Make sure Task's assignees and watchers are empty lists rather than null when it has none
+func (t *Task) SetDefaultPeople() {
+	if t.Assignees == nil {
+		t.Assignees = []bson.ObjectId{}
+	}
+	if t.Watchers == nil {
+		t.Watchers = []bson.ObjectId{}
+	}
+}
 
-	if status, ok := json["status"]; ok {
-		t.Status = status.(bool)
+// SetDefaultChecklists -> Make sure Task's checklists are an empty list rather than null when it has none
+func (t *Task) SetDefaultChecklists() {
+	if t.Checklists == nil {
+		t.Checklists = []Checklist{}
 	}
 }
\ No newline at end of file
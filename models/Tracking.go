@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Tracking -> When and by whom an entity was created and last modified, set by the persistence layer (see dao.Store.As)
+type Tracking struct {
+	CreatedAt time.Time `bson:"createdAt" json:"createdAt"`
+	UpdatedAt time.Time `bson:"updatedAt" json:"updatedAt"`
+	// Users who created and last modified the entity, empty for entities created before authors were recorded
+	CreatedBy bson.ObjectId `bson:"createdBy,omitempty" json:"createdBy,omitempty"`
+	UpdatedBy bson.ObjectId `bson:"updatedBy,omitempty" json:"updatedBy,omitempty"`
+}
+
+// TrackCreation -> Record that given user created the entity at given time (millisecond precision, as stored by Mongo)
+func (t *Tracking) TrackCreation(userID bson.ObjectId, at time.Time) {
+	t.CreatedAt, t.CreatedBy = at.UTC().Truncate(time.Millisecond), userID
+	t.UpdatedAt, t.UpdatedBy = t.CreatedAt, userID
+}
+
+// TrackUpdate -> Record that given user modified the entity at given time
+func (t *Tracking) TrackUpdate(userID bson.ObjectId, at time.Time) {
+	t.UpdatedAt, t.UpdatedBy = at.UTC().Truncate(time.Millisecond), userID
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// User Structure, represents an account allowed to use the API, identified by its email
+type User struct {
+	UserId       bson.ObjectId `bson:"_id" json:"userId"`
+	Email        string        `bson:"email" json:"email" onCreate:"nonzero,max=254,regexp=^[^@ ]+@[^@ ]+$"`
+	Name         string        `bson:"name" json:"name" onCreate:"max=100"`
+	PasswordHash string        `bson:"passwordHash" json:"-"`
+	CreatedAt    time.Time     `bson:"createdAt" json:"createdAt"`
+}
+
+// Initialize User structure with creation timestamp set to now (millisecond precision, as stored by Mongo)
+func NewUser() User {
+	return User{CreatedAt: time.Now().UTC().Truncate(time.Millisecond)}
+}
+
+// NormalizeEmail -> Emails are compared case insensitively, without surrounding spaces
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// SetPassword -> Hash given password with bcrypt, only the hash is stored
+func (u *User) SetPassword(password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.PasswordHash = string(hash)
+	return nil
+}
+
+// CheckPassword -> Whether given password matches User's password hash
+func (u *User) CheckPassword(password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte(password)) == nil
+}
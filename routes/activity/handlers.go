@@ -0,0 +1,105 @@
+package activity
+
+import (
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var activityLogger *log.Entry
+
+func init() {
+	activityLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceActivityLogger)
+}
+
+// Record -> Record a change made on an entity of a board by the user sending given request, before/after summaries
+// are nil for creations/deletions. Failures are logged, they never fail the request which made the change
+func Record(r *http.Request, s dao.Store, boardID bson.ObjectId, verb, entityType string, entityID bson.ObjectId, before, after models.ActivitySummary, handlerLogger *log.Entry) {
+	user, _ := auth.UserFromContext(r.Context())
+
+	activity := models.NewActivity(boardID, user.UserId, verb, entityType, entityID)
+	if !activity.SetChanges(before, after) {
+		return
+	}
+
+	if err := s.Activity.Insert(&activity); err != nil {
+		handlerLogger.Warnf("Could not record activity %s %s %s, got error: %s", verb, entityType, entityID.Hex(), err.Error())
+	}
+}
+
+// respondWithActivities -> Respond with a page (query parameters limit and offset) of the activities selected by given query
+func respondWithActivities(w http.ResponseWriter, r *http.Request, query dao.ActivityQuery, handlerLogger *log.Entry) {
+	page, ok := helpers.GetPage(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	activities, err := store.Activity.Find(query, page)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve activity of board %s, got error: %s", query.BoardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	total, err := store.Activity.Count(query)
+	if err != nil {
+		handlerLogger.Errorf("Could not count activity of board %s, got error: %s", query.BoardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, ActivityApiResponse{Activities: activities, Total: total, Limit: page.Limit, Offset: page.Offset})
+}
+
+// BoardActivityHandler -> Handler for Board Activity Endpoint, lists changes made on the board's lists and tasks, newest first
+func BoardActivityHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	respondWithActivities(w, r, dao.ActivityQuery{BoardID: boardID}, handlerLogger)
+}
+
+// TaskActivityHandler -> Handler for Task Activity Endpoint, lists changes made on the task, newest first
+func TaskActivityHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	listID, ok := helpers.GetObjectIdVar(w, r, "listId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	taskID, ok := helpers.GetObjectIdVar(w, r, "taskId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	task, err := store.Tasks.FindByListAndID(listID, taskID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve task %s, got error: %s", taskID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	if err != nil || task.BoardId != boardID {
+		handlerLogger.Warnf("Task not found with id: %s in list %s of board %s", taskID.Hex(), listID.Hex(), boardID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Task does not exist")
+		return
+	}
+
+	respondWithActivities(w, r, dao.ActivityQuery{BoardID: boardID, EntityType: models.EntityTask, EntityID: taskID}, handlerLogger)
+}
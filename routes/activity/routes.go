@@ -0,0 +1,27 @@
+package activity
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for the Activity feed of a Board
+func InitRoutes(activityRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Board Activity Listing ---- //
+	activityRouter.HandleFunc("", BoardActivityHandler).Methods("GET")
+	activityRouter.HandleFunc("/", BoardActivityHandler).Methods("GET")
+}
+
+// Initialize Routes for the Activity feed of a Task
+func InitTaskRoutes(taskActivityRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Task Activity Listing ---- //
+	taskActivityRouter.HandleFunc("", TaskActivityHandler).Methods("GET")
+	taskActivityRouter.HandleFunc("/", TaskActivityHandler).Methods("GET")
+}
@@ -0,0 +1,13 @@
+package activity
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// ActivityApiResponse -> Response of activity listing endpoints, a page of activities along with the total number of activities
+type ActivityApiResponse struct {
+	Activities []models.Activity `json:"activities"`
+	Total      int               `json:"total"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+}
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var adminLogger *log.Entry
+
+func init() {
+	adminLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceAdminLogger)
+}
+
+// parseAuditQuery -> Audit query from query parameters entityType, entityId, actorId, from and to (RFC 3339),
+// respond with Bad Request if one of them is not valid
+func parseAuditQuery(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (dao.AuditQuery, bool) {
+	params := r.URL.Query()
+	query := dao.AuditQuery{EntityType: params.Get("entityType")}
+
+	for name, id := range map[string]*bson.ObjectId{"entityId": &query.EntityID, "actorId": &query.ActorID} {
+		value := params.Get(name)
+		if value == "" {
+			continue
+		}
+		if !bson.IsObjectIdHex(value) {
+			handlerLogger.Warnf("User provided invalid %s: %s", name, value)
+			helpers.RespondWithError(w, http.StatusBadRequest, name+" must be a valid identifier")
+			return query, false
+		}
+		*id = bson.ObjectIdHex(value)
+	}
+
+	for name, date := range map[string]**time.Time{"from": &query.From, "to": &query.To} {
+		value := params.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			handlerLogger.Warnf("User provided invalid %s: %s", name, value)
+			helpers.RespondWithError(w, http.StatusBadRequest, name+" must be an RFC 3339 timestamp")
+			return query, false
+		}
+		parsed = parsed.UTC()
+		*date = &parsed
+	}
+	return query, true
+}
+
+// AuditIndexHandler -> Handler for Audit Log Endpoint, lists audit entries newest first, filtered by entity, actor and time range
+func AuditIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+
+	query, ok := parseAuditQuery(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	page, ok := helpers.GetPage(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	entries, err := store.Audit.Find(query, page)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve audit log, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	total, err := store.Audit.Count(query)
+	if err != nil {
+		handlerLogger.Errorf("Could not count audit log entries, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, AuditApiResponse{Entries: entries, Total: total, Limit: page.Limit, Offset: page.Offset})
+}
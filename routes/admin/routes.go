@@ -0,0 +1,18 @@
+package admin
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for Administration endpoints
+func InitRoutes(adminRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Audit Log Listing ---- //
+	adminRouter.HandleFunc("/audit", AuditIndexHandler).Methods("GET")
+	adminRouter.HandleFunc("/audit/", AuditIndexHandler).Methods("GET")
+}
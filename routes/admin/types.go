@@ -0,0 +1,13 @@
+package admin
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// AuditApiResponse -> Response of audit log endpoint, a page of entries along with the total number of selected entries
+type AuditApiResponse struct {
+	Entries []models.AuditEntry `json:"entries"`
+	Total   int                 `json:"total"`
+	Limit   int                 `json:"limit"`
+	Offset  int                 `json:"offset"`
+}
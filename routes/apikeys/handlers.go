@@ -0,0 +1,129 @@
+package apikeys
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var apiKeyLogger *log.Entry
+
+func init() {
+	apiKeyLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceApiKeysLogger)
+}
+
+// ApiKeyIndexHandler -> Handler for API Key Listing Endpoint, lists keys of the current user (never the keys themselves)
+func ApiKeyIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+
+	apiKeys, err := store.ApiKeys.FindByUserID(user.UserId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve API keys of user %s, got error: %s", user.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, ApiKeyApiResponse{ApiKeys: apiKeys})
+}
+
+// ApiKeyCreateHandler -> Handler for API Key Creation Endpoint, the generated key is only returned in this response
+func ApiKeyCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+	apiKey := models.NewApiKey()
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return
+	}
+
+	var body ApiKeyCreate
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	apiKey.Name = body.Name
+	if body.Scope != "" {
+		apiKey.Scope = body.Scope
+	}
+	if errs := helpers.Validate(apiKey, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on API key creation, got error: %s", errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return
+	}
+
+	if body.ExpiresAt != nil {
+		expiresAt := body.ExpiresAt.UTC().Truncate(time.Millisecond)
+		if !expiresAt.After(apiKey.CreatedAt) {
+			handlerLogger.Warnf("API key expiration date %s is not in the future", expiresAt)
+			helpers.RespondWithError(w, http.StatusBadRequest, "ExpiresAt: must be in the future")
+			return
+		}
+		apiKey.ExpiresAt = &expiresAt
+	}
+
+	key, err := apiKey.Generate()
+	if err != nil {
+		handlerLogger.Errorf("Could not generate API key, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not generate API key")
+		return
+	}
+	apiKey.ApiKeyId = bson.NewObjectId()
+	apiKey.UserId = user.UserId
+
+	if err := store.ApiKeys.Insert(&apiKey); err != nil {
+		handlerLogger.Errorf("Could not insert API key of user %s, got error: %s", user.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, "", models.VerbCreated, models.EntityApiKey, apiKey.ApiKeyId, nil, models.NewSnapshot(apiKey), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusCreated, CreatedApiKey{ApiKey: apiKey, Key: key})
+}
+
+// ApiKeyDeleteHandler -> Handler for API Key Revocation Endpoint, the key is rejected from then on
+func ApiKeyDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+
+	apiKeyID, ok := helpers.GetObjectIdVar(w, r, "apiKeyId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	apiKey, err := store.ApiKeys.FindByUserAndID(user.UserId, apiKeyID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve API key %s, got error: %s", apiKeyID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	if err != nil {
+		handlerLogger.Warnf("API key not found with id: %s for user %s", apiKeyID.Hex(), user.UserId.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+
+	if err := store.ApiKeys.Delete(&apiKey); err != nil {
+		handlerLogger.Errorf("Could not delete API key %s, got error: %s", apiKey.ApiKeyId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, "", models.VerbDeleted, models.EntityApiKey, apiKey.ApiKeyId, models.NewSnapshot(apiKey), nil, handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, apiKey)
+}
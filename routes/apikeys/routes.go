@@ -0,0 +1,24 @@
+package apikeys
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for API Key Resource
+func InitRoutes(apiKeyRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- API Key Index ---- //
+	apiKeyRouter.HandleFunc("", ApiKeyIndexHandler).Methods("GET")
+	apiKeyRouter.HandleFunc("/", ApiKeyIndexHandler).Methods("GET")
+	// ---- API Key Creation ---- //
+	apiKeyRouter.HandleFunc("", ApiKeyCreateHandler).Methods("POST")
+	apiKeyRouter.HandleFunc("/", ApiKeyCreateHandler).Methods("POST")
+	// ---- API Key Revocation ---- //
+	apiKeyRouter.HandleFunc("/{apiKeyId}", ApiKeyDeleteHandler).Methods("DELETE")
+	apiKeyRouter.HandleFunc("/{apiKeyId}/", ApiKeyDeleteHandler).Methods("DELETE")
+}
@@ -0,0 +1,25 @@
+package apikeys
+
+import (
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// ApiKeyApiResponse -> Response of API key index endpoint
+type ApiKeyApiResponse struct {
+	ApiKeys []models.ApiKey `json:"apiKeys"`
+}
+
+// ApiKeyCreate -> Request body of API key creation endpoint, scope defaults to write, keys never expire unless expiresAt is set
+type ApiKeyCreate struct {
+	Name      string     `json:"name"`
+	Scope     string     `json:"scope"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// CreatedApiKey -> Response of API key creation endpoint, the only one holding the key itself
+type CreatedApiKey struct {
+	models.ApiKey
+	Key string `json:"key"`
+}
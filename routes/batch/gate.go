@@ -0,0 +1,37 @@
+package batch
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// gate -> Held shared by every request while it runs, and exclusively by all-or-nothing batches: nothing else may use
+// the store during their transaction
+var gate sync.RWMutex
+
+// gateKey -> Context key marking requests which hold the gate, requests of a batch run under the hold of the batch
+type gateKey struct{}
+
+// Gate -> Middleware running requests alongside each other, but never alongside an all-or-nothing batch.
+// Must run before any middleware using the store
+func Gate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Value(gateKey{}) != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// An invalid value is rejected by BatchHandler, the batch then runs nothing
+		allOrNothing, _ := strconv.ParseBool(r.URL.Query().Get("allOrNothing"))
+		if isBatchPath(r.URL.Path) && allOrNothing {
+			gate.Lock()
+			defer gate.Unlock()
+		} else {
+			gate.RLock()
+			defer gate.RUnlock()
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), gateKey{}, true)))
+	})
+}
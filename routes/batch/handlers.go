@@ -0,0 +1,207 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// MaxBatchRequests -> Largest number of requests of a batch
+const MaxBatchRequests = 20
+
+// BatchPath -> Path of the batch endpoint, batches can not be nested
+const BatchPath = "/batch"
+
+var batchLogger *log.Entry
+
+func init() {
+	batchLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceBatchLogger)
+}
+
+// errBatchFailed -> A request of an all-or-nothing batch failed, its transaction is rolled back
+var errBatchFailed = errors.New("request of the batch failed")
+
+// isBatchPath -> Whether given path leads to the batch endpoint
+func isBatchPath(p string) bool {
+	cleaned := path.Clean(p)
+	return cleaned == BatchPath || strings.HasPrefix(cleaned, BatchPath+"/")
+}
+
+// newSubRequest -> HTTP request of a batch, sent with the headers (credentials), request ID and client address of the batch itself
+func newSubRequest(r *http.Request, sub SubRequest) (*http.Request, error) {
+	target, err := url.Parse(sub.Path)
+	if err != nil {
+		return nil, err
+	}
+	if isBatchPath(target.Path) {
+		return nil, fmt.Errorf("batches can not be nested")
+	}
+
+	var body io.Reader = http.NoBody
+	if len(sub.Body) > 0 {
+		body = bytes.NewReader(sub.Body)
+	}
+	req, err := http.NewRequest(sub.Method, sub.Path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.WithContext(r.Context())
+	for name, values := range r.Header {
+		req.Header[name] = append([]string{}, values...)
+	}
+	req.Header.Del("Content-Length")
+	if info, ok := audit.RequestInfoFromContext(r.Context()); ok {
+		req.Header.Set(audit.RequestIDHeader, info.RequestID)
+	}
+	req.RemoteAddr = r.RemoteAddr
+	return req, nil
+}
+
+// routable -> Whether a route of the application matches given request (path and method)
+func routable(req *http.Request) bool {
+	var match mux.RouteMatch
+	return router.Match(req, &match) && match.MatchErr == nil
+}
+
+// dispatch -> Run a request of a batch through the application router, a panic of its handler is answered with a 500
+// instead of interrupting the batch
+func dispatch(req *http.Request) (response SubResponse) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			batchLogger.Errorf("Request %s %s of a batch panicked: %v", req.Method, req.URL.Path, recovered)
+			body := helpers.JsonEncode(helpers.ErrorMessage{Status: http.StatusInternalServerError, Message: "Internal Server Error"})
+			response = SubResponse{Status: http.StatusInternalServerError, Body: json.RawMessage(body)}
+		}
+	}()
+
+	rec := newRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.response()
+}
+
+// notApplied -> Response to a request of an all-or-nothing batch which was not run, or rolled back, because the request
+// at given index failed
+func notApplied(failed int) SubResponse {
+	body := helpers.JsonEncode(helpers.ErrorMessage{Status: http.StatusFailedDependency, Message: fmt.Sprintf("Not applied, the request at index %d failed", failed)})
+	return SubResponse{Status: http.StatusFailedDependency, Body: json.RawMessage(body)}
+}
+
+// BatchHandler -> Handler running an array of requests (method, path, body) in order, through the application router,
+// as the user sending the batch. Responds with the status and body of each request. With query parameter allOrNothing=true,
+// the batch runs in a single transaction, alone (see Gate): it stops at the first failing request (status 400 or more)
+// and every change of the batch is rolled back. Storage backends without transactions (MongoDB) reject this mode
+func BatchHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerBatchLogger, r.URL.Path, r.Method)
+	var subs []SubRequest
+
+	allOrNothing := false
+	if value := r.URL.Query().Get("allOrNothing"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			handlerLogger.Warnf("Invalid allOrNothing query parameter: %s", value)
+			helpers.RespondWithError(w, http.StatusBadRequest, "allOrNothing must be true or false")
+			return
+		}
+		allOrNothing = parsed
+	}
+	if allOrNothing && store.Transactor == nil {
+		handlerLogger.Warn("All-or-nothing batch rejected, the storage does not support transactions")
+		helpers.RespondWithError(w, http.StatusBadRequest, "allOrNothing is not supported by this storage")
+		return
+	}
+
+	// Make sure that Request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received empty Request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty request body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&subs); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(subs) == 0 || len(subs) > MaxBatchRequests {
+		handlerLogger.Warnf("Batch of %d requests rejected", len(subs))
+		helpers.RespondWithError(w, http.StatusBadRequest, "A batch holds 1 to "+strconv.Itoa(MaxBatchRequests)+" requests")
+		return
+	}
+
+	// Check every request before running any of them
+	requests := make([]*http.Request, len(subs))
+	for i, sub := range subs {
+		err := helpers.Validate(sub, "onCreate")
+		if err == nil {
+			requests[i], err = newSubRequest(r, sub)
+		}
+		if err != nil {
+			handlerLogger.Warnf("Invalid request at index %d of batch, got error: %s", i, err.Error())
+			helpers.RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("Request at index %d: %s", i, err.Error()))
+			return
+		}
+	}
+
+	responses := make([]SubResponse, len(requests))
+	if !allOrNothing {
+		for i, req := range requests {
+			responses[i] = dispatch(req)
+		}
+		helpers.RespondWithJson(w, http.StatusOK, responses)
+		return
+	}
+
+	// Nothing runs unless every request matches a route, the failing one is answered by the router (404 or 405)
+	failed := -1
+	for i, req := range requests {
+		if !routable(req) {
+			failed = i
+			responses[i] = dispatch(req)
+			break
+		}
+	}
+
+	if failed < 0 {
+		err := store.Transactor.Atomically(func() error {
+			for i, req := range requests {
+				responses[i] = dispatch(req)
+				if responses[i].Status >= http.StatusBadRequest {
+					failed = i
+					return errBatchFailed
+				}
+			}
+			return nil
+		})
+		if err != nil && failed < 0 {
+			handlerLogger.Errorf("Could not commit batch, got error: %s", err.Error())
+			helpers.RespondWithError(w, http.StatusInternalServerError, "Could not apply batch")
+			return
+		}
+	}
+
+	if failed >= 0 {
+		for i := range responses {
+			if i != failed {
+				responses[i] = notApplied(failed)
+			}
+		}
+		handlerLogger.Warnf("Batch rolled back, request at index %d failed with status %d", failed, responses[failed].Status)
+	}
+	helpers.RespondWithJson(w, http.StatusOK, responses)
+}
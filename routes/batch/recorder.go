@@ -0,0 +1,48 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+)
+
+// recorder -> In-memory http.ResponseWriter, records the response to a request of a batch
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newRecorder() *recorder {
+	return &recorder{header: http.Header{}}
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	return rec.body.Write(b)
+}
+
+// response -> Recorded status and body, a body which is not JSON (e.g. "404 page not found") is returned as a JSON string
+func (rec *recorder) response() SubResponse {
+	rec.WriteHeader(http.StatusOK)
+	body := bytes.TrimSpace(rec.body.Bytes())
+	switch {
+	case len(body) == 0:
+		return SubResponse{Status: rec.status, Body: json.RawMessage("null")}
+	case json.Valid(body):
+		return SubResponse{Status: rec.status, Body: json.RawMessage(body)}
+	}
+
+	text, _ := json.Marshal(string(body))
+	return SubResponse{Status: rec.status, Body: text}
+}
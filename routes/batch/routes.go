@@ -0,0 +1,22 @@
+package batch
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Application router requests of a batch are dispatched through, injected by InitRoutes
+var router *mux.Router
+
+// Store all-or-nothing batches run in a transaction of, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for the Batch endpoint, requests of a batch are dispatched through given application router
+func InitRoutes(batchRouter *mux.Router, appRouter *mux.Router, s dao.Store) {
+	router = appRouter
+	store = s
+
+	// ---- Run many requests at once ---- //
+	batchRouter.HandleFunc("", BatchHandler).Methods("POST")
+	batchRouter.HandleFunc("/", BatchHandler).Methods("POST")
+}
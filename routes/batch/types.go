@@ -0,0 +1,18 @@
+package batch
+
+import (
+	"encoding/json"
+)
+
+// SubRequest -> Request of a batch, its path may hold a query string
+type SubRequest struct {
+	Method string          `json:"method" onCreate:"nonzero,regexp=^(GET|POST|PUT|PATCH|DELETE)$"`
+	Path   string          `json:"path" onCreate:"nonzero,max=2000,regexp=^/"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// SubResponse -> Response to a request of a batch: HTTP status and body (JSON, or a string when the body is not JSON)
+type SubResponse struct {
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
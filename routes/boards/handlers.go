@@ -0,0 +1,182 @@
+package boards
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var boardLogger *log.Entry
+
+func init() {
+	boardLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceBoardsLogger)
+}
+
+// BoardIndexHandler -> Handler for Board Listing Endpoint, lists boards the user is a member of
+func BoardIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+
+	boardIDs, err := store.Members.FindBoardIDsByUserID(user.UserId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve memberships of user %s, got error: %s", user.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	boards, err := store.Boards.FindByIDs(boardIDs)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve boards from database, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, BoardApiResponse{Boards: boards})
+}
+
+// BoardCreateHandler -> Handler for Board Creation Endpoint, the user creating the board becomes its owner
+func BoardCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+	var board models.Board
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return
+	}
+
+	var body models.Board
+	decoder := json.NewDecoder(r.Body)
+	if err := decoder.Decode(&body); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Only keep user editable fields, identifier and timestamps are managed by the API
+	board.Name, board.Description = body.Name, body.Description
+
+	if errs := helpers.Validate(board, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on board creation, got error: %s", errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return
+	}
+
+	board.BoardId = bson.NewObjectId()
+	if err := auth.StoreFor(r, store).Boards.Insert(&board); err != nil {
+		handlerLogger.Error("Could not insert to database")
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	owner := models.NewBoardMember(board.BoardId, user.UserId, models.RoleOwner)
+	if err := store.Members.Insert(&owner); err != nil {
+		handlerLogger.Errorf("Could not make user %s owner of board %s, got error: %s", user.UserId.Hex(), board.BoardId.Hex(), err.Error())
+		// Do not leave a board nobody can access
+		store.Boards.Delete(&board)
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, board.BoardId, models.VerbCreated, models.EntityBoard, board.BoardId, nil, models.NewSnapshot(board), handlerLogger)
+	audit.Record(r, store, board.BoardId, models.VerbCreated, models.EntityMember, owner.UserId, nil, models.NewSnapshot(owner), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusCreated, board)
+}
+
+// BoardViewHandler -> Handler to View Board Endpoint
+func BoardViewHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerViewLogger, r.URL.Path, r.Method)
+	board, ok := auth.FindBoard(w, r, store.Boards, handlerLogger)
+	if !ok {
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, board)
+}
+
+// BoardUpdateHandler -> Handler to Update a Board Endpoint, restricted to board owners
+func BoardUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	var bodyBoard models.Board
+	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleOwner, handlerLogger) {
+		return
+	}
+
+	board, ok := auth.FindBoard(w, r, store.Boards, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received Empty request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty body")
+		return
+	}
+
+	// Parse request body
+	var body map[string]interface{}
+	if err := helpers.DecodeBody(r.Body, &body); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate Request body types against Board data structure
+	if err := json.Unmarshal(helpers.JsonEncode(body), &bodyBoard); err != nil {
+		handlerLogger.Warnf("Invalid types in request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before := models.NewSnapshot(board)
+	board.HydrateFromMap(body)
+
+	if err := helpers.Validate(board, "onCreate"); err != nil {
+		handlerLogger.Warnf("Could not validate Board model, received error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, "Could not validate given data, errors: "+err.Error())
+		return
+	}
+
+	if err := auth.StoreFor(r, store).Boards.Update(&board); err != nil {
+		handlerLogger.Errorf("Could not update board with id: %s, got error: %s", board.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, board.BoardId, models.VerbUpdated, models.EntityBoard, board.BoardId, before, models.NewSnapshot(board), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, board)
+}
+
+// BoardDeleteHandler -> Handler for Board Deletion Endpoint, restricted to board owners, also removes Board's lists and their tasks
+func BoardDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleOwner, handlerLogger) {
+		return
+	}
+
+	board, ok := auth.FindBoard(w, r, store.Boards, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Board's lists and their tasks are removed along with it by the store
+	if err := store.Boards.Delete(&board); err != nil {
+		handlerLogger.Errorf("Could not delete board with id: %s, got error: %s", board.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, board.BoardId, models.VerbDeleted, models.EntityBoard, board.BoardId, models.NewSnapshot(board), nil, handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, board)
+}
@@ -0,0 +1,30 @@
+package boards
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for Board Resource
+func InitRoutes(boardRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Board Listing ---- //
+	boardRouter.HandleFunc("", BoardIndexHandler).Methods("GET")
+	boardRouter.HandleFunc("/", BoardIndexHandler).Methods("GET")
+	// ---- Board Creation ---- //
+	boardRouter.HandleFunc("", BoardCreateHandler).Methods("POST")
+	boardRouter.HandleFunc("/", BoardCreateHandler).Methods("POST")
+	// ---- Board View ---- //
+	boardRouter.HandleFunc("/{boardId}", BoardViewHandler).Methods("GET")
+	boardRouter.HandleFunc("/{boardId}/", BoardViewHandler).Methods("GET")
+	// ---- Board Update ---- //
+	boardRouter.HandleFunc("/{boardId}", BoardUpdateHandler).Methods("PATCH")
+	boardRouter.HandleFunc("/{boardId}/", BoardUpdateHandler).Methods("PATCH")
+	// ---- Board Deletion ---- //
+	boardRouter.HandleFunc("/{boardId}", BoardDeleteHandler).Methods("DELETE")
+	boardRouter.HandleFunc("/{boardId}/", BoardDeleteHandler).Methods("DELETE")
+}
@@ -0,0 +1,9 @@
+package boards
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+type BoardApiResponse struct {
+	Boards []models.Board `json:"boards"`
+}
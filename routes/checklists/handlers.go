@@ -0,0 +1,364 @@
+package checklists
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/tasks"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var checklistLogger *log.Entry
+
+func init() {
+	checklistLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceChecklistsLogger)
+}
+
+// findChecklist -> Retrieve the Task and the index of the Checklist targeted by route parameters, respond with an error if it does not exist
+func findChecklist(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.Task, int, bool) {
+	task, ok := tasks.FindTask(w, r, handlerLogger)
+	if !ok {
+		return task, -1, false
+	}
+
+	checklistID, ok := helpers.GetObjectIdVar(w, r, "checklistId", handlerLogger)
+	if !ok {
+		return task, -1, false
+	}
+
+	index := task.FindChecklist(checklistID)
+	if index < 0 {
+		handlerLogger.Warnf("Checklist not found with id: %s in task %s", checklistID.Hex(), task.TaskId.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Checklist not found")
+		return task, -1, false
+	}
+	return task, index, true
+}
+
+// findItem -> Retrieve the Task, and the indexes of the Checklist and Item targeted by route parameters, respond with an error if they do not exist
+func findItem(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.Task, int, int, bool) {
+	task, checklistIndex, ok := findChecklist(w, r, handlerLogger)
+	if !ok {
+		return task, -1, -1, false
+	}
+
+	itemID, ok := helpers.GetObjectIdVar(w, r, "itemId", handlerLogger)
+	if !ok {
+		return task, -1, -1, false
+	}
+
+	itemIndex := task.Checklists[checklistIndex].FindItem(itemID)
+	if itemIndex < 0 {
+		handlerLogger.Warnf("Checklist item not found with id: %s", itemID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Checklist item not found")
+		return task, -1, -1, false
+	}
+	return task, checklistIndex, itemIndex, true
+}
+
+// decodeBody -> Parse request body into v, respond with an error if it is empty or malformed
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}, handlerLogger *log.Entry) bool {
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return false
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// validate -> Validate v against its onCreate rules, respond with an error if it is not valid
+func validate(w http.ResponseWriter, v interface{}, handlerLogger *log.Entry) bool {
+	if errs := helpers.Validate(v, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed for User Input, got error: %s", errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return false
+	}
+	return true
+}
+
+// Returned by changes of checklists when the checklist or item they modify was deleted concurrently
+var (
+	errChecklistNotFound = errors.New("Checklist not found")
+	errItemNotFound      = errors.New("Checklist item not found")
+)
+
+// checklistOf -> Checklist with given ID of task, to modify in a change
+func checklistOf(task *models.Task, checklistID bson.ObjectId) (*models.Checklist, error) {
+	index := task.FindChecklist(checklistID)
+	if index < 0 {
+		return nil, errChecklistNotFound
+	}
+	return &task.Checklists[index], nil
+}
+
+// itemOf -> Checklist with given ID of task, and index of its item with given ID, to modify in a change
+func itemOf(task *models.Task, checklistID, itemID bson.ObjectId) (*models.Checklist, int, error) {
+	checklist, err := checklistOf(task, checklistID)
+	if err != nil {
+		return nil, -1, err
+	}
+	index := checklist.FindItem(itemID)
+	if index < 0 {
+		return nil, -1, errItemNotFound
+	}
+	return checklist, index, nil
+}
+
+// updateChecklists -> Apply change to the checklists of the stored task (concurrent edits of other checklists and items are kept),
+// audit the change from the task it was applied to, and respond with the updated task
+func updateChecklists(w http.ResponseWriter, r *http.Request, task models.Task, code int, change func(task *models.Task) error, handlerLogger *log.Entry) {
+	var before models.Snapshot
+	commentCount := task.CommentCount
+	err := auth.StoreFor(r, store).Tasks.UpdateChecklists(&task, func(stored *models.Task) error {
+		// Comments are not counted by the store, the count must not show up as changed
+		stored.CommentCount = commentCount
+		before = models.NewSnapshot(*stored)
+		return change(stored)
+	})
+	switch {
+	case err == errChecklistNotFound || err == errItemNotFound:
+		handlerLogger.Warnf("Could not update checklists of task %s, got error: %s", task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	case err == dao.ErrNotFound:
+		handlerLogger.Warnf("Task %s deleted before its checklists were updated", task.TaskId.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Task does not exist")
+		return
+	case err == dao.ErrConflict:
+		handlerLogger.Warnf("Checklists of task %s kept being modified concurrently", task.TaskId.Hex())
+		helpers.RespondWithError(w, http.StatusConflict, "Task is being modified concurrently, try again")
+		return
+	case err != nil:
+		handlerLogger.Errorf("Could not update checklists of task %s, got error: %s", task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	task.CommentCount = commentCount
+	audit.Record(r, store, task.BoardId, models.VerbUpdated, models.EntityTask, task.TaskId, before, models.NewSnapshot(task), handlerLogger)
+
+	helpers.RespondWithJson(w, code, task)
+}
+
+// ChecklistCreateHandler -> Handler for Checklist Creation Endpoint, the checklist is appended to the task's checklists
+func ChecklistCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	var body ChecklistBody
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, ok := tasks.FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	if !decodeBody(w, r, &body, handlerLogger) {
+		return
+	}
+
+	checklist := models.NewChecklist()
+	checklist.Name = body.Name
+	if !validate(w, checklist, handlerLogger) {
+		return
+	}
+
+	updateChecklists(w, r, task, http.StatusCreated, func(task *models.Task) error {
+		task.Checklists = append(task.Checklists, checklist)
+		return nil
+	}, handlerLogger)
+}
+
+// ChecklistUpdateHandler -> Handler to Rename a Checklist Endpoint
+func ChecklistUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
+	var body ChecklistBody
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, index, ok := findChecklist(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	checklist := task.Checklists[index]
+
+	if !decodeBody(w, r, &body, handlerLogger) {
+		return
+	}
+
+	checklist.Name = body.Name
+	if !validate(w, checklist, handlerLogger) {
+		return
+	}
+
+	updateChecklists(w, r, task, http.StatusOK, func(task *models.Task) error {
+		stored, err := checklistOf(task, checklist.ChecklistId)
+		if err == nil {
+			stored.Name = checklist.Name
+		}
+		return err
+	}, handlerLogger)
+}
+
+// ChecklistDeleteHandler -> Handler for Checklist Deletion Endpoint, along with its items
+func ChecklistDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, index, ok := findChecklist(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	checklistID := task.Checklists[index].ChecklistId
+
+	updateChecklists(w, r, task, http.StatusOK, func(task *models.Task) error {
+		index := task.FindChecklist(checklistID)
+		if index < 0 {
+			return errChecklistNotFound
+		}
+		task.Checklists = append(task.Checklists[:index:index], task.Checklists[index+1:]...)
+		return nil
+	}, handlerLogger)
+}
+
+// ItemCreateHandler -> Handler for Checklist Item Creation Endpoint, the item is appended unchecked to the checklist
+func ItemCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	var body ItemCreate
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, index, ok := findChecklist(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	checklistID := task.Checklists[index].ChecklistId
+
+	if !decodeBody(w, r, &body, handlerLogger) {
+		return
+	}
+
+	item := models.NewChecklistItem()
+	item.Text = body.Text
+	if !validate(w, item, handlerLogger) {
+		return
+	}
+
+	updateChecklists(w, r, task, http.StatusCreated, func(task *models.Task) error {
+		checklist, err := checklistOf(task, checklistID)
+		if err == nil {
+			checklist.Items = append(checklist.Items, item)
+		}
+		return err
+	}, handlerLogger)
+}
+
+// ItemUpdateHandler -> Handler to Update a Checklist Item Endpoint: edit its text, check or uncheck it
+func ItemUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
+	var body ItemUpdate
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, checklistIndex, itemIndex, ok := findItem(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	checklistID := task.Checklists[checklistIndex].ChecklistId
+	item := task.Checklists[checklistIndex].Items[itemIndex]
+
+	if !decodeBody(w, r, &body, handlerLogger) {
+		return
+	}
+
+	if body.Text != nil {
+		item.Text = *body.Text
+	}
+	if !validate(w, item, handlerLogger) {
+		return
+	}
+
+	// Only given fields are applied, so that concurrent edits of the other one are kept
+	updateChecklists(w, r, task, http.StatusOK, func(task *models.Task) error {
+		checklist, index, err := itemOf(task, checklistID, item.ItemId)
+		if err != nil {
+			return err
+		}
+		if body.Text != nil {
+			checklist.Items[index].Text = *body.Text
+		}
+		if body.Checked != nil {
+			checklist.Items[index].Checked = *body.Checked
+		}
+		return nil
+	}, handlerLogger)
+}
+
+// ItemDeleteHandler -> Handler for Checklist Item Deletion Endpoint
+func ItemDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, checklistIndex, itemIndex, ok := findItem(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	checklistID, itemID := task.Checklists[checklistIndex].ChecklistId, task.Checklists[checklistIndex].Items[itemIndex].ItemId
+
+	updateChecklists(w, r, task, http.StatusOK, func(task *models.Task) error {
+		checklist, index, err := itemOf(task, checklistID, itemID)
+		if err == nil {
+			checklist.RemoveItem(index)
+		}
+		return err
+	}, handlerLogger)
+}
+
+// ItemMoveHandler -> Handler for Checklist Item Move Endpoint: move an item to another position of its checklist
+func ItemMoveHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerMoveLogger, r.URL.Path, r.Method)
+	var move ItemMove
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, checklistIndex, itemIndex, ok := findItem(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	checklistID, itemID := task.Checklists[checklistIndex].ChecklistId, task.Checklists[checklistIndex].Items[itemIndex].ItemId
+
+	if !decodeBody(w, r, &move, handlerLogger) || !validate(w, move, handlerLogger) {
+		return
+	}
+
+	updateChecklists(w, r, task, http.StatusOK, func(task *models.Task) error {
+		checklist, index, err := itemOf(task, checklistID, itemID)
+		if err == nil {
+			checklist.MoveItem(index, move.Order)
+		}
+		return err
+	}, handlerLogger)
+}
@@ -0,0 +1,36 @@
+package checklists
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for Checklist Resource, every endpoint responds with the updated task
+func InitRoutes(checklistRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Checklist Creation ---- //
+	checklistRouter.HandleFunc("", ChecklistCreateHandler).Methods("POST")
+	checklistRouter.HandleFunc("/", ChecklistCreateHandler).Methods("POST")
+	// ---- Checklist Rename ---- //
+	checklistRouter.HandleFunc("/{checklistId}", ChecklistUpdateHandler).Methods("PATCH")
+	checklistRouter.HandleFunc("/{checklistId}/", ChecklistUpdateHandler).Methods("PATCH")
+	// ---- Checklist Deletion ---- //
+	checklistRouter.HandleFunc("/{checklistId}", ChecklistDeleteHandler).Methods("DELETE")
+	checklistRouter.HandleFunc("/{checklistId}/", ChecklistDeleteHandler).Methods("DELETE")
+	// ---- Checklist Item Creation ---- //
+	checklistRouter.HandleFunc("/{checklistId}/items", ItemCreateHandler).Methods("POST")
+	checklistRouter.HandleFunc("/{checklistId}/items/", ItemCreateHandler).Methods("POST")
+	// ---- Checklist Item Update (text, checked) ---- //
+	checklistRouter.HandleFunc("/{checklistId}/items/{itemId}", ItemUpdateHandler).Methods("PATCH")
+	checklistRouter.HandleFunc("/{checklistId}/items/{itemId}/", ItemUpdateHandler).Methods("PATCH")
+	// ---- Checklist Item Deletion ---- //
+	checklistRouter.HandleFunc("/{checklistId}/items/{itemId}", ItemDeleteHandler).Methods("DELETE")
+	checklistRouter.HandleFunc("/{checklistId}/items/{itemId}/", ItemDeleteHandler).Methods("DELETE")
+	// ---- Checklist Item Move (position in its checklist) ---- //
+	checklistRouter.HandleFunc("/{checklistId}/items/{itemId}/move", ItemMoveHandler).Methods("POST")
+	checklistRouter.HandleFunc("/{checklistId}/items/{itemId}/move/", ItemMoveHandler).Methods("POST")
+}
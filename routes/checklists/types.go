@@ -0,0 +1,22 @@
+package checklists
+
+// ChecklistBody -> Request body of checklist creation and rename endpoints
+type ChecklistBody struct {
+	Name string `json:"name"`
+}
+
+// ItemCreate -> Request body of checklist item creation endpoint
+type ItemCreate struct {
+	Text string `json:"text"`
+}
+
+// ItemUpdate -> Request body of checklist item update endpoint, absent fields are left unchanged
+type ItemUpdate struct {
+	Text    *string `json:"text"`
+	Checked *bool   `json:"checked"`
+}
+
+// ItemMove -> Request body of checklist item move endpoint, position starting at 1 (defaults to last)
+type ItemMove struct {
+	Order int `json:"order" onCreate:"min=0"`
+}
@@ -0,0 +1,195 @@
+package comments
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/tasks"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var commentLogger *log.Entry
+
+func init() {
+	commentLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceCommentsLogger)
+}
+
+// findComment -> Retrieve the Comment targeted by route parameters, respond with an error if it does not exist on this task
+func findComment(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.Comment, bool) {
+	task, ok := tasks.FindTask(w, r, handlerLogger)
+	if !ok {
+		return models.Comment{}, false
+	}
+
+	commentID, ok := helpers.GetObjectIdVar(w, r, "commentId", handlerLogger)
+	if !ok {
+		return models.Comment{}, false
+	}
+
+	comment, err := store.Comments.FindByTaskAndID(task.TaskId, commentID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve comment %s, got error: %s", commentID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return comment, false
+	}
+	if err != nil {
+		handlerLogger.Warnf("Comment not found with id: %s on task %s", commentID.Hex(), task.TaskId.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Comment not found")
+		return comment, false
+	}
+	return comment, true
+}
+
+// decodeCommentBody -> Parse and validate request body of comment creation and edition, respond with an error if it is not valid
+func decodeCommentBody(w http.ResponseWriter, r *http.Request, comment *models.Comment, handlerLogger *log.Entry) bool {
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return false
+	}
+
+	var body CommentBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+
+	comment.Body = body.Body
+	if errs := helpers.Validate(*comment, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on comment, got error: %s", errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return false
+	}
+	return true
+}
+
+// CommentIndexHandler -> Handler for Comment Listing Endpoint, oldest comments first, paginated with query parameters limit and offset
+func CommentIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+
+	page, ok := helpers.GetPage(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	task, ok := tasks.FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	comments, err := store.Comments.FindByTaskID(task.TaskId, page)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve comments of task %s, got error: %s", task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	total, err := store.Comments.CountByTaskID(task.TaskId)
+	if err != nil {
+		handlerLogger.Errorf("Could not count comments of task %s, got error: %s", task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, CommentApiResponse{Comments: comments, Total: total, Limit: page.Limit, Offset: page.Offset})
+}
+
+// CommentCreateHandler -> Handler for Comment Creation Endpoint, the current user is the comment's author
+func CommentCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, ok := tasks.FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	comment := models.NewComment()
+	if !decodeCommentBody(w, r, &comment, handlerLogger) {
+		return
+	}
+
+	comment.CommentId = bson.NewObjectId()
+	comment.TaskId = task.TaskId
+	comment.BoardId = task.BoardId
+	comment.AuthorId = user.UserId
+
+	if err := store.Comments.Insert(&comment); err != nil {
+		handlerLogger.Errorf("Could not insert comment on task %s, got error: %s", task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, comment.BoardId, models.VerbCreated, models.EntityComment, comment.CommentId, nil, models.NewSnapshot(comment), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusCreated, comment)
+}
+
+// CommentUpdateHandler -> Handler for Comment Edition Endpoint, restricted to the comment's author
+func CommentUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+
+	comment, ok := findComment(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	if comment.AuthorId != user.UserId {
+		handlerLogger.Warnf("User %s tried to edit comment %s of another user", user.UserId.Hex(), comment.CommentId.Hex())
+		helpers.RespondWithError(w, http.StatusForbidden, "Only the author of a comment can edit it")
+		return
+	}
+
+	before := models.NewSnapshot(comment)
+	if !decodeCommentBody(w, r, &comment, handlerLogger) {
+		return
+	}
+
+	comment.Touch()
+	if err := store.Comments.Update(&comment); err != nil {
+		handlerLogger.Errorf("Could not update comment %s, got error: %s", comment.CommentId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, comment.BoardId, models.VerbUpdated, models.EntityComment, comment.CommentId, before, models.NewSnapshot(comment), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, comment)
+}
+
+// CommentDeleteHandler -> Handler for Comment Deletion Endpoint, restricted to the comment's author and board owners
+func CommentDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+
+	comment, ok := findComment(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Owners moderate their board, other members may only delete their own comments
+	if comment.AuthorId != user.UserId && !auth.Authorize(w, r, models.RoleOwner, handlerLogger) {
+		return
+	}
+
+	if err := store.Comments.Delete(&comment); err != nil {
+		handlerLogger.Errorf("Could not delete comment %s, got error: %s", comment.CommentId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, comment.BoardId, models.VerbDeleted, models.EntityComment, comment.CommentId, models.NewSnapshot(comment), nil, handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, comment)
+}
@@ -0,0 +1,27 @@
+package comments
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for Comment Resource
+func InitRoutes(commentRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Comment Listing ---- //
+	commentRouter.HandleFunc("", CommentIndexHandler).Methods("GET")
+	commentRouter.HandleFunc("/", CommentIndexHandler).Methods("GET")
+	// ---- Comment Creation ---- //
+	commentRouter.HandleFunc("", CommentCreateHandler).Methods("POST")
+	commentRouter.HandleFunc("/", CommentCreateHandler).Methods("POST")
+	// ---- Comment Edition (author only) ---- //
+	commentRouter.HandleFunc("/{commentId}", CommentUpdateHandler).Methods("PATCH")
+	commentRouter.HandleFunc("/{commentId}/", CommentUpdateHandler).Methods("PATCH")
+	// ---- Comment Deletion ---- //
+	commentRouter.HandleFunc("/{commentId}", CommentDeleteHandler).Methods("DELETE")
+	commentRouter.HandleFunc("/{commentId}/", CommentDeleteHandler).Methods("DELETE")
+}
@@ -0,0 +1,18 @@
+package comments
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// CommentApiResponse -> Response of comment listing endpoint, a page of comments along with the total number of comments
+type CommentApiResponse struct {
+	Comments []models.Comment `json:"comments"`
+	Total    int              `json:"total"`
+	Limit    int              `json:"limit"`
+	Offset   int              `json:"offset"`
+}
+
+// CommentBody -> Request body of comment creation and edition endpoints
+type CommentBody struct {
+	Body string `json:"body"`
+}
@@ -0,0 +1,216 @@
+package filters
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/tasks"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var filterLogger *log.Entry
+
+func init() {
+	filterLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceFiltersLogger)
+}
+
+// findFilter -> Retrieve Filter from route parameters boardId/filterId, respond with an error if the filter does not exist in this board
+func findFilter(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.Filter, bool) {
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return models.Filter{}, false
+	}
+
+	filterID, ok := helpers.GetObjectIdVar(w, r, "filterId", handlerLogger)
+	if !ok {
+		return models.Filter{}, false
+	}
+
+	filter, err := store.Filters.FindByBoardAndID(boardID, filterID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve filter %s, got error: %s", filterID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return filter, false
+	}
+	if err != nil {
+		handlerLogger.Warnf("Filter not found with id: %s in board %s", filterID.Hex(), boardID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Filter not found")
+		return filter, false
+	}
+	return filter, true
+}
+
+// validateFilter -> Validate a Filter and compile its expression, respond with Bad Request if one of them is not valid
+func validateFilter(w http.ResponseWriter, filter models.Filter, handlerLogger *log.Entry) bool {
+	if errs := helpers.Validate(filter, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on filter %s, got error: %s", filter.Name, errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return false
+	}
+
+	_, ok := tasks.CompileFilter(w, store, filter.BoardId, filter.Query, handlerLogger)
+	return ok
+}
+
+// FilterIndexHandler -> Handler for Filter Listing Endpoint, saved filters of the board sorted by name
+func FilterIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	filters, err := store.Filters.FindByBoardID(boardID)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve filters of board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, FilterApiResponse{Filters: filters})
+}
+
+// FilterCreateHandler -> Handler for Filter Creation Endpoint, the expression must be valid on the board
+func FilterCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	var filter models.Filter
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filter.FilterId = bson.NewObjectId()
+	filter.BoardId = boardID
+	if !validateFilter(w, filter, handlerLogger) {
+		return
+	}
+
+	if err := auth.StoreFor(r, store).Filters.Insert(&filter); err != nil {
+		handlerLogger.Errorf("Could not insert filter in board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, filter.BoardId, models.VerbCreated, models.EntityFilter, filter.FilterId, nil, models.NewSnapshot(filter), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusCreated, filter)
+}
+
+// FilterViewHandler -> Handler to View Filter Endpoint
+func FilterViewHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerViewLogger, r.URL.Path, r.Method)
+	filter, ok := findFilter(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, filter)
+}
+
+// FilterUpdateHandler -> Handler to Update a Filter Endpoint, name and/or query
+func FilterUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
+	var bodyFilter models.Filter
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	filter, ok := findFilter(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received Empty request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty body")
+		return
+	}
+
+	// Parse request body
+	var body map[string]interface{}
+	if err := helpers.DecodeBody(r.Body, &body); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate Request body types against Filter data structure
+	if err := json.Unmarshal(helpers.JsonEncode(body), &bodyFilter); err != nil {
+		handlerLogger.Warnf("Invalid types in request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before := models.NewSnapshot(filter)
+	filter.HydrateFromMap(body)
+	if !validateFilter(w, filter, handlerLogger) {
+		return
+	}
+
+	if err := auth.StoreFor(r, store).Filters.Update(&filter); err != nil {
+		handlerLogger.Errorf("Could not update filter with id: %s, got error: %s", filter.FilterId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, filter.BoardId, models.VerbUpdated, models.EntityFilter, filter.FilterId, before, models.NewSnapshot(filter), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, filter)
+}
+
+// FilterDeleteHandler -> Handler for Filter Deletion Endpoint
+func FilterDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	filter, ok := findFilter(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	if err := store.Filters.Delete(&filter); err != nil {
+		handlerLogger.Errorf("Could not delete filter with id: %s, got error: %s", filter.FilterId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, filter.BoardId, models.VerbDeleted, models.EntityFilter, filter.FilterId, models.NewSnapshot(filter), nil, handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, filter)
+}
+
+// FilterTasksHandler -> Handler running a saved Filter, lists the tasks of the board it selects, a page at a time (limit, offset)
+func FilterTasksHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	filter, ok := findFilter(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	tasks.RunFilter(w, r, filter.BoardId, filter.Query, handlerLogger)
+}
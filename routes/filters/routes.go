@@ -0,0 +1,33 @@
+package filters
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for saved task Filter Resource
+func InitRoutes(filterRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Filter Index (sorted by name) ---- //
+	filterRouter.HandleFunc("", FilterIndexHandler).Methods("GET")
+	filterRouter.HandleFunc("/", FilterIndexHandler).Methods("GET")
+	// ---- Filter Creation ---- //
+	filterRouter.HandleFunc("", FilterCreateHandler).Methods("POST")
+	filterRouter.HandleFunc("/", FilterCreateHandler).Methods("POST")
+	// ---- Filter View ---- //
+	filterRouter.HandleFunc("/{filterId}", FilterViewHandler).Methods("GET")
+	filterRouter.HandleFunc("/{filterId}/", FilterViewHandler).Methods("GET")
+	// ---- Filter Update ---- //
+	filterRouter.HandleFunc("/{filterId}", FilterUpdateHandler).Methods("PATCH")
+	filterRouter.HandleFunc("/{filterId}/", FilterUpdateHandler).Methods("PATCH")
+	// ---- Filter Deletion ---- //
+	filterRouter.HandleFunc("/{filterId}", FilterDeleteHandler).Methods("DELETE")
+	filterRouter.HandleFunc("/{filterId}/", FilterDeleteHandler).Methods("DELETE")
+	// ---- Tasks selected by the Filter ---- //
+	filterRouter.HandleFunc("/{filterId}/tasks", FilterTasksHandler).Methods("GET")
+	filterRouter.HandleFunc("/{filterId}/tasks/", FilterTasksHandler).Methods("GET")
+}
@@ -0,0 +1,10 @@
+package filters
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// FilterApiResponse -> Response of filter index endpoint
+type FilterApiResponse struct {
+	Filters []models.Filter `json:"filters"`
+}
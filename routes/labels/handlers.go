@@ -0,0 +1,197 @@
+package labels
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var labelLogger *log.Entry
+
+func init() {
+	labelLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceLabelsLogger)
+}
+
+// findLabel -> Retrieve Label from route parameters boardId/labelId, respond with an error if the label does not exist in this board
+func findLabel(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.Label, bool) {
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return models.Label{}, false
+	}
+
+	labelID, ok := helpers.GetObjectIdVar(w, r, "labelId", handlerLogger)
+	if !ok {
+		return models.Label{}, false
+	}
+
+	label, err := store.Labels.FindByBoardAndID(boardID, labelID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve label %s, got error: %s", labelID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return label, false
+	}
+	if err != nil {
+		handlerLogger.Warnf("Label not found with id: %s in board %s", labelID.Hex(), boardID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Label not found")
+		return label, false
+	}
+	return label, true
+}
+
+// LabelIndexHandler -> Handler for Label Listing Endpoint, labels of the board sorted by name
+func LabelIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	labels, err := store.Labels.FindByBoardID(boardID)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve labels of board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, LabelApiResponse{Labels: labels})
+}
+
+// LabelCreateHandler -> Handler for Label Creation Endpoint
+func LabelCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	var label models.Label
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&label); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if errs := helpers.Validate(label, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on label %s, got error: %s", label.Name, errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return
+	}
+
+	label.LabelId = bson.NewObjectId()
+	label.BoardId = boardID
+	if err := auth.StoreFor(r, store).Labels.Insert(&label); err != nil {
+		handlerLogger.Errorf("Could not insert label in board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, label.BoardId, models.VerbCreated, models.EntityLabel, label.LabelId, nil, models.NewSnapshot(label), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusCreated, label)
+}
+
+// LabelViewHandler -> Handler to View Label Endpoint
+func LabelViewHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerViewLogger, r.URL.Path, r.Method)
+	label, ok := findLabel(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, label)
+}
+
+// LabelUpdateHandler -> Handler to Update a Label Endpoint, name and/or color
+func LabelUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
+	var bodyLabel models.Label
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	label, ok := findLabel(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received Empty request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty body")
+		return
+	}
+
+	// Parse request body
+	var body map[string]interface{}
+	if err := helpers.DecodeBody(r.Body, &body); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Validate Request body types against Label data structure
+	if err := json.Unmarshal(helpers.JsonEncode(body), &bodyLabel); err != nil {
+		handlerLogger.Warnf("Invalid types in request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	before := models.NewSnapshot(label)
+	label.HydrateFromMap(body)
+
+	if err := helpers.Validate(label, "onCreate"); err != nil {
+		handlerLogger.Warnf("Could not validate Label model, received error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := auth.StoreFor(r, store).Labels.Update(&label); err != nil {
+		handlerLogger.Errorf("Could not update label with id: %s, got error: %s", label.LabelId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, label.BoardId, models.VerbUpdated, models.EntityLabel, label.LabelId, before, models.NewSnapshot(label), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, label)
+}
+
+// LabelDeleteHandler -> Handler for Label Deletion Endpoint, the label is detached from every task of the board
+func LabelDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	label, ok := findLabel(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	if err := store.Labels.Delete(&label); err != nil {
+		handlerLogger.Errorf("Could not delete label with id: %s, got error: %s", label.LabelId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, label.BoardId, models.VerbDeleted, models.EntityLabel, label.LabelId, models.NewSnapshot(label), nil, handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, label)
+}
@@ -0,0 +1,30 @@
+package labels
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for Label Resource
+func InitRoutes(labelRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Label Index (sorted by name) ---- //
+	labelRouter.HandleFunc("", LabelIndexHandler).Methods("GET")
+	labelRouter.HandleFunc("/", LabelIndexHandler).Methods("GET")
+	// ---- Label Creation ---- //
+	labelRouter.HandleFunc("", LabelCreateHandler).Methods("POST")
+	labelRouter.HandleFunc("/", LabelCreateHandler).Methods("POST")
+	// ---- Label View ---- //
+	labelRouter.HandleFunc("/{labelId}", LabelViewHandler).Methods("GET")
+	labelRouter.HandleFunc("/{labelId}/", LabelViewHandler).Methods("GET")
+	// ---- Label Update ---- //
+	labelRouter.HandleFunc("/{labelId}", LabelUpdateHandler).Methods("PATCH")
+	labelRouter.HandleFunc("/{labelId}/", LabelUpdateHandler).Methods("PATCH")
+	// ---- Label Deletion (detached from tasks) ---- //
+	labelRouter.HandleFunc("/{labelId}", LabelDeleteHandler).Methods("DELETE")
+	labelRouter.HandleFunc("/{labelId}/", LabelDeleteHandler).Methods("DELETE")
+}
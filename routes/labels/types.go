@@ -0,0 +1,10 @@
+package labels
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// LabelApiResponse -> Response of label index endpoint
+type LabelApiResponse struct {
+	Labels []models.Label `json:"labels"`
+}
@@ -3,21 +3,21 @@ package lists
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 
-	"github.com/gorilla/mux"
-
-	"github.com/AmFlint/taco-api-go/config/database"
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
 	"github.com/AmFlint/taco-api-go/constants"
 	"github.com/AmFlint/taco-api-go/dao"
 	"github.com/AmFlint/taco-api-go/helpers"
 	"github.com/AmFlint/taco-api-go/helpers/logger"
 	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/activity"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/mgo.v2/bson"
 	validator2 "gopkg.in/validator.v2"
 )
 
-//TODO: Create Middleware for initiating TaskDAO
 //TODO: Create Middleware for vars["taskId"]
 //TODO: Create Error message constant for Not Found
 
@@ -27,10 +27,46 @@ func init() {
 	listLogger = log.WithField(constants.HandlerKeyLogger, constants.ResourceListsLogger)
 }
 
+// findList -> Retrieve List from route parameters boardId/listId, respond with an error if the list does not exist in this board
+func findList(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.List, bool) {
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return models.List{}, false
+	}
+
+	listID, ok := helpers.GetObjectIdVar(w, r, "listId", handlerLogger)
+	if !ok {
+		return models.List{}, false
+	}
+
+	list, err := store.Lists.FindByBoardAndID(boardID, listID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve list %s, got error: %s", listID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return list, false
+	}
+	if err != nil {
+		handlerLogger.Warnf("List not found with id: %s in board %s", listID.Hex(), boardID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "List not found")
+		return list, false
+	}
+	return list, true
+}
+
 // ListCreateHandler -> Handler for List Creation Endpoint ---- //
 func ListCreateHandler(w http.ResponseWriter, r *http.Request) {
 	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
 	list := models.NewList()
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	// Lists can only be created in an existing Board
+	board, ok := auth.FindBoard(w, r, store.Boards, handlerLogger)
+	if !ok {
+		return
+	}
+	boardID := board.BoardId
 
 	// Make sure that request body is not empty
 	if r.Body == nil {
@@ -56,12 +92,24 @@ func ListCreateHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Manage Database insertion for this new List
 	list.ListId = bson.NewObjectId()
-	listDao := dao.NewListDao()
-	if err := listDao.Insert(&list); err != nil {
+	list.BoardId = boardID
+
+	// New lists are appended at the end of their board
+	count, err := store.Lists.CountByBoardID(boardID)
+	if err != nil {
+		handlerLogger.Errorf("Could not count lists of board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	list.Order = count + 1
+
+	if err := auth.StoreFor(r, store).Lists.Insert(&list); err != nil {
 		handlerLogger.Error("Could not insert to database")
 		helpers.RespondWithError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	activity.Record(r, store, list.BoardId, models.VerbCreated, models.EntityList, list.ListId, nil, list.ActivitySummary(), handlerLogger)
+	audit.Record(r, store, list.BoardId, models.VerbCreated, models.EntityList, list.ListId, nil, models.NewSnapshot(list), handlerLogger)
 
 	helpers.RespondWithJson(w, http.StatusCreated, list)
 }
@@ -69,34 +117,28 @@ func ListCreateHandler(w http.ResponseWriter, r *http.Request) {
 // ListDeleteHandler -> Handler for List Deletion Endpoint
 func ListDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
-
-	vars := mux.Vars(r)
-	listIDVars := vars["listId"]
-
-	if isObjectID := bson.IsObjectIdHex(listIDVars); !isObjectID {
-		handlerLogger.Warn("User provided invalid Object ID for parmeters listId")
-		helpers.RespondWithError(w, http.StatusBadRequest, "Invalid ObjectID")
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
 		return
 	}
 
-	listID := bson.ObjectIdHex(listIDVars)
-
-	listDAO := dao.NewListDao()
-	taskDAO := dao.NewTaskDAO(database.GetDatabaseConnection())
+	list, ok := findList(w, r, handlerLogger)
+	if !ok {
+		return
+	}
 
-	list, err := listDAO.FindByIDAndDelete(listID)
-	if err != nil {
-		handlerLogger.Warnf("List not found with id: %s", listIDVars)
-		helpers.RespondWithError(w, http.StatusNotFound, "List not found")
+	// Tasks attached to the list are removed along with it by the store
+	if err := store.Lists.Delete(&list); err != nil {
+		handlerLogger.Errorf("Could not delete list with id: %s, got error: %s", list.ListId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
 		return
 	}
 
-	if err := taskDAO.DeleteFromListID(listID); err != nil {
-		// TODO: Check whether to respond now or ignore as list is already deleted, or better: Use a transaction
-		handlerLogger.Error("Could not delete tasks from database")
-		//helpers.RespondWithError(w, http.StatusInternalServerError, "Could remove tasks attached to given list")
-		//return
+	// Close the gap left in the board's ordering
+	if err := store.Lists.Renumber(list.BoardId); err != nil {
+		handlerLogger.Warnf("Could not renumber lists of board %s, got error: %s", list.BoardId.Hex(), err.Error())
 	}
+	activity.Record(r, store, list.BoardId, models.VerbDeleted, models.EntityList, list.ListId, list.ActivitySummary(), nil, handlerLogger)
+	audit.Record(r, store, list.BoardId, models.VerbDeleted, models.EntityList, list.ListId, models.NewSnapshot(list), nil, handlerLogger)
 
 	helpers.RespondWithJson(w, http.StatusOK, list)
 }
@@ -104,23 +146,10 @@ func ListDeleteHandler(w http.ResponseWriter, r *http.Request) {
 // ListViewHandler -> Handler to View List Endpoint
 func ListViewHandler(w http.ResponseWriter, r *http.Request) {
 	handlerLogger := logger.GenerateLogger(constants.HandlerViewLogger, r.URL.Path, r.Method)
-	vars := mux.Vars(r)
-	listIdVars := vars["listId"]
-
-	if isObjectId := bson.IsObjectIdHex(listIdVars); !isObjectId {
-		handlerLogger.Warn("Invalid Object ID for list")
-		helpers.RespondWithError(w, http.StatusBadRequest, "Invalid ObjectID")
-		return
-	}
 
-	listID := bson.ObjectIdHex(listIdVars)
-	listDAO := dao.NewListDao()
-
-	list, err := listDAO.FindByID(listID)
-	// List not found
-	if err != nil {
-		handlerLogger.Warnf("List not found with id: %s", listIdVars)
-		helpers.RespondWithError(w, http.StatusNotFound, "List not found")
+	list, ok := findList(w, r, handlerLogger)
+	// Invalid identifiers or List not found in this board
+	if !ok {
 		return
 	}
 
@@ -132,24 +161,13 @@ func ListViewHandler(w http.ResponseWriter, r *http.Request) {
 func ListUpdateHandler(w http.ResponseWriter, r *http.Request) {
 	var mainList models.List
 	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
-	vars := mux.Vars(r)
-
-	listIdVars := vars["listId"]
-
-	if isObjectId := bson.IsObjectIdHex(listIdVars); !isObjectId {
-		handlerLogger.Warn("Invalid Object ID for list")
-		helpers.RespondWithError(w, http.StatusBadRequest, "Invalid ObjectID")
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
 		return
 	}
 
-	listID := bson.ObjectIdHex(listIdVars)
-	listDAO := dao.NewListDao()
-
 	// TODO: Create / Use method UpdateByID -> check error type for response
-	list, err := listDAO.FindByID(listID)
-	if err != nil {
-		handlerLogger.Warnf("List not found with id: %s", listIdVars)
-		helpers.RespondWithError(w, http.StatusNotFound, "List not found")
+	list, ok := findList(w, r, handlerLogger)
+	if !ok {
 		return
 	}
 
@@ -172,25 +190,209 @@ func ListUpdateHandler(w http.ResponseWriter, r *http.Request) {
 	bodyJson := helpers.JsonEncode(body)
 	// Validate Request body types against List data structure
 	if err := json.Unmarshal(bodyJson, &mainList); err != nil {
-		handlerLogger.Fatal(err.Error())
-		helpers.RespondWithError(w, http.StatusInternalServerError, "Can not unmarshal body")
+		handlerLogger.Warnf("Invalid types in request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Null attributes are left unchanged
+	_, hasName := body["name"].(string)
+	_, hasOrder := body["order"].(float64)
+	if !hasName && !hasOrder {
+		handlerLogger.Warn("Received request body without any updatable field")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Nothing to update, expected fields: name, order")
 		return
 	}
 
 	// Validate List from Request Body
-	if err := helpers.Validate(mainList, "onCreate"); err != nil {
+	before, beforeSnapshot := list.ActivitySummary(), models.NewSnapshot(list)
+	list.HydrateFromMap(body)
+	if err := helpers.Validate(list, "onCreate"); err != nil {
 		handlerLogger.Warnf("Could not validate List model, received error: %s", err.Error())
 		helpers.RespondWithError(w, http.StatusBadRequest, "Could not validate given data, errors: " + err.Error())
 		return
 	}
 
-	list.HydrateFromMap(body)
-	if err := listDAO.Update(&list); err != nil {
-		handlerLogger.Warnf("Could not update list with id: %s, got error: %s", listIdVars, err.Error())
+	// Update leaves the order unchanged, it is only changed through Move which renumbers every list of the board at once
+	if hasName {
+		if err := auth.StoreFor(r, store).Lists.Update(&list); err != nil {
+			handlerLogger.Warnf("Could not update list with id: %s, got error: %s", list.ListId.Hex(), err.Error())
+			helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+			return
+		}
+	}
+
+	if hasOrder {
+		if err := store.Lists.Move(&list, list.Order); err != nil {
+			handlerLogger.Errorf("Could not move list with id: %s, got error: %s", list.ListId.Hex(), err.Error())
+			helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+			return
+		}
+	}
+
+	// A change of order alone is a move
+	after := list.ActivitySummary()
+	verb := models.VerbUpdated
+	if before["name"] == after["name"] {
+		verb = models.VerbMoved
+	}
+	activity.Record(r, store, list.BoardId, verb, models.EntityList, list.ListId, before, after, handlerLogger)
+	audit.Record(r, store, list.BoardId, verb, models.EntityList, list.ListId, beforeSnapshot, models.NewSnapshot(list), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, list)
+}
+
+// ListIndexHandler -> Handler to List every List of a Board, sorted by order, with their tasks embedded
+// Query parameter summary=true replaces embedded tasks by a summary (count and points totals) of each list
+func ListIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+
+	summary := false
+	if summaryParam := r.URL.Query().Get("summary"); len(summaryParam) > 0 {
+		var err error
+		if summary, err = strconv.ParseBool(summaryParam); err != nil {
+			handlerLogger.Warnf("Invalid value for query parameter summary: %s", summaryParam)
+			helpers.RespondWithError(w, http.StatusBadRequest, "Query parameter summary must be a boolean")
+			return
+		}
+	}
+
+	board, ok := auth.FindBoard(w, r, store.Boards, handlerLogger)
+	if !ok {
+		return
+	}
+
+	lists, err := store.Lists.FindByBoardID(board.BoardId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve lists of board %s, got error: %s", board.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	// Retrieve every task of the board at once, then dispatch them in their lists
+	tasks, err := store.Tasks.FindByBoardID(board.BoardId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve tasks of board %s, got error: %s", board.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	if !summary {
+		if err := dao.FillCommentCounts(store.Comments, tasks); err != nil {
+			handlerLogger.Errorf("Could not count comments of board %s tasks, got error: %s", board.BoardId.Hex(), err.Error())
+			helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+			return
+		}
+	}
+
+	tasksByList := make(map[bson.ObjectId][]models.Task, len(lists))
+	for _, task := range tasks {
+		tasksByList[task.ListId] = append(tasksByList[task.ListId], task)
+	}
+
+	for i := range lists {
+		listTasks := tasksByList[lists[i].ListId]
+		if summary {
+			lists[i].Summarize(listTasks)
+			lists[i].Tasks = []models.Task{}
+			continue
+		}
+		if listTasks == nil {
+			listTasks = []models.Task{}
+		}
+		lists[i].Tasks = listTasks
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, ListApiResponse{Lists: lists})
+}
+
+// ListReorderHandler -> Handler to Reorder every List of a Board at once, from the full ordered set of list IDs
+func ListReorderHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerMoveLogger, r.URL.Path, r.Method)
+	var reorder ListReorder
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	board, ok := auth.FindBoard(w, r, store.Boards, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received Empty request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&reorder); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	currentIDs, err := store.Lists.FindIDsByBoardID(board.BoardId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve lists of board %s, got error: %s", board.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	// Given IDs must be exactly the board's lists, each of them once
+	if !sameIDs(currentIDs, reorder.Lists) {
+		handlerLogger.Warnf("Reorder of board %s does not contain exactly the board's lists", board.BoardId.Hex())
+		helpers.RespondWithError(w, http.StatusBadRequest, "Field lists must contain every list of the board, exactly once")
+		return
+	}
+
+	if err := store.Lists.Reorder(reorder.Lists); err != nil {
+		handlerLogger.Errorf("Could not reorder lists of board %s, got error: %s", board.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	lists, err := store.Lists.FindByBoardID(board.BoardId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve lists of board %s, got error: %s", board.BoardId.Hex(), err.Error())
 		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
 		return
 	}
-	handlerLogger.Infof("I fucked it all: %s", list)
 
-	helpers.RespondWithJson(w, http.StatusOK, mainList)
+	// Record a move for every list whose position changed, lists were previously sorted as currentIDs
+	previousOrders := make(map[bson.ObjectId]int, len(currentIDs))
+	for i, id := range currentIDs {
+		previousOrders[id] = i + 1
+	}
+	for i := range lists {
+		if lists[i].Order == previousOrders[lists[i].ListId] {
+			continue
+		}
+		// Snapshots hold JSON values, numbers being float64
+		before, beforeSnapshot := lists[i].ActivitySummary(), models.NewSnapshot(lists[i])
+		before["order"], beforeSnapshot["order"] = previousOrders[lists[i].ListId], float64(previousOrders[lists[i].ListId])
+		activity.Record(r, store, board.BoardId, models.VerbMoved, models.EntityList, lists[i].ListId, before, lists[i].ActivitySummary(), handlerLogger)
+		audit.Record(r, store, board.BoardId, models.VerbMoved, models.EntityList, lists[i].ListId, beforeSnapshot, models.NewSnapshot(lists[i]), handlerLogger)
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, ListApiResponse{Lists: lists})
+}
+
+// sameIDs -> Check whether given slices hold the same set of IDs, without duplicates
+func sameIDs(expected, got []bson.ObjectId) bool {
+	if len(expected) != len(got) {
+		return false
+	}
+
+	remaining := make(map[bson.ObjectId]bool, len(expected))
+	for _, id := range expected {
+		remaining[id] = true
+	}
+	for _, id := range got {
+		if !remaining[id] {
+			return false
+		}
+		delete(remaining, id)
+	}
+	return true
 }
@@ -1,11 +1,23 @@
 package lists
 
 import (
+	"github.com/AmFlint/taco-api-go/dao"
 	"github.com/gorilla/mux"
 )
 
-// Initialize Routes for Task Resource
-func InitRoutes(listRouter *mux.Router) {
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for List Resource
+func InitRoutes(listRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- List Index (sorted by order) ---- //
+	listRouter.HandleFunc("", ListIndexHandler).Methods("GET")
+	listRouter.HandleFunc("/", ListIndexHandler).Methods("GET")
+	// ---- List Reorder (whole board at once) ---- //
+	listRouter.HandleFunc("/reorder", ListReorderHandler).Methods("POST")
+	listRouter.HandleFunc("/reorder/", ListReorderHandler).Methods("POST")
 	// ---- List Creation ---- //
 	listRouter.HandleFunc("", ListCreateHandler).Methods("POST")
 	listRouter.HandleFunc("/", ListCreateHandler).Methods("POST")
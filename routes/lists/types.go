@@ -2,8 +2,14 @@ package lists
 
 import (
 	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
 )
 
 type ListApiResponse struct {
 	Lists []models.List `json:"lists"`
-}
\ No newline at end of file
+}
+
+// ListReorder -> Request body of the Reorder endpoint, every list ID of the board in the wanted order
+type ListReorder struct {
+	Lists []bson.ObjectId `json:"lists"`
+}
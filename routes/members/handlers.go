@@ -0,0 +1,243 @@
+package members
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+)
+
+var memberLogger *log.Entry
+
+func init() {
+	memberLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceMembersLogger)
+}
+
+// withIdentity -> Attach member's email and name, respond with an error if the user can not be retrieved
+func withIdentity(w http.ResponseWriter, member models.BoardMember, handlerLogger *log.Entry) (Member, bool) {
+	user, err := store.Users.FindByID(member.UserId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve user %s, got error: %s", member.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return Member{}, false
+	}
+	return Member{BoardMember: member, Email: user.Email, Name: user.Name}, true
+}
+
+// findMember -> Retrieve membership targeted by route parameters boardId/userId, respond with an error if it does not exist
+func findMember(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.BoardMember, bool) {
+	current, _ := auth.MemberFromContext(r.Context())
+	userID, ok := helpers.GetObjectIdVar(w, r, "userId", handlerLogger)
+	if !ok {
+		return models.BoardMember{}, false
+	}
+
+	member, err := store.Members.FindByBoardAndUser(current.BoardId, userID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve member %s, got error: %s", userID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return member, false
+	}
+	if err != nil {
+		handlerLogger.Warnf("Member not found with id: %s in board %s", userID.Hex(), current.BoardId.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Member not found")
+		return member, false
+	}
+	return member, true
+}
+
+// keepsAnOwner -> Check that the board still has an owner once member loses its role, respond with an error otherwise
+func keepsAnOwner(w http.ResponseWriter, member models.BoardMember, handlerLogger *log.Entry) bool {
+	if member.Role != models.RoleOwner {
+		return true
+	}
+
+	members, err := store.Members.FindByBoardID(member.BoardId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve members of board %s, got error: %s", member.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return false
+	}
+
+	for _, other := range members {
+		if other.Role == models.RoleOwner && other.UserId != member.UserId {
+			return true
+		}
+	}
+	handlerLogger.Warnf("Refused to remove last owner of board %s", member.BoardId.Hex())
+	helpers.RespondWithError(w, http.StatusConflict, "A board must keep at least one owner")
+	return false
+}
+
+// MemberIndexHandler -> Handler for Member Listing Endpoint, open to every member of the board
+func MemberIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	current, _ := auth.MemberFromContext(r.Context())
+
+	boardMembers, err := store.Members.FindByBoardID(current.BoardId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve members of board %s, got error: %s", current.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	members := make([]Member, len(boardMembers))
+	for i, boardMember := range boardMembers {
+		member, ok := withIdentity(w, boardMember, handlerLogger)
+		if !ok {
+			return
+		}
+		members[i] = member
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, MemberApiResponse{Members: members})
+}
+
+// MemberCreateHandler -> Handler for Member Addition Endpoint, restricted to board owners
+func MemberCreateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	var body MemberCreate
+	if !auth.Authorize(w, r, models.RoleOwner, handlerLogger) {
+		return
+	}
+	current, _ := auth.MemberFromContext(r.Context())
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if errs := helpers.Validate(body, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on member addition, got error: %s", errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return
+	}
+
+	user, err := store.Users.FindByEmail(models.NormalizeEmail(body.Email))
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve user, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	if err != nil {
+		handlerLogger.Warn("No user registered with given email")
+		helpers.RespondWithError(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	member := models.NewBoardMember(current.BoardId, user.UserId, body.Role)
+	err = store.Members.Insert(&member)
+	if err == dao.ErrDuplicate {
+		handlerLogger.Warnf("User %s already is a member of board %s", user.UserId.Hex(), current.BoardId.Hex())
+		helpers.RespondWithError(w, http.StatusConflict, "User already is a member of this board")
+		return
+	}
+	if err != nil {
+		handlerLogger.Errorf("Could not insert member, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, member.BoardId, models.VerbCreated, models.EntityMember, member.UserId, nil, models.NewSnapshot(member), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusCreated, Member{BoardMember: member, Email: user.Email, Name: user.Name})
+}
+
+// MemberUpdateHandler -> Handler to Update a Member's role, restricted to board owners
+func MemberUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
+	var body MemberUpdate
+	if !auth.Authorize(w, r, models.RoleOwner, handlerLogger) {
+		return
+	}
+
+	member, ok := findMember(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received Empty request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := helpers.Validate(body, "onCreate"); err != nil {
+		handlerLogger.Warnf("Validation failed for User Input, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if body.Role != models.RoleOwner && !keepsAnOwner(w, member, handlerLogger) {
+		return
+	}
+
+	before := models.NewSnapshot(member)
+	member.Role = body.Role
+	if err := store.Members.Update(&member); err != nil {
+		handlerLogger.Errorf("Could not update member %s, got error: %s", member.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, member.BoardId, models.VerbUpdated, models.EntityMember, member.UserId, before, models.NewSnapshot(member), handlerLogger)
+
+	response, ok := withIdentity(w, member, handlerLogger)
+	if !ok {
+		return
+	}
+	helpers.RespondWithJson(w, http.StatusOK, response)
+}
+
+// MemberDeleteHandler -> Handler for Member Removal Endpoint, restricted to board owners, except for members leaving the board
+func MemberDeleteHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	current, _ := auth.MemberFromContext(r.Context())
+
+	member, ok := findMember(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	if member.UserId != current.UserId && !auth.Authorize(w, r, models.RoleOwner, handlerLogger) {
+		return
+	}
+
+	if !keepsAnOwner(w, member, handlerLogger) {
+		return
+	}
+
+	if err := store.Members.Delete(&member); err != nil {
+		handlerLogger.Errorf("Could not delete member %s, got error: %s", member.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	audit.Record(r, store, member.BoardId, models.VerbDeleted, models.EntityMember, member.UserId, models.NewSnapshot(member), nil, handlerLogger)
+
+	// Former members are neither assigned to nor watching the board's tasks anymore
+	if err := store.Tasks.RemoveUser(member.BoardId, member.UserId); err != nil {
+		handlerLogger.Warnf("Could not remove user %s from tasks of board %s, got error: %s", member.UserId.Hex(), member.BoardId.Hex(), err.Error())
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, member)
+}
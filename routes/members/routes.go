@@ -0,0 +1,27 @@
+package members
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for Board Member Resource
+func InitRoutes(memberRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Member Index ---- //
+	memberRouter.HandleFunc("", MemberIndexHandler).Methods("GET")
+	memberRouter.HandleFunc("/", MemberIndexHandler).Methods("GET")
+	// ---- Member Addition ---- //
+	memberRouter.HandleFunc("", MemberCreateHandler).Methods("POST")
+	memberRouter.HandleFunc("/", MemberCreateHandler).Methods("POST")
+	// ---- Member Role Update ---- //
+	memberRouter.HandleFunc("/{userId}", MemberUpdateHandler).Methods("PATCH")
+	memberRouter.HandleFunc("/{userId}/", MemberUpdateHandler).Methods("PATCH")
+	// ---- Member Removal ---- //
+	memberRouter.HandleFunc("/{userId}", MemberDeleteHandler).Methods("DELETE")
+	memberRouter.HandleFunc("/{userId}/", MemberDeleteHandler).Methods("DELETE")
+}
@@ -0,0 +1,28 @@
+package members
+
+import (
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// Member -> Board membership along with the member's identity
+type Member struct {
+	models.BoardMember
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
+
+// MemberApiResponse -> Response of member index endpoint
+type MemberApiResponse struct {
+	Members []Member `json:"members"`
+}
+
+// MemberCreate -> Request body of member addition endpoint, the user is designated by its email
+type MemberCreate struct {
+	Email string `json:"email" onCreate:"nonzero"`
+	Role  string `json:"role" onCreate:"nonzero,regexp=^(owner|editor|viewer)$"`
+}
+
+// MemberUpdate -> Request body of member role update endpoint
+type MemberUpdate struct {
+	Role string `json:"role" onCreate:"nonzero,regexp=^(owner|editor|viewer)$"`
+}
@@ -0,0 +1,116 @@
+package search
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// Bounds of a search: length of the query, number of distinct words, and deepest result reachable through pagination
+const (
+	MaxQueryLength   = 200
+	MaxSearchTerms   = 10
+	MaxSearchResults = 500
+)
+
+var searchLogger *log.Entry
+
+func init() {
+	searchLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceSearchLogger)
+}
+
+// taskResult -> Search result of a matching task, its snippet is taken from the description when it holds a term
+func taskResult(match dao.TaskMatch, terms []string) SearchResult {
+	snippet, found := highlight(match.Task.Description, terms)
+	if !found {
+		snippet, _ = highlight(match.Task.Title, terms)
+	}
+	return SearchResult{Type: models.EntityTask, BoardId: match.Task.BoardId, ListId: match.Task.ListId, TaskId: match.Task.TaskId,
+		Title: match.Task.Title, Snippet: snippet, Score: match.Score}
+}
+
+// listResult -> Search result of a matching list
+func listResult(match dao.ListMatch, terms []string) SearchResult {
+	snippet, _ := highlight(match.List.Name, terms)
+	return SearchResult{Type: models.EntityList, BoardId: match.List.BoardId, ListId: match.List.ListId,
+		Title: match.List.Name, Snippet: snippet, Score: match.Score}
+}
+
+// SearchHandler -> Handler for Search Endpoint, searches task titles and descriptions, and list names, of every board
+// the user is a member of. Query parameter q holds the searched words, results are paginated (limit, offset)
+func SearchHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+
+	text := r.URL.Query().Get("q")
+	if len(text) > MaxQueryLength {
+		handlerLogger.Warn("User provided a search query too long")
+		helpers.RespondWithError(w, http.StatusBadRequest, "q must be at most "+strconv.Itoa(MaxQueryLength)+" characters long")
+		return
+	}
+
+	terms := dao.SearchTerms(text)
+	if len(terms) == 0 || len(terms) > MaxSearchTerms {
+		handlerLogger.Warnf("User provided a search query with %d words", len(terms))
+		helpers.RespondWithError(w, http.StatusBadRequest, "q must hold between 1 and "+strconv.Itoa(MaxSearchTerms)+" words")
+		return
+	}
+
+	page, ok := helpers.GetPage(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	if page.Offset+page.Limit > MaxSearchResults {
+		handlerLogger.Warnf("User requested search results beyond %d", MaxSearchResults)
+		helpers.RespondWithError(w, http.StatusBadRequest, "Only the first "+strconv.Itoa(MaxSearchResults)+" results can be requested")
+		return
+	}
+
+	boardIDs, err := store.Members.FindBoardIDsByUserID(user.UserId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve memberships of user %s, got error: %s", user.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	// Every result of the page may come from either tasks or lists
+	query := dao.SearchQuery{BoardIDs: boardIDs, Terms: terms, Limit: page.Offset + page.Limit}
+	tasks, err := store.Tasks.Search(query)
+	if err != nil {
+		handlerLogger.Errorf("Could not search tasks, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	lists, err := store.Lists.Search(query)
+	if err != nil {
+		handlerLogger.Errorf("Could not search lists, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	results := []SearchResult{}
+	for _, match := range tasks {
+		results = append(results, taskResult(match, terms))
+	}
+	for _, match := range lists {
+		results = append(results, listResult(match, terms))
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+
+	start, end := page.Offset, page.Offset+page.Limit
+	if start > len(results) {
+		start = len(results)
+	}
+	if end > len(results) {
+		end = len(results)
+	}
+	helpers.RespondWithJson(w, http.StatusOK, SearchApiResponse{Results: results[start:end], Limit: page.Limit, Offset: page.Offset})
+}
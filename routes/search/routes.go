@@ -0,0 +1,18 @@
+package search
+
+import (
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Initialize Routes for the Search endpoint
+func InitRoutes(searchRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Search across boards ---- //
+	searchRouter.HandleFunc("", SearchHandler).Methods("GET")
+	searchRouter.HandleFunc("/", SearchHandler).Methods("GET")
+}
@@ -0,0 +1,79 @@
+package search
+
+import (
+	"html"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/dao"
+)
+
+// Length of snippets, and of the text kept before the first occurrence of a term, in characters
+const (
+	SnippetLength  = 160
+	SnippetContext = 40
+)
+
+// occurrences -> Ranges [start, end) of the runes of given text holding one of the terms, longest term first
+func occurrences(text []rune, terms [][]rune) [][2]int {
+	ranges := [][2]int{}
+	for i := 0; i < len(text); {
+		length := 0
+		for _, term := range terms {
+			if len(term) > length && i+len(term) <= len(text) && string(text[i:i+len(term)]) == string(term) {
+				length = len(term)
+			}
+		}
+		if length == 0 {
+			i++
+			continue
+		}
+		ranges = append(ranges, [2]int{i, i + length})
+		i += length
+	}
+	return ranges
+}
+
+// highlight -> HTML escaped excerpt of given text starting shortly before the first occurrence of a term (or at the
+// start of the text), occurrences being wrapped in <mark> tags. Whether text holds one of the terms
+func highlight(text string, terms []string) (string, bool) {
+	runes := []rune(text)
+	// Terms are folded rune by rune, so positions in the folded text are positions in the original one
+	folded := []rune(dao.FoldCase(text))
+	foldedTerms := make([][]rune, len(terms))
+	for i, term := range terms {
+		foldedTerms[i] = []rune(term)
+	}
+	ranges := occurrences(folded, foldedTerms)
+
+	start := 0
+	if len(ranges) > 0 && ranges[0][0] > SnippetContext {
+		start = ranges[0][0] - SnippetContext
+	}
+	end := start + SnippetLength
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	var snippet strings.Builder
+	if start > 0 {
+		snippet.WriteString("…")
+	}
+	position := start
+	for _, occurrence := range ranges {
+		if occurrence[0] >= end {
+			break
+		}
+		// Never cut an occurrence
+		if occurrence[1] > end {
+			end = occurrence[1]
+		}
+		snippet.WriteString(html.EscapeString(string(runes[position:occurrence[0]])))
+		snippet.WriteString("<mark>" + html.EscapeString(string(runes[occurrence[0]:occurrence[1]])) + "</mark>")
+		position = occurrence[1]
+	}
+	snippet.WriteString(html.EscapeString(string(runes[position:end])))
+	if end < len(runes) {
+		snippet.WriteString("…")
+	}
+	return snippet.String(), len(ranges) > 0
+}
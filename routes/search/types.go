@@ -0,0 +1,26 @@
+package search
+
+import (
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SearchResult -> Task or list matching a search, along with an excerpt of its matching text
+type SearchResult struct {
+	// models.EntityTask or models.EntityList
+	Type    string        `json:"type"`
+	BoardId bson.ObjectId `json:"boardId"`
+	ListId  bson.ObjectId `json:"listId"`
+	TaskId  bson.ObjectId `json:"taskId,omitempty"`
+	// Title of the task, or name of the list
+	Title string `json:"title"`
+	// HTML escaped excerpt of the description (or title) holding the terms, wrapped in <mark> tags
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+// SearchApiResponse -> Response of search endpoint, a page of results, most relevant first
+type SearchApiResponse struct {
+	Results []SearchResult `json:"results"`
+	Limit   int            `json:"limit"`
+	Offset  int            `json:"offset"`
+}
@@ -0,0 +1,193 @@
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/activity"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// bulkVerbs -> Verb of the activity recorded for each task of a bulk operation
+var bulkVerbs = map[string]string{
+	BulkSetStatus: models.VerbUpdated,
+	BulkSetPoints: models.VerbUpdated,
+	BulkMove:      models.VerbMoved,
+	BulkDelete:    models.VerbDeleted,
+}
+
+// bulkChanges -> Attributes set on every task by a bulk update (setStatus, setPoints), as a request body of the update endpoint
+// would, respond with Bad Request when the operation's argument is missing
+func bulkChanges(w http.ResponseWriter, bulk TaskBulk, handlerLogger *log.Entry) (map[string]interface{}, bool) {
+	var changes map[string]interface{}
+	switch {
+	case bulk.Operation == BulkSetStatus && bulk.Status != nil:
+		changes = map[string]interface{}{"status": *bulk.Status}
+	case bulk.Operation == BulkSetPoints && bulk.Points != nil:
+		changes = map[string]interface{}{"points": *bulk.Points}
+	case bulk.Operation == BulkSetStatus:
+		handlerLogger.Warn("Bulk operation setStatus without status")
+		helpers.RespondWithError(w, http.StatusBadRequest, "status is required by operation setStatus")
+		return nil, false
+	case bulk.Operation == BulkSetPoints:
+		handlerLogger.Warn("Bulk operation setPoints without points")
+		helpers.RespondWithError(w, http.StatusBadRequest, "points is required by operation setPoints")
+		return nil, false
+	}
+	return changes, true
+}
+
+// checkBulkTarget -> Make sure the target list of a bulk move is given and belongs to the board, respond with an error otherwise
+func checkBulkTarget(w http.ResponseWriter, boardID, listID bson.ObjectId, handlerLogger *log.Entry) bool {
+	if listID == "" {
+		handlerLogger.Warn("Bulk operation move without listId")
+		helpers.RespondWithError(w, http.StatusBadRequest, "listId is required by operation move")
+		return false
+	}
+
+	_, err := store.Lists.FindByBoardAndID(boardID, listID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve list %s, got error: %s", listID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return false
+	}
+	if err != nil {
+		handlerLogger.Warnf("Target list not found with id: %s in board %s", listID.Hex(), boardID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Target list not found")
+		return false
+	}
+	return true
+}
+
+// applyBulk -> Apply a validated bulk operation to given tasks at once
+func applyBulk(r *http.Request, bulk TaskBulk, tasks []models.Task) error {
+	tracked := auth.StoreFor(r, store)
+	switch bulk.Operation {
+	case BulkMove:
+		return tracked.Tasks.MoveAll(tasks, bulk.ListId)
+	case BulkDelete:
+		return tracked.Tasks.DeleteAll(tasks)
+	default:
+		return tracked.Tasks.UpdateAll(tasks)
+	}
+}
+
+// BulkTaskHandler -> Handler applying one operation (setStatus, setPoints, move at the end of a list, delete) to many tasks
+// of a board. Every task is checked first: when one of them does not exist or would not be valid, nothing is applied and
+// the response holds the error of each task. Otherwise the operation is applied at once and the response holds every task
+func BulkTaskHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerBulkLogger, r.URL.Path, r.Method)
+	var bulk TaskBulk
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that Request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received empty Request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty request body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&bulk); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := helpers.Validate(bulk, "onCreate"); err != nil {
+		handlerLogger.Warnf("Validation failed for User Input, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, ok := bulkChanges(w, bulk, handlerLogger)
+	if !ok || (bulk.Operation == BulkMove && !checkBulkTarget(w, boardID, bulk.ListId, handlerLogger)) {
+		return
+	}
+
+	boardTasks, err := store.Tasks.FindByBoardID(boardID)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve tasks of board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	byID := make(map[bson.ObjectId]models.Task, len(boardTasks))
+	for _, task := range boardTasks {
+		byID[task.TaskId] = task
+	}
+
+	// Check every task before applying anything, a task given twice is handled once
+	var tasks, before []models.Task
+	results := []TaskBulkResult{}
+	seen := map[bson.ObjectId]bool{}
+	failed := false
+	for _, taskID := range bulk.TaskIds {
+		if seen[taskID] {
+			continue
+		}
+		seen[taskID] = true
+
+		result := TaskBulkResult{TaskId: taskID, Status: http.StatusOK}
+		task, found := byID[taskID]
+		if !found {
+			result.Status, result.Error = http.StatusNotFound, "Task does not exist"
+		} else {
+			before = append(before, task)
+			if changes != nil {
+				task.HydrateFromMap(changes)
+				if err := helpers.Validate(task, "onCreate"); err != nil {
+					result.Status, result.Error = http.StatusBadRequest, err.Error()
+				}
+			}
+			tasks = append(tasks, task)
+		}
+
+		failed = failed || result.Status != http.StatusOK
+		results = append(results, result)
+	}
+
+	if failed {
+		for i := range results {
+			if results[i].Status == http.StatusOK {
+				results[i].Status, results[i].Error = http.StatusFailedDependency, "Not applied, another task failed"
+			}
+		}
+		handlerLogger.Warnf("Bulk operation %s on board %s not applied, some tasks failed", bulk.Operation, boardID.Hex())
+		helpers.RespondWithJson(w, http.StatusBadRequest, TaskBulkApiResponse{Operation: bulk.Operation, Applied: false, Results: results})
+		return
+	}
+
+	if err := applyBulk(r, bulk, tasks); err != nil {
+		handlerLogger.Errorf("Could not apply bulk operation %s on board %s, got error: %s", bulk.Operation, boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Server Error during bulk task operation")
+		return
+	}
+
+	verb := bulkVerbs[bulk.Operation]
+	for i := range tasks {
+		var after models.ActivitySummary
+		var afterSnapshot models.Snapshot
+		if bulk.Operation != BulkDelete {
+			after, afterSnapshot = tasks[i].ActivitySummary(), models.NewSnapshot(tasks[i])
+		}
+		activity.Record(r, store, boardID, verb, models.EntityTask, tasks[i].TaskId, before[i].ActivitySummary(), after, handlerLogger)
+		audit.Record(r, store, boardID, verb, models.EntityTask, tasks[i].TaskId, models.NewSnapshot(before[i]), afterSnapshot, handlerLogger)
+		results[i].Task = &tasks[i]
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, TaskBulkApiResponse{Operation: bulk.Operation, Applied: true, Results: results})
+}
@@ -0,0 +1,152 @@
+package tasks
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Number of days of the due tasks endpoints, when query parameter days is absent and at most
+const (
+	DefaultDueDays = 7
+	MaxDueDays     = 365
+)
+
+// checkDates -> Normalize start and due dates of a Task, respond with an error if it is due before it starts
+func checkDates(w http.ResponseWriter, task *models.Task, handlerLogger *log.Entry) bool {
+	task.NormalizeDates()
+	if err := task.CheckDates(); err != nil {
+		handlerLogger.Warnf("Invalid dates for task %s, got error: %s", task.Title, err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// overdueQuery -> Tasks due before now
+func overdueQuery() dao.DueQuery {
+	return dao.DueQuery{Before: time.Now().UTC()}
+}
+
+// dueWithinQuery -> Tasks due from now to the number of days given by query parameter days, respond with an error if it is not valid
+func dueWithinQuery(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (dao.DueQuery, bool) {
+	days := DefaultDueDays
+	if param := r.URL.Query().Get("days"); param != "" {
+		value, err := strconv.Atoi(param)
+		if err != nil || value < 1 || value > MaxDueDays {
+			handlerLogger.Warnf("User provided invalid number of days: %s", param)
+			helpers.RespondWithError(w, http.StatusBadRequest, "days must be an integer between 1 and "+strconv.Itoa(MaxDueDays))
+			return dao.DueQuery{}, false
+		}
+		days = value
+	}
+
+	now := time.Now().UTC()
+	return dao.DueQuery{From: &now, Before: now.AddDate(0, 0, days)}, true
+}
+
+// respondWithDueTasks -> Respond with tasks matching given query, along with their number of comments
+func respondWithDueTasks(w http.ResponseWriter, query dao.DueQuery, keep func(task models.Task) bool, handlerLogger *log.Entry) {
+	due, err := store.Tasks.FindDue(query)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve due tasks, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	tasks := make([]models.Task, 0, len(due))
+	for _, task := range due {
+		if keep(task) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	if err := dao.FillCommentCounts(store.Comments, tasks); err != nil {
+		handlerLogger.Errorf("Could not count comments of tasks, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, tasks)
+}
+
+// respondWithBoardDueTasks -> Respond with tasks of the board of route parameter boardId matching given query
+func respondWithBoardDueTasks(w http.ResponseWriter, r *http.Request, query dao.DueQuery, handlerLogger *log.Entry) {
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	query.BoardID = boardID
+	respondWithDueTasks(w, query, func(models.Task) bool { return true }, handlerLogger)
+}
+
+// respondWithMyDueTasks -> Respond with tasks assigned to the current user matching given query, on boards it still is a member of
+func respondWithMyDueTasks(w http.ResponseWriter, r *http.Request, query dao.DueQuery, handlerLogger *log.Entry) {
+	user, _ := auth.UserFromContext(r.Context())
+
+	member, ok := memberBoards(w, user.UserId, handlerLogger)
+	if !ok {
+		return
+	}
+
+	query.Assignee = user.UserId
+	respondWithDueTasks(w, query, func(task models.Task) bool { return member[task.BoardId] }, handlerLogger)
+}
+
+// memberBoards -> IDs of boards given user is a member of, respond with an error if they can not be retrieved
+func memberBoards(w http.ResponseWriter, userID bson.ObjectId, handlerLogger *log.Entry) (map[bson.ObjectId]bool, bool) {
+	boardIDs, err := store.Members.FindBoardIDsByUserID(userID)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve memberships of user %s, got error: %s", userID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return nil, false
+	}
+
+	member := make(map[bson.ObjectId]bool, len(boardIDs))
+	for _, boardID := range boardIDs {
+		member[boardID] = true
+	}
+	return member, true
+}
+
+// BoardOverdueHandler -> Handler for Board Overdue Tasks Endpoint, lists tasks of the board past their due date, oldest deadline first
+func BoardOverdueHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	respondWithBoardDueTasks(w, r, overdueQuery(), handlerLogger)
+}
+
+// BoardDueHandler -> Handler for Board Due Tasks Endpoint, lists tasks of the board due within the next days (query parameter days)
+func BoardDueHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	query, ok := dueWithinQuery(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	respondWithBoardDueTasks(w, r, query, handlerLogger)
+}
+
+// MyOverdueHandler -> Handler for My Overdue Tasks Endpoint, lists tasks assigned to the current user past their due date
+func MyOverdueHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	respondWithMyDueTasks(w, r, overdueQuery(), handlerLogger)
+}
+
+// MyDueHandler -> Handler for My Due Tasks Endpoint, lists tasks assigned to the current user due within the next days (query parameter days)
+func MyDueHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	query, ok := dueWithinQuery(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+	respondWithMyDueTasks(w, r, query, handlerLogger)
+}
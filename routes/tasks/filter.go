@@ -0,0 +1,56 @@
+package tasks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/taskfilter"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// CompileFilter -> Query on the tasks of a board equivalent to given filter expression (see package taskfilter),
+// respond with Bad Request if it is not valid
+func CompileFilter(w http.ResponseWriter, s dao.Store, boardID bson.ObjectId, expression string, handlerLogger *log.Entry) (dao.TaskQuery, bool) {
+	labels, err := s.Labels.FindByBoardID(boardID)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve labels of board %s, got error: %s", boardID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return dao.TaskQuery{}, false
+	}
+
+	query, err := taskfilter.Compile(expression, boardID, labels, time.Now().UTC())
+	if err != nil {
+		handlerLogger.Warnf("User provided invalid filter %q, got error: %s", expression, err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, "Invalid filter: "+err.Error())
+		return query, false
+	}
+	return query, true
+}
+
+// RunFilter -> Respond with a page (query parameters limit and offset) of the tasks of a board selected by given filter expression
+func RunFilter(w http.ResponseWriter, r *http.Request, boardID bson.ObjectId, expression string, handlerLogger *log.Entry) {
+	query, ok := CompileFilter(w, store, boardID, expression, handlerLogger)
+	if !ok {
+		return
+	}
+
+	respondWithTaskPage(w, r, query, handlerLogger)
+}
+
+// BoardFilterHandler -> Handler listing the tasks of a board, across its lists, selected by the filter expression
+// of query parameter filter (e.g. status:false points>5 label:bug due<7d), a page at a time (limit, offset)
+func BoardFilterHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	RunFilter(w, r, boardID, r.URL.Query().Get("filter"), handlerLogger)
+}
@@ -3,20 +3,19 @@ package tasks
 
 import (
 	"net/http"
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
 	"github.com/AmFlint/taco-api-go/helpers"
 	"github.com/AmFlint/taco-api-go/dao"
-	"github.com/AmFlint/taco-api-go/config/database"
 	"encoding/json"
 	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/activity"
 	"gopkg.in/mgo.v2/bson"
-	"github.com/gorilla/mux"
 	log "github.com/sirupsen/logrus"
 	"github.com/AmFlint/taco-api-go/constants"
 	"github.com/AmFlint/taco-api-go/helpers/logger"
 )
 
-//TODO: Create Middleware for initiating TaskDAO
-//TODO: Create Middleware for vars["taskId"]
 //TODO: Create Error message constant for Not Found
 var taskLogger *log.Entry
 
@@ -24,41 +23,124 @@ func init() {
 	taskLogger = log.WithField(constants.ResourceKeyLogger, "tasks")
 }
 
-func TaskIndexHandler(w http.ResponseWriter, r *http.Request) {
-	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
-	taskDao := dao.NewTaskDAO(database.GetDatabaseConnection())
-	tasks, err := taskDao.FindAll()
-	//tasks := []models.Task {
-	//	{TaskId: bson.NewObjectId(), Title: "Test Title", Description: "test description", Status: "done"},
-	//	{TaskId: bson.NewObjectId(), Title: "Second task", Description: "Second task desc", Status: "in progress"},
-	//}
+// findParentList -> Retrieve the List targeted by route parameters boardId/listId, respond with an error if it does not exist in this board
+func findParentList(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.List, bool) {
+	boardID, ok := helpers.GetObjectIdVar(w, r, "boardId", handlerLogger)
+	if !ok {
+		return models.List{}, false
+	}
+
+	listID, ok := helpers.GetObjectIdVar(w, r, "listId", handlerLogger)
+	if !ok {
+		return models.List{}, false
+	}
+
+	list, err := store.Lists.FindByBoardAndID(boardID, listID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve list %s, got error: %s", listID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return list, false
+	}
+	if err != nil {
+		handlerLogger.Warnf("List not found with id: %s in board %s", listID.Hex(), boardID.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "List not found")
+		return list, false
+	}
+	return list, true
+}
+
+// FindTask -> Retrieve the Task targeted by route parameters boardId/listId/taskId along with its comment count, respond with an error
+// if it does not exist in this list
+func FindTask(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (models.Task, bool) {
+	list, ok := findParentList(w, r, handlerLogger)
+	if !ok {
+		return models.Task{}, false
+	}
+
+	taskId, ok := helpers.GetObjectIdVar(w, r, "taskId", handlerLogger)
+	if !ok {
+		return models.Task{}, false
+	}
 
+	task, err := store.Tasks.FindByListAndID(list.ListId, taskId)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve task %s, got error: %s", taskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return task, false
+	}
 	if err != nil {
-		handlerLogger.Fatal("Could not connect to DB to retrieve Tasks")
+		handlerLogger.Warnf("Task does not exist for provided id: %s in list %s", taskId.Hex(), list.ListId.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, "Task does not exist")
+		return task, false
 	}
 
-	helpers.RespondWithJson(w, 200, tasks)
+	if task.CommentCount, err = store.Comments.CountByTaskID(task.TaskId); err != nil {
+		handlerLogger.Errorf("Could not count comments of task %s, got error: %s", taskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return task, false
+	}
+	return task, true
 }
 
-func TaskViewHandler(w http.ResponseWriter, r *http.Request) {
-	handlerLogger := logger.GenerateLogger(constants.HandlerViewLogger, r.URL.Path, r.Method)
-	//defer r.Body.Close()
-	vars := mux.Vars(r)
-	taskIdVar := vars["taskId"]
+// checkLabels -> Drop duplicated labels of a Task, and make sure every label is defined on the task's board, respond with an error otherwise
+func checkLabels(w http.ResponseWriter, task *models.Task, handlerLogger *log.Entry) bool {
+	task.SetDefaultLabels()
+	if len(task.Labels) == 0 {
+		return true
+	}
+
+	boardLabels, err := store.Labels.FindByBoardID(task.BoardId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve labels of board %s, got error: %s", task.BoardId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return false
+	}
+
+	defined := make(map[bson.ObjectId]bool, len(boardLabels))
+	for _, label := range boardLabels {
+		defined[label.LabelId] = true
+	}
+
+	seen := make(map[bson.ObjectId]bool, len(task.Labels))
+	labels := make([]bson.ObjectId, 0, len(task.Labels))
+	for _, labelID := range task.Labels {
+		if !defined[labelID] {
+			handlerLogger.Warnf("Label %s is not defined on board %s", labelID.Hex(), task.BoardId.Hex())
+			helpers.RespondWithError(w, http.StatusBadRequest, "Label "+labelID.Hex()+" does not exist on this board")
+			return false
+		}
+		if !seen[labelID] {
+			seen[labelID] = true
+			labels = append(labels, labelID)
+		}
+	}
+	task.Labels = labels
+	return true
+}
 
-	if isObjectId := bson.IsObjectIdHex(taskIdVar); !isObjectId {
-		handlerLogger.Warn("User provided invalid ObjectID for task Id paremeter")
-		helpers.RespondWithError(w, http.StatusBadRequest, "Parameter task id is not a valid ObjectID")
+// TaskIndexHandler -> Handler to List Tasks of a List, a page at a time (limit, offset), sorted by order unless
+// query parameter sort says otherwise, filtered by query parameters status, pointsMin, pointsMax and title
+func TaskIndexHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+
+	list, ok := findParentList(w, r, handlerLogger)
+	if !ok {
 		return
 	}
 
-	taskId := bson.ObjectIdHex(taskIdVar)
+	query, ok := parseTaskQuery(w, r, list.ListId, handlerLogger)
+	if !ok {
+		return
+	}
 
-	taskDAO := dao.NewTaskDAO(database.GetDatabaseConnection())
-	task, err := taskDAO.FindById(taskId)
-	if err != nil {
-		handlerLogger.Warnf("Task does not exist for provided id: %s", taskId.Hex())
-		helpers.RespondWithError(w, http.StatusNotFound, "Task does not exist")
+	respondWithTaskPage(w, r, query, handlerLogger)
+}
+
+func TaskViewHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerViewLogger, r.URL.Path, r.Method)
+
+	task, ok := FindTask(w, r, handlerLogger)
+	if !ok {
 		return
 	}
 
@@ -70,6 +152,15 @@ func TaskViewHandler(w http.ResponseWriter, r *http.Request) {
 func TaskCreateHandler(w http.ResponseWriter, r *http.Request) {
 	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
 	var task models.Task
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	// Tasks can only be created in an existing List of the Board
+	list, ok := findParentList(w, r, handlerLogger)
+	if !ok {
+		return
+	}
 
 	// Make sure that request body is not empty
 	if r.Body == nil {
@@ -88,46 +179,71 @@ func TaskCreateHandler(w http.ResponseWriter, r *http.Request) {
 
 
 	if errs := helpers.Validate(task, "onCreate"); errs != nil {
-		handlerLogger.Warnf("Validation failed on task %s, got error: %s", task, errs.Error())
+		handlerLogger.Warnf("Validation failed on task %s, got error: %s", task.Title, errs.Error())
 		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
 		return
 	}
 
+	if !checkDates(w, &task, handlerLogger) {
+		return
+	}
+
 	task.SetDefaultStatus()
+	// Checklists, assignees and watchers are managed through their own endpoints
+	task.Checklists = []models.Checklist{}
+	task.Assignees, task.Watchers = nil, nil
+	task.SetDefaultPeople()
 	task.TaskId = bson.NewObjectId()
+	task.ListId = list.ListId
+	task.BoardId = list.BoardId
+	if !checkLabels(w, &task, handlerLogger) {
+		return
+	}
 
-	taskDAO := dao.NewTaskDAO(database.GetDatabaseConnection())
 
-	if err := taskDAO.Insert(&task); err != nil {
+	// New tasks are appended at the end of their list
+	count, err := store.Tasks.CountByListID(list.ListId)
+	if err != nil {
+		handlerLogger.Errorf("Could not count tasks of list %s, got error: %s", list.ListId.Hex(), err.Error())
 		helpers.RespondWithError(w, http.StatusInternalServerError, "Internal Server Error")
 		return
 	}
+	task.Order = count + 1
+
+	if err := auth.StoreFor(r, store).Tasks.Insert(&task); err != nil {
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+	activity.Record(r, store, task.BoardId, models.VerbCreated, models.EntityTask, task.TaskId, nil, task.ActivitySummary(), handlerLogger)
+	audit.Record(r, store, task.BoardId, models.VerbCreated, models.EntityTask, task.TaskId, nil, models.NewSnapshot(task), handlerLogger)
 	helpers.RespondWithJson(w, http.StatusCreated, task)
 }
 
 // Http Method DELETE on Task resource: Delete a Task
 func TaskDeleteHandler(w http.ResponseWriter, r *http.Request) {
 	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
-	vars := mux.Vars(r)
-
-	taskIdVar := vars["taskId"]
-
-	if isObjectId := bson.IsObjectIdHex(taskIdVar); !isObjectId {
-		handlerLogger.Warn("User provided invalid ObjectID for task Id paremeter")
-		helpers.RespondWithError(w, http.StatusBadRequest, "Parameter Task is not a valid object id")
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
 		return
 	}
 
-	taskId := bson.ObjectIdHex(taskIdVar)
-	taskDAO := dao.NewTaskDAO(database.GetDatabaseConnection())
+	task, ok := FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
 
-	task, err := taskDAO.FindByIdAndDelete(taskId)
-	if err != nil {
-		helpers.RespondWithError(w, http.StatusNotFound, "Task Not Found")
-		handlerLogger.Warnf("Task not found with id: %s", taskId.Hex())
+	if err := store.Tasks.Delete(&task); err != nil {
+		handlerLogger.Errorf("Could not delete task with id: %s, got error: %s", task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Server Error during task Deletion")
 		return
 	}
 
+	// Close the gap left in the list's ordering
+	if err := store.Tasks.Renumber(task.ListId); err != nil {
+		handlerLogger.Warnf("Could not renumber tasks of list %s, got error: %s", task.ListId.Hex(), err.Error())
+	}
+	activity.Record(r, store, task.BoardId, models.VerbDeleted, models.EntityTask, task.TaskId, task.ActivitySummary(), nil, handlerLogger)
+	audit.Record(r, store, task.BoardId, models.VerbDeleted, models.EntityTask, task.TaskId, models.NewSnapshot(task), nil, handlerLogger)
+
 	helpers.RespondWithJson(w, http.StatusOK, task)
 	return
 }
@@ -136,25 +252,14 @@ func TaskDeleteHandler(w http.ResponseWriter, r *http.Request) {
 func TaskUpdateHandler(w http.ResponseWriter, r *http.Request) {
 	handlerLogger := logger.GenerateLogger(constants.HandlerUpdateLogger, r.URL.Path, r.Method)
 	var task models.Task
-
-	vars := mux.Vars(r)
-	taskIdHex := vars["taskId"]
-
-	// Check TaskId
-	if isObjectId := bson.IsObjectIdHex(taskIdHex);  !isObjectId {
-		handlerLogger.Warn("User provided invalid ObjectID for task Id paremeter")
-		helpers.RespondWithError(w, http.StatusBadRequest, "Invalid Task Id")
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
 		return
 	}
 
-	taskId := bson.ObjectIdHex(taskIdHex)
-	taskDao := dao.NewTaskDAO(database.GetDatabaseConnection())
 
 	// Retrieve task from database
-	mainTask, err := taskDao.FindById(taskId)
-	if err != nil {
-		handlerLogger.Warnf("Task not found for id: %s", taskId.Hex())
-		helpers.RespondWithError(w, http.StatusNotFound, err.Error())
+	mainTask, ok := FindTask(w, r, handlerLogger)
+	if !ok {
 		return
 	}
 
@@ -177,12 +282,13 @@ func TaskUpdateHandler(w http.ResponseWriter, r *http.Request) {
 	bodyJson := helpers.JsonEncode(body)
 	// Check that request body types are correct for Task Model
 	if err := json.Unmarshal(bodyJson, &task); err != nil {
-		handlerLogger.Fatal(err.Error())
-		helpers.RespondWithError(w, http.StatusInternalServerError, "Can not unmarshal body")
+		handlerLogger.Warnf("Invalid types in request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
 	// Hydrate Task from request's attributes
+	before, beforeSnapshot := mainTask.ActivitySummary(), models.NewSnapshot(mainTask)
 	mainTask.HydrateFromMap(body)
 
 	if err := helpers.Validate(mainTask, "onCreate"); err != nil {
@@ -191,11 +297,74 @@ func TaskUpdateHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := taskDao.Update(&mainTask); err != nil {
-		handlerLogger.Fatal("Error while trying to access database, unreachable")
+	if !checkDates(w, &mainTask, handlerLogger) || !checkLabels(w, &mainTask, handlerLogger) {
+		return
+	}
+
+	if err := auth.StoreFor(r, store).Tasks.Update(&mainTask); err != nil {
+		handlerLogger.Errorf("Error while trying to access database, got error: %s", err.Error())
 		helpers.RespondWithError(w, http.StatusInternalServerError, "Server Error during task Update")
 		return
 	}
+	activity.Record(r, store, mainTask.BoardId, models.VerbUpdated, models.EntityTask, mainTask.TaskId, before, mainTask.ActivitySummary(), handlerLogger)
+	audit.Record(r, store, mainTask.BoardId, models.VerbUpdated, models.EntityTask, mainTask.TaskId, beforeSnapshot, models.NewSnapshot(mainTask), handlerLogger)
 
 	helpers.RespondWithJson(w, http.StatusOK, mainTask)
-}
\ No newline at end of file
+}
+
+// Http Method POST on Task Move endpoint: Move a Task to another position, in its list or in another list of the board
+func TaskMoveHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerMoveLogger, r.URL.Path, r.Method)
+	var move TaskMove
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+
+	task, ok := FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	// Make sure that Request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Received empty Request body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty request body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&move); err != nil {
+		handlerLogger.Warnf("Bad format for Request body, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := helpers.Validate(move, "onCreate"); err != nil {
+		handlerLogger.Warnf("Validation failed for User Input, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Target list defaults to task's current list, and must belong to the same board
+	if move.ListId == "" {
+		move.ListId = task.ListId
+	}
+	if move.ListId != task.ListId {
+		if _, err := store.Lists.FindByBoardAndID(task.BoardId, move.ListId); err != nil {
+			handlerLogger.Warnf("Target list not found with id: %s in board %s", move.ListId.Hex(), task.BoardId.Hex())
+			helpers.RespondWithError(w, http.StatusNotFound, "Target list not found")
+			return
+		}
+	}
+
+	before, beforeSnapshot := task.ActivitySummary(), models.NewSnapshot(task)
+	if err := auth.StoreFor(r, store).Tasks.Move(&task, move.ListId, move.Order); err != nil {
+		handlerLogger.Errorf("Could not move task %s, got error: %s", task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Server Error during task Move")
+		return
+	}
+	activity.Record(r, store, task.BoardId, models.VerbMoved, models.EntityTask, task.TaskId, before, task.ActivitySummary(), handlerLogger)
+	audit.Record(r, store, task.BoardId, models.VerbMoved, models.EntityTask, task.TaskId, beforeSnapshot, models.NewSnapshot(task), handlerLogger)
+
+	helpers.RespondWithJson(w, http.StatusOK, task)
+}
@@ -0,0 +1,199 @@
+package tasks
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/activity"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// decodePerson -> Parse a TaskPerson request body, userId defaults to the current user when the body or the field is missing
+func decodePerson(w http.ResponseWriter, r *http.Request, handlerLogger *log.Entry) (bson.ObjectId, bool) {
+	var body TaskPerson
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+			helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+			return "", false
+		}
+	}
+
+	if body.UserId == "" {
+		user, _ := auth.UserFromContext(r.Context())
+		return user.UserId, true
+	}
+	return body.UserId, true
+}
+
+// checkBoardMember -> Make sure given user is a member of the task's board, respond with an error otherwise
+func checkBoardMember(w http.ResponseWriter, task models.Task, userID bson.ObjectId, handlerLogger *log.Entry) bool {
+	_, err := store.Members.FindByBoardAndUser(task.BoardId, userID)
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve membership of user %s, got error: %s", userID.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return false
+	}
+	if err != nil {
+		handlerLogger.Warnf("User %s is not a member of board %s", userID.Hex(), task.BoardId.Hex())
+		helpers.RespondWithError(w, http.StatusBadRequest, "User "+userID.Hex()+" is not a member of this board")
+		return false
+	}
+	return true
+}
+
+// authorizeSelfOrEditor -> Members may act on their own behalf, acting on behalf of someone else requires the editor role
+func authorizeSelfOrEditor(w http.ResponseWriter, r *http.Request, userID bson.ObjectId, handlerLogger *log.Entry) bool {
+	current, _ := auth.MemberFromContext(r.Context())
+	if current.UserId == userID {
+		return true
+	}
+	return auth.Authorize(w, r, models.RoleEditor, handlerLogger)
+}
+
+// peopleChange -> Change of the assignees/watchers of a stored task, AddPerson or RemovePerson of a TaskStore
+type peopleChange func(task *models.Task, people dao.TaskPeople, userID bson.ObjectId) (bool, error)
+
+// savePeople -> Apply change to given people of the stored task, so that concurrent changes of the task are kept, and respond
+// with the saved task. Changes are recorded from the task's state before them, when change does nothing the task is returned
+// as is, or 404 with given message when it is not empty
+func savePeople(w http.ResponseWriter, r *http.Request, task models.Task, change peopleChange, people dao.TaskPeople, userID bson.ObjectId,
+	unchanged string, handlerLogger *log.Entry) {
+	summary, snapshot := task.ActivitySummary(), models.NewSnapshot(task)
+	commentCount := task.CommentCount
+	changed, err := change(&task, people, userID)
+	if err != nil {
+		handlerLogger.Errorf("Could not update %s of task %s, got error: %s", people, task.TaskId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	task.CommentCount = commentCount
+	task.SetDefaultPeople()
+
+	if !changed && unchanged != "" {
+		handlerLogger.Warnf("User %s is not among %s of task %s", userID.Hex(), people, task.TaskId.Hex())
+		helpers.RespondWithError(w, http.StatusNotFound, unchanged)
+		return
+	}
+	if changed {
+		activity.Record(r, store, task.BoardId, models.VerbUpdated, models.EntityTask, task.TaskId, summary, task.ActivitySummary(), handlerLogger)
+		audit.Record(r, store, task.BoardId, models.VerbUpdated, models.EntityTask, task.TaskId, snapshot, models.NewSnapshot(task), handlerLogger)
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, task)
+}
+
+// TaskAssignHandler -> Handler for Task Assignment Endpoint, restricted to editors, the assignee must be a member of the board
+func TaskAssignHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, ok := FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	userID, ok := decodePerson(w, r, handlerLogger)
+	if !ok || !checkBoardMember(w, task, userID, handlerLogger) {
+		return
+	}
+
+	savePeople(w, r, task, auth.StoreFor(r, store).Tasks.AddPerson, dao.Assignees, userID, "", handlerLogger)
+}
+
+// TaskUnassignHandler -> Handler for Task Unassignment Endpoint, restricted to editors
+func TaskUnassignHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+	if !auth.Authorize(w, r, models.RoleEditor, handlerLogger) {
+		return
+	}
+
+	task, ok := FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	userID, ok := helpers.GetObjectIdVar(w, r, "userId", handlerLogger)
+	if !ok {
+		return
+	}
+
+	savePeople(w, r, task, auth.StoreFor(r, store).Tasks.RemovePerson, dao.Assignees, userID, "User is not assigned to this task", handlerLogger)
+}
+
+// TaskWatchHandler -> Handler for Task Watch Endpoint, any member may watch a task, making someone else watch it requires the editor role
+func TaskWatchHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerCreateLogger, r.URL.Path, r.Method)
+
+	task, ok := FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	userID, ok := decodePerson(w, r, handlerLogger)
+	if !ok || !authorizeSelfOrEditor(w, r, userID, handlerLogger) || !checkBoardMember(w, task, userID, handlerLogger) {
+		return
+	}
+
+	savePeople(w, r, task, auth.StoreFor(r, store).Tasks.AddPerson, dao.Watchers, userID, "", handlerLogger)
+}
+
+// TaskUnwatchHandler -> Handler for Task Unwatch Endpoint, any member may stop watching a task, removing someone else requires the editor role
+func TaskUnwatchHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerDeleteLogger, r.URL.Path, r.Method)
+
+	task, ok := FindTask(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	userID, ok := helpers.GetObjectIdVar(w, r, "userId", handlerLogger)
+	if !ok || !authorizeSelfOrEditor(w, r, userID, handlerLogger) {
+		return
+	}
+
+	savePeople(w, r, task, auth.StoreFor(r, store).Tasks.RemovePerson, dao.Watchers, userID, "User is not watching this task", handlerLogger)
+}
+
+// MyTasksHandler -> Handler for My Tasks Endpoint, lists tasks assigned to the current user on boards it still is a member of
+func MyTasksHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerListLogger, r.URL.Path, r.Method)
+	user, _ := auth.UserFromContext(r.Context())
+
+	member, ok := memberBoards(w, user.UserId, handlerLogger)
+	if !ok {
+		return
+	}
+
+	assigned, err := store.Tasks.FindByAssignee(user.UserId)
+	if err != nil {
+		handlerLogger.Errorf("Could not retrieve tasks assigned to user %s, got error: %s", user.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	tasks := make([]models.Task, 0, len(assigned))
+	for _, task := range assigned {
+		if member[task.BoardId] {
+			tasks = append(tasks, task)
+		}
+	}
+
+	if err := dao.FillCommentCounts(store.Comments, tasks); err != nil {
+		handlerLogger.Errorf("Could not count comments of tasks, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	helpers.RespondWithJson(w, http.StatusOK, tasks)
+}
@@ -0,0 +1,120 @@
+package tasks
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// MaxTitleFilterLength -> Longest title substring tasks can be filtered by, titles being at most 200 characters
+const MaxTitleFilterLength = 200
+
+// taskSortFields -> Accepted values of query parameter sort, prefixed with "-" for a descending sort
+var taskSortFields = map[string]bool{
+	dao.TaskSortOrder:     true,
+	dao.TaskSortTitle:     true,
+	dao.TaskSortPoints:    true,
+	dao.TaskSortCreatedAt: true,
+}
+
+// parsePointsParam -> Value of a points query parameter, nil when absent, respond with an error if it is not a number
+func parsePointsParam(w http.ResponseWriter, r *http.Request, name string, handlerLogger *log.Entry) (*float64, bool) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return nil, true
+	}
+
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		handlerLogger.Warnf("User provided invalid %s: %s", name, param)
+		helpers.RespondWithError(w, http.StatusBadRequest, name+" must be a number")
+		return nil, false
+	}
+	return &value, true
+}
+
+// parseTaskQuery -> Query on tasks of given list from query parameters sort, status, pointsMin, pointsMax and title,
+// respond with an error if one of them is not valid
+func parseTaskQuery(w http.ResponseWriter, r *http.Request, listID bson.ObjectId, handlerLogger *log.Entry) (dao.TaskQuery, bool) {
+	params := r.URL.Query()
+	query := dao.TaskQuery{ListID: listID, Sort: dao.TaskSortOrder, Title: params.Get("title")}
+
+	if sort := params.Get("sort"); sort != "" {
+		query.Sort, query.Descending = strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+		if !taskSortFields[query.Sort] {
+			handlerLogger.Warnf("User provided invalid sort: %s", sort)
+			helpers.RespondWithError(w, http.StatusBadRequest, "sort must be one of order, title, points, createdAt, optionally prefixed with -")
+			return query, false
+		}
+	}
+
+	if status := params.Get("status"); status != "" {
+		value, err := strconv.ParseBool(status)
+		if err != nil {
+			handlerLogger.Warnf("User provided invalid status: %s", status)
+			helpers.RespondWithError(w, http.StatusBadRequest, "status must be a boolean")
+			return query, false
+		}
+		query.Status = &value
+	}
+
+	var ok bool
+	if query.PointsMin, ok = parsePointsParam(w, r, "pointsMin", handlerLogger); !ok {
+		return query, false
+	}
+	if query.PointsMax, ok = parsePointsParam(w, r, "pointsMax", handlerLogger); !ok {
+		return query, false
+	}
+	if query.PointsMin != nil && query.PointsMax != nil && *query.PointsMin > *query.PointsMax {
+		handlerLogger.Warnf("User provided pointsMin %v greater than pointsMax %v", *query.PointsMin, *query.PointsMax)
+		helpers.RespondWithError(w, http.StatusBadRequest, "pointsMin can not be greater than pointsMax")
+		return query, false
+	}
+
+	if len(query.Title) > MaxTitleFilterLength {
+		handlerLogger.Warn("User provided a title filter longer than a title")
+		helpers.RespondWithError(w, http.StatusBadRequest, "title must be at most "+strconv.Itoa(MaxTitleFilterLength)+" characters")
+		return query, false
+	}
+	return query, true
+}
+
+// respondWithTaskPage -> Respond with a page (query parameters limit and offset) of the tasks selected by given query,
+// along with their number of comments, the total number of selected tasks and the offset of the next page
+func respondWithTaskPage(w http.ResponseWriter, r *http.Request, query dao.TaskQuery, handlerLogger *log.Entry) {
+	page, ok := helpers.GetPage(w, r, handlerLogger)
+	if !ok {
+		return
+	}
+
+	tasks, err := store.Tasks.Find(query, page)
+	if err != nil {
+		handlerLogger.Errorf("Could not connect to DB to retrieve Tasks, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	total, err := store.Tasks.Count(query)
+	if err != nil {
+		handlerLogger.Errorf("Could not count tasks, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	if err := dao.FillCommentCounts(store.Comments, tasks); err != nil {
+		handlerLogger.Errorf("Could not count comments of tasks, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	response := TaskPageApiResponse{Items: tasks, Total: total, Limit: page.Limit, Offset: page.Offset}
+	if next := page.Offset + len(tasks); next < total {
+		response.NextOffset = &next
+	}
+	helpers.RespondWithJson(w, http.StatusOK, response)
+}
@@ -1,11 +1,17 @@
 package tasks
 
 import (
+	"github.com/AmFlint/taco-api-go/dao"
 	"github.com/gorilla/mux"
 )
 
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
 // Initialize Routes for Task Resource
-func InitRoutes(taskRouter *mux.Router) {
+func InitRoutes(taskRouter *mux.Router, s dao.Store) {
+	store = s
+
 	// ---- Task Listing ---- //
 	taskRouter.HandleFunc("", TaskIndexHandler).Methods("GET")
 	taskRouter.HandleFunc("/", TaskIndexHandler).Methods("GET")
@@ -21,4 +27,50 @@ func InitRoutes(taskRouter *mux.Router) {
 	// ---- Task Update ---- //
 	taskRouter.HandleFunc("/{taskId}", TaskUpdateHandler).Methods("PATCH")
 	taskRouter.HandleFunc("/{taskId}/", TaskUpdateHandler).Methods("PATCH")
+	// ---- Task Move (other list and/or position) ---- //
+	taskRouter.HandleFunc("/{taskId}/move", TaskMoveHandler).Methods("POST")
+	taskRouter.HandleFunc("/{taskId}/move/", TaskMoveHandler).Methods("POST")
+	// ---- Task Assignees ---- //
+	taskRouter.HandleFunc("/{taskId}/assignees", TaskAssignHandler).Methods("POST")
+	taskRouter.HandleFunc("/{taskId}/assignees/", TaskAssignHandler).Methods("POST")
+	taskRouter.HandleFunc("/{taskId}/assignees/{userId}", TaskUnassignHandler).Methods("DELETE")
+	taskRouter.HandleFunc("/{taskId}/assignees/{userId}/", TaskUnassignHandler).Methods("DELETE")
+	// ---- Task Watchers ---- //
+	taskRouter.HandleFunc("/{taskId}/watchers", TaskWatchHandler).Methods("POST")
+	taskRouter.HandleFunc("/{taskId}/watchers/", TaskWatchHandler).Methods("POST")
+	taskRouter.HandleFunc("/{taskId}/watchers/{userId}", TaskUnwatchHandler).Methods("DELETE")
+	taskRouter.HandleFunc("/{taskId}/watchers/{userId}/", TaskUnwatchHandler).Methods("DELETE")
+}
+
+// Initialize Routes of the current user's tasks
+func InitUserRoutes(meRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Tasks assigned to current user ---- //
+	meRouter.HandleFunc("/tasks", MyTasksHandler).Methods("GET")
+	meRouter.HandleFunc("/tasks/", MyTasksHandler).Methods("GET")
+	// ---- Overdue tasks assigned to current user ---- //
+	meRouter.HandleFunc("/tasks/overdue", MyOverdueHandler).Methods("GET")
+	meRouter.HandleFunc("/tasks/overdue/", MyOverdueHandler).Methods("GET")
+	// ---- Tasks assigned to current user due within the next days ---- //
+	meRouter.HandleFunc("/tasks/due", MyDueHandler).Methods("GET")
+	meRouter.HandleFunc("/tasks/due/", MyDueHandler).Methods("GET")
+}
+
+// Initialize Routes of the tasks of a whole board, across its lists
+func InitBoardRoutes(boardTaskRouter *mux.Router, s dao.Store) {
+	store = s
+
+	// ---- Tasks of the board selected by a filter expression ---- //
+	boardTaskRouter.HandleFunc("", BoardFilterHandler).Methods("GET")
+	boardTaskRouter.HandleFunc("/", BoardFilterHandler).Methods("GET")
+	// ---- Bulk operation on tasks of the board ---- //
+	boardTaskRouter.HandleFunc("/bulk", BulkTaskHandler).Methods("POST")
+	boardTaskRouter.HandleFunc("/bulk/", BulkTaskHandler).Methods("POST")
+	// ---- Overdue tasks of the board ---- //
+	boardTaskRouter.HandleFunc("/overdue", BoardOverdueHandler).Methods("GET")
+	boardTaskRouter.HandleFunc("/overdue/", BoardOverdueHandler).Methods("GET")
+	// ---- Tasks of the board due within the next days ---- //
+	boardTaskRouter.HandleFunc("/due", BoardDueHandler).Methods("GET")
+	boardTaskRouter.HandleFunc("/due/", BoardDueHandler).Methods("GET")
 }
\ No newline at end of file
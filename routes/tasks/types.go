@@ -1,5 +1,10 @@
 package tasks
 
+import (
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
 // Save Tasks Handlers custom Structures
 type Task struct {
 	Title string `json:"title"`
@@ -16,4 +21,57 @@ type ErrorResponse struct {
 type ErrorsResponse struct {
 	Code int `json:"code"`
 	Messages []string `json:"messages"`
-}
\ No newline at end of file
+}
+// TaskMove -> Request body of the Move endpoint: target list (defaults to current one) and position (defaults to last)
+type TaskMove struct {
+	ListId bson.ObjectId `json:"listId"`
+	Order  int           `json:"order" onCreate:"min=0"`
+}
+
+// TaskPerson -> Request body of the assign and watch endpoints, userId defaults to the current user
+type TaskPerson struct {
+	UserId bson.ObjectId `json:"userId"`
+}
+
+// TaskPageApiResponse -> Response of the task index: a page of tasks, the total number of matching tasks,
+// and the offset of the next page (null on the last page)
+type TaskPageApiResponse struct {
+	Items      []models.Task `json:"items"`
+	Total      int           `json:"total"`
+	Limit      int           `json:"limit"`
+	Offset     int           `json:"offset"`
+	NextOffset *int          `json:"nextOffset"`
+}
+
+// Operations of the bulk endpoint
+const (
+	BulkSetStatus = "setStatus"
+	BulkSetPoints = "setPoints"
+	BulkMove      = "move"
+	BulkDelete    = "delete"
+)
+
+// TaskBulk -> Request body of the bulk endpoint: operation applied to every task (at most 100), along with its
+// argument: status for setStatus, points for setPoints, target listId for move
+type TaskBulk struct {
+	TaskIds   []bson.ObjectId `json:"taskIds" onCreate:"min=1,max=100"`
+	Operation string          `json:"operation" onCreate:"nonzero,regexp=^(setStatus|setPoints|move|delete)$"`
+	Status    *bool           `json:"status"`
+	Points    *float64        `json:"points"`
+	ListId    bson.ObjectId   `json:"listId"`
+}
+
+// TaskBulkResult -> Outcome of a bulk operation on one of its tasks: HTTP status, along with the error or the task
+type TaskBulkResult struct {
+	TaskId bson.ObjectId `json:"taskId"`
+	Status int           `json:"status"`
+	Error  string        `json:"error,omitempty"`
+	Task   *models.Task  `json:"task,omitempty"`
+}
+
+// TaskBulkApiResponse -> Response of the bulk endpoint: whether the operation was applied, and the result of each task
+type TaskBulkApiResponse struct {
+	Operation string           `json:"operation"`
+	Applied   bool             `json:"applied"`
+	Results   []TaskBulkResult `json:"results"`
+}
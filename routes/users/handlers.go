@@ -0,0 +1,131 @@
+package users
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/AmFlint/taco-api-go/audit"
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/constants"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/helpers/logger"
+	"github.com/AmFlint/taco-api-go/models"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/mgo.v2/bson"
+)
+
+var userLogger *log.Entry
+
+func init() {
+	userLogger = log.WithField(constants.ResourceKeyLogger, constants.ResourceUsersLogger)
+}
+
+// respondWithToken -> Issue a token for given user and respond with it
+func respondWithToken(w http.ResponseWriter, code int, user models.User, handlerLogger *log.Entry) {
+	token, expiresAt, err := tokens.Issue(user.UserId)
+	if err != nil {
+		handlerLogger.Errorf("Could not sign token for user %s, got error: %s", user.UserId.Hex(), err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not issue token")
+		return
+	}
+
+	helpers.RespondWithJson(w, code, TokenApiResponse{Token: token, ExpiresAt: expiresAt, User: user})
+}
+
+// recordAudit -> Audit an action of given user on its own account, the request making it is not authenticated yet
+func recordAudit(r *http.Request, user models.User, action string, after models.Snapshot, handlerLogger *log.Entry) {
+	r = r.WithContext(auth.WithPrincipal(r.Context(), auth.Principal{User: user}))
+	audit.Record(r, store, "", action, models.EntityUser, user.UserId, nil, after, handlerLogger)
+}
+
+// RegisterHandler -> Handler for User Registration Endpoint, responds with a token for the new user
+func RegisterHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerRegisterLogger, r.URL.Path, r.Method)
+	var registration Registration
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&registration); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if errs := helpers.Validate(registration, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on registration, got error: %s", errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return
+	}
+
+	user := models.NewUser()
+	user.Email, user.Name = models.NormalizeEmail(registration.Email), registration.Name
+	if errs := helpers.Validate(user, "onCreate"); errs != nil {
+		handlerLogger.Warnf("Validation failed on registration, got error: %s", errs.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, errs.Error())
+		return
+	}
+
+	if err := user.SetPassword(registration.Password); err != nil {
+		handlerLogger.Errorf("Could not hash password, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Internal Server Error")
+		return
+	}
+
+	user.UserId = bson.NewObjectId()
+	err := store.Users.Insert(&user)
+	if err == dao.ErrDuplicate {
+		handlerLogger.Warnf("Email %s is already registered", user.Email)
+		helpers.RespondWithError(w, http.StatusConflict, "Email already registered")
+		return
+	}
+	if err != nil {
+		handlerLogger.Errorf("Could not insert user, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+	recordAudit(r, user, models.VerbCreated, models.NewSnapshot(user), handlerLogger)
+
+	respondWithToken(w, http.StatusCreated, user, handlerLogger)
+}
+
+// LoginHandler -> Handler for User Login Endpoint, exchanges email/password for a token
+func LoginHandler(w http.ResponseWriter, r *http.Request) {
+	handlerLogger := logger.GenerateLogger(constants.HandlerLoginLogger, r.URL.Path, r.Method)
+	var credentials Credentials
+
+	// Make sure that request body is not empty
+	if r.Body == nil {
+		handlerLogger.Warn("Empty Request Body")
+		helpers.RespondWithError(w, http.StatusBadRequest, "Empty Request Body")
+		return
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		handlerLogger.Warnf("User sent data with wrong format, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	user, err := store.Users.FindByEmail(models.NormalizeEmail(credentials.Email))
+	if err != nil && err != dao.ErrNotFound {
+		handlerLogger.Errorf("Could not retrieve user, got error: %s", err.Error())
+		helpers.RespondWithError(w, http.StatusInternalServerError, "Could not reach Database")
+		return
+	}
+
+	// Same response for unknown email and wrong password, not to disclose registered emails
+	if err != nil || !user.CheckPassword(credentials.Password) {
+		handlerLogger.Warn("Login failed, invalid email or password")
+		helpers.RespondWithError(w, http.StatusUnauthorized, "Invalid email or password")
+		return
+	}
+	recordAudit(r, user, models.VerbLoggedIn, nil, handlerLogger)
+
+	respondWithToken(w, http.StatusOK, user, handlerLogger)
+}
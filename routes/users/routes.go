@@ -0,0 +1,26 @@
+package users
+
+import (
+	"github.com/AmFlint/taco-api-go/auth"
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/gorilla/mux"
+)
+
+// Persistence layer used by handlers, injected by InitRoutes
+var store dao.Store
+
+// Token issuer used on registration/login, injected by InitRoutes
+var tokens *auth.Tokens
+
+// Initialize Routes for User accounts (public endpoints)
+func InitRoutes(authRouter *mux.Router, s dao.Store, t *auth.Tokens) {
+	store = s
+	tokens = t
+
+	// ---- User Registration ---- //
+	authRouter.HandleFunc("/register", RegisterHandler).Methods("POST")
+	authRouter.HandleFunc("/register/", RegisterHandler).Methods("POST")
+	// ---- User Login ---- //
+	authRouter.HandleFunc("/login", LoginHandler).Methods("POST")
+	authRouter.HandleFunc("/login/", LoginHandler).Methods("POST")
+}
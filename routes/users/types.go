@@ -0,0 +1,27 @@
+package users
+
+import (
+	"time"
+
+	"github.com/AmFlint/taco-api-go/models"
+)
+
+// Registration -> Request body of registration endpoint. bcrypt only uses the first 72 bytes of a password
+type Registration struct {
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password" onCreate:"min=8,max=72"`
+}
+
+// Credentials -> Request body of login endpoint
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// TokenApiResponse -> Bearer token issued on registration/login, along with the authenticated user
+type TokenApiResponse struct {
+	Token     string      `json:"token"`
+	ExpiresAt time.Time   `json:"expiresAt"`
+	User      models.User `json:"user"`
+}
@@ -0,0 +1,205 @@
+package taskfilter
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/dao"
+	"github.com/AmFlint/taco-api-go/models"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Fields of filter clauses
+const (
+	FieldStatus = "status"
+	FieldPoints = "points"
+	FieldLabel  = "label"
+	FieldDue    = "due"
+	FieldTitle  = "title"
+	FieldSort   = "sort"
+)
+
+// Longest filter expression, and largest number of clauses
+const (
+	MaxExpressionLength = 500
+	MaxClauses          = 20
+)
+
+// DueNone -> Value of clause due:none, selecting tasks without due date
+const DueNone = "none"
+
+// sortFields -> Accepted values of clause sort, prefixed with "-" for a descending sort
+var sortFields = map[string]bool{
+	dao.TaskSortOrder:     true,
+	dao.TaskSortTitle:     true,
+	dao.TaskSortPoints:    true,
+	dao.TaskSortCreatedAt: true,
+}
+
+// relativeDate -> Due date relative to now, e.g. 7d, 12h, 2w or -1d
+var relativeDate = regexp.MustCompile(`^([+-]?\d{1,4})([hdw])$`)
+
+var relativeUnits = map[string]time.Duration{"h": time.Hour, "d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+
+// expect -> Error unless the clause uses one of given operators
+func (c Clause) expect(operators ...string) error {
+	for _, operator := range operators {
+		if c.Operator == operator {
+			return nil
+		}
+	}
+	return c.errorf("%s only supports %s", c.Field, strings.Join(operators, " "))
+}
+
+// parseDate -> Date of a due clause: relative to now (7d), a day (2024-06-14, midnight UTC) or a time (RFC 3339)
+func parseDate(c Clause, now time.Time) (time.Time, error) {
+	if match := relativeDate.FindStringSubmatch(c.Value); match != nil {
+		amount, _ := strconv.Atoi(match[1])
+		return now.Add(time.Duration(amount) * relativeUnits[match[2]]), nil
+	}
+	if date, err := time.Parse("2006-01-02", c.Value); err == nil {
+		return date, nil
+	}
+	if date, err := time.Parse(time.RFC3339, c.Value); err == nil {
+		return date.UTC(), nil
+	}
+	return time.Time{}, c.errorf("due date %q must be relative (7d, 12h, 2w), a day (2006-01-02) or a time (RFC 3339)", c.Value)
+}
+
+// raise -> Restrict a lower bound to given value, when it is tighter
+func raise(bound **float64, exclusive *bool, value float64, strict bool) {
+	if *bound == nil || value > **bound || (value == **bound && strict) {
+		*bound, *exclusive = &value, strict
+	}
+}
+
+// lower -> Restrict an upper bound to given value, when it is tighter
+func lower(bound **float64, exclusive *bool, value float64, strict bool) {
+	if *bound == nil || value < **bound || (value == **bound && strict) {
+		*bound, *exclusive = &value, strict
+	}
+}
+
+// compilePoints -> Restrict points of the query following a points clause
+func compilePoints(c Clause, query *dao.TaskQuery) error {
+	value, err := strconv.ParseFloat(c.Value, 64)
+	if err != nil {
+		return c.errorf("points %q must be a number", c.Value)
+	}
+
+	switch c.Operator {
+	case ":", "=":
+		raise(&query.PointsMin, &query.PointsMinExclusive, value, false)
+		lower(&query.PointsMax, &query.PointsMaxExclusive, value, false)
+	case ">", ">=":
+		raise(&query.PointsMin, &query.PointsMinExclusive, value, c.Operator == ">")
+	case "<", "<=":
+		lower(&query.PointsMax, &query.PointsMaxExclusive, value, c.Operator == "<")
+	}
+	return nil
+}
+
+// compileDue -> Restrict due dates of the query following a due clause
+func compileDue(c Clause, query *dao.TaskQuery, now time.Time) error {
+	if c.Operator == ":" || c.Operator == "=" {
+		if !strings.EqualFold(c.Value, DueNone) {
+			return c.errorf("due only supports :none, < and >")
+		}
+		query.NoDueDate = true
+		return nil
+	}
+	if err := c.expect(":", "<", ">"); err != nil {
+		return err
+	}
+
+	date, err := parseDate(c, now)
+	if err != nil {
+		return err
+	}
+	if c.Operator == ">" && (query.DueAfter == nil || date.After(*query.DueAfter)) {
+		query.DueAfter = &date
+	}
+	if c.Operator == "<" && (query.DueBefore == nil || date.Before(*query.DueBefore)) {
+		query.DueBefore = &date
+	}
+	return nil
+}
+
+// compileLabel -> Restrict the query to tasks holding the label named by a label clause (case insensitive)
+func compileLabel(c Clause, query *dao.TaskQuery, labels []models.Label) error {
+	for _, label := range labels {
+		if strings.EqualFold(label.Name, c.Value) {
+			if !dao.HasID(query.Labels, label.LabelId) {
+				query.Labels = append(query.Labels, label.LabelId)
+			}
+			return nil
+		}
+	}
+	return c.errorf("label %q does not exist on this board", c.Value)
+}
+
+// Compile -> Query on the tasks of a board equivalent to given expression, whose clauses must all match, e.g.
+// status:false points>5 label:bug due<7d. Label names are resolved among given labels of the board, relative due dates
+// are relative to now. Bare words (and title clauses) are searched, joined by spaces, in task titles
+func Compile(expression string, boardID bson.ObjectId, labels []models.Label, now time.Time) (dao.TaskQuery, error) {
+	query := dao.TaskQuery{BoardID: boardID, Sort: dao.TaskSortOrder}
+	if len(expression) > MaxExpressionLength {
+		return query, &Error{Position: MaxExpressionLength + 1, Message: "expression is longer than " + strconv.Itoa(MaxExpressionLength) + " characters"}
+	}
+
+	clauses, err := Parse(expression)
+	if err != nil {
+		return query, err
+	}
+	if len(clauses) > MaxClauses {
+		return query, clauses[MaxClauses].errorf("expression has more than %d clauses", MaxClauses)
+	}
+
+	words := []string{}
+	for _, c := range clauses {
+		c.Field = strings.ToLower(c.Field)
+		switch c.Field {
+		case "":
+			words = append(words, c.Value)
+		case FieldTitle:
+			err = c.expect(":", "=")
+			words = append(words, c.Value)
+		case FieldStatus:
+			if err = c.expect(":", "="); err == nil {
+				status, parseErr := strconv.ParseBool(c.Value)
+				if parseErr != nil {
+					err = c.errorf("status %q must be true or false", c.Value)
+				}
+				query.Status = &status
+			}
+		case FieldPoints:
+			err = compilePoints(c, &query)
+		case FieldDue:
+			err = compileDue(c, &query, now)
+		case FieldLabel:
+			if err = c.expect(":", "="); err == nil {
+				err = compileLabel(c, &query, labels)
+			}
+		case FieldSort:
+			if err = c.expect(":", "="); err == nil {
+				query.Sort, query.Descending = strings.TrimPrefix(c.Value, "-"), strings.HasPrefix(c.Value, "-")
+				if !sortFields[query.Sort] {
+					err = c.errorf("sort %q must be one of order, title, points, createdAt, optionally prefixed with -", c.Value)
+				}
+			}
+		default:
+			err = c.errorf("unknown field %q, expected one of status, points, label, due, title, sort", c.Field)
+		}
+		if err != nil {
+			return query, err
+		}
+	}
+
+	if query.NoDueDate && (query.DueAfter != nil || query.DueBefore != nil) {
+		return query, &Error{Position: 1, Message: "due:none can not be combined with due dates"}
+	}
+	query.Title = strings.Join(words, " ")
+	return query, nil
+}
@@ -0,0 +1,122 @@
+package taskfilter
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// Operators of a clause, longest first so that >= is not read as >
+var operators = []string{">=", "<=", ":", "=", ">", "<"}
+
+// Error -> Invalid filter expression, Position is the character (starting at 1) where the faulty clause starts
+type Error struct {
+	Position int
+	Message  string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (at character %d)", e.Message, e.Position)
+}
+
+// Clause -> Condition of an expression, e.g. points>5. Bare words have no field nor operator
+type Clause struct {
+	Field    string
+	Operator string
+	Value    string
+	// Character (starting at 1) where the clause starts
+	Position int
+}
+
+func (c Clause) errorf(format string, args ...interface{}) *Error {
+	return &Error{Position: c.Position, Message: fmt.Sprintf(format, args...)}
+}
+
+// scanner -> Reads clauses from the runes of an expression
+type scanner struct {
+	runes    []rune
+	position int
+}
+
+func (s *scanner) done() bool {
+	return s.position >= len(s.runes)
+}
+
+func (s *scanner) skipSpaces() {
+	for !s.done() && unicode.IsSpace(s.runes[s.position]) {
+		s.position++
+	}
+}
+
+// operator -> Operator at current position, if any, which is consumed
+func (s *scanner) operator() string {
+	for _, operator := range operators {
+		end := s.position + len(operator)
+		if end <= len(s.runes) && string(s.runes[s.position:end]) == operator {
+			s.position = end
+			return operator
+		}
+	}
+	return ""
+}
+
+// value -> Word until the next space, or text between double quotes
+func (s *scanner) value(start int) (string, error) {
+	if s.done() || s.runes[s.position] != '"' {
+		begin := s.position
+		for !s.done() && !unicode.IsSpace(s.runes[s.position]) {
+			s.position++
+		}
+		return string(s.runes[begin:s.position]), nil
+	}
+
+	s.position++
+	begin := s.position
+	for !s.done() && s.runes[s.position] != '"' {
+		s.position++
+	}
+	if s.done() {
+		return "", &Error{Position: start + 1, Message: "unterminated quote"}
+	}
+	s.position++
+	return string(s.runes[begin : s.position-1]), nil
+}
+
+// clause -> Next clause: a field (letters) followed by an operator and a value, or a bare (possibly quoted) word
+func (s *scanner) clause() (Clause, error) {
+	start := s.position
+	for !s.done() && unicode.IsLetter(s.runes[s.position]) {
+		s.position++
+	}
+
+	if field := string(s.runes[start:s.position]); field != "" {
+		if operator := s.operator(); operator != "" {
+			value, err := s.value(start)
+			if err != nil {
+				return Clause{}, err
+			}
+			if value == "" {
+				return Clause{}, &Error{Position: start + 1, Message: "missing value after " + field + operator}
+			}
+			return Clause{Field: field, Operator: operator, Value: value, Position: start + 1}, nil
+		}
+	}
+
+	// Not a field: read the whole word again
+	s.position = start
+	value, err := s.value(start)
+	return Clause{Value: value, Position: start + 1}, err
+}
+
+// Parse -> Clauses of given expression, separated by spaces
+func Parse(expression string) ([]Clause, error) {
+	s := &scanner{runes: []rune(expression)}
+	clauses := []Clause{}
+	for s.skipSpaces(); !s.done(); s.skipSpaces() {
+		clause, err := s.clause()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+	return clauses, nil
+}
@@ -0,0 +1,139 @@
+package activity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/activity"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName = "Busy board"
+	testingListName  = "Busy list"
+)
+
+func getBoardURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/", boardID.Hex())
+}
+
+func getListURL(boardID, listID bson.ObjectId) string {
+	return fmt.Sprintf("%slists/%s/", getBoardURL(boardID), listID.Hex())
+}
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("%stasks/%s/", getListURL(boardID, listID), taskID.Hex())
+}
+
+// execute -> Execute request with given JSON body, decode the response into v
+func execute(t *testing.T, method, url string, body interface{}, expectedCode int, v interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	if err := json.Unmarshal(response.Body.Bytes(), v); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+}
+
+// getActivity -> Retrieve a page of the activity feed at given URL
+func getActivity(t *testing.T, url string) activity.ActivityApiResponse {
+	var response activity.ActivityApiResponse
+	execute(t, "GET", url, nil, http.StatusOK, &response)
+	return response
+}
+
+// describe -> Verb and entity type of each activity, e.g. "created task"
+func describe(activities []models.Activity) []string {
+	descriptions := []string{}
+	for _, a := range activities {
+		descriptions = append(descriptions, a.Verb+" "+a.EntityType)
+	}
+	return descriptions
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+func TestActivity(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	boardActivityURL := getBoardURL(boardID) + "activity"
+
+	var list, otherList models.List
+	var task models.Task
+
+	t.Run("Empty board activity", func(t *testing.T) {
+		response := getActivity(t, boardActivityURL)
+		utils.AssertIntEqualsTo(t, len(response.Activities), 0)
+		utils.AssertIntEqualsTo(t, response.Total, 0)
+	})
+
+	t.Run("Changes are recorded newest first", func(t *testing.T) {
+		execute(t, "POST", getBoardURL(boardID)+"lists/", map[string]interface{}{"name": testingListName}, http.StatusCreated, &list)
+		execute(t, "POST", getBoardURL(boardID)+"lists/", map[string]interface{}{"name": testingListName}, http.StatusCreated, &otherList)
+		execute(t, "POST", getListURL(boardID, list.ListId)+"tasks/", map[string]interface{}{"title": "Write docs"}, http.StatusCreated, &task)
+		execute(t, "PATCH", getTaskURL(boardID, list.ListId, task.TaskId), map[string]interface{}{"title": "Write more docs"}, http.StatusOK, &task)
+		execute(t, "POST", getTaskURL(boardID, list.ListId, task.TaskId)+"move", map[string]interface{}{"listId": otherList.ListId, "order": 1}, http.StatusOK, &task)
+
+		response := getActivity(t, boardActivityURL)
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(response.Activities)), "[moved task updated task created task created list created list]")
+		utils.AssertIntEqualsTo(t, response.Total, 5)
+
+		created := response.Activities[4]
+		utils.AssertStringEqualsTo(t, created.EntityId.Hex(), list.ListId.Hex())
+		utils.AssertNotEmpty(t, created.ActorId)
+		utils.AssertBoolEqualsTo(t, created.Before == nil, true)
+		utils.AssertStringEqualsTo(t, fmt.Sprint(created.After["name"]), testingListName)
+	})
+
+	t.Run("Updates only record changed fields", func(t *testing.T) {
+		updated := getActivity(t, boardActivityURL).Activities[1]
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(updated.Before), "map[title:Write docs]")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(updated.After), "map[title:Write more docs]")
+	})
+
+	t.Run("Updates without changes are not recorded", func(t *testing.T) {
+		execute(t, "PATCH", getTaskURL(boardID, otherList.ListId, task.TaskId), map[string]interface{}{"title": task.Title}, http.StatusOK, &task)
+
+		utils.AssertIntEqualsTo(t, getActivity(t, boardActivityURL).Total, 5)
+	})
+
+	t.Run("Paginate board activity", func(t *testing.T) {
+		response := getActivity(t, boardActivityURL+"?limit=2&offset=1")
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(response.Activities)), "[updated task created task]")
+		utils.AssertIntEqualsTo(t, response.Total, 5)
+		utils.AssertIntEqualsTo(t, response.Limit, 2)
+		utils.AssertIntEqualsTo(t, response.Offset, 1)
+	})
+
+	t.Run("Task activity", func(t *testing.T) {
+		var deleted models.List
+		execute(t, "DELETE", getListURL(boardID, list.ListId), nil, http.StatusOK, &deleted)
+
+		response := getActivity(t, getTaskURL(boardID, otherList.ListId, task.TaskId)+"activity")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(response.Activities)), "[moved task updated task created task]")
+		utils.AssertIntEqualsTo(t, response.Total, 3)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(getActivity(t, boardActivityURL+"?limit=1").Activities)), "[deleted list]")
+	})
+
+	t.Run("Task activity of an unknown task", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getTaskURL(boardID, otherList.ListId, bson.NewObjectId())+"activity", nil)
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,203 @@
+package apikeys
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/routes/apikeys"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	apiKeysURL       = "/me/api-keys/"
+	boardsURL        = "/boards/"
+	testingKeyName   = "Deployment script"
+	testingBoardName = "Board created with an API key"
+)
+
+func getApiKeyURL(apiKeyID bson.ObjectId) string {
+	return fmt.Sprintf("%s%s/", apiKeysURL, apiKeyID.Hex())
+}
+
+func getApiKeyCreate(scope string, expiresAt *time.Time) []byte {
+	apiKey := make(map[string]interface{})
+	apiKey["name"] = testingKeyName
+	if scope != "" {
+		apiKey["scope"] = scope
+	}
+	if expiresAt != nil {
+		apiKey["expiresAt"] = expiresAt.Format(time.RFC3339)
+	}
+	return helpers.JsonEncode(apiKey)
+}
+
+func getValidBoard() []byte {
+	board := make(map[string]interface{})
+	board["name"] = testingBoardName
+	return helpers.JsonEncode(board)
+}
+
+// executeWithKey -> Execute request authenticated with given API key instead of the test program's user
+func executeWithKey(key string, req *http.Request) int {
+	req.Header.Set("Authorization", "ApiKey "+key)
+	return utils.ExecuteRequest(req).Code
+}
+
+// generateApiKey -> Create an API key of given scope for the test program's user
+func generateApiKey(t *testing.T, scope string) apikeys.CreatedApiKey {
+	req, _ := http.NewRequest("POST", apiKeysURL, bytes.NewReader(getApiKeyCreate(scope, nil)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+	var created apikeys.CreatedApiKey
+	if err := json.Unmarshal(response.Body.Bytes(), &created); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return created
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+// ---- Test Create Endpoint ---- //
+func TestCreateApiKeyEndpoint(t *testing.T) {
+	t.Run("Create an API key with default scope", func(t *testing.T) {
+		created := generateApiKey(t, "")
+
+		utils.AssertStringEqualsTo(t, created.Name, testingKeyName)
+		utils.AssertStringEqualsTo(t, created.Scope, "write")
+		utils.AssertNotEmpty(t, created.Key)
+		utils.AssertStringEqualsTo(t, created.Prefix, created.Key[:len(created.Prefix)])
+	})
+
+	t.Run("Create an API key with an unknown scope", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", apiKeysURL, bytes.NewReader(getApiKeyCreate("admin", nil)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Create an API key expiring in the past", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+		req, _ := http.NewRequest("POST", apiKeysURL, bytes.NewReader(getApiKeyCreate("", &expiresAt)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Create an API key while anonymous", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", apiKeysURL, bytes.NewReader(getApiKeyCreate("", nil)))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Create an API key with an API key", func(t *testing.T) {
+		created := generateApiKey(t, "write")
+		req, _ := http.NewRequest("POST", apiKeysURL, bytes.NewReader(getApiKeyCreate("", nil)))
+
+		utils.CheckResponseCode(t, executeWithKey(created.Key, req), http.StatusForbidden)
+	})
+}
+
+// ---- Test Authentication with API keys ---- //
+func TestApiKeyAuthentication(t *testing.T) {
+	writeKey := generateApiKey(t, "write")
+	readKey := generateApiKey(t, "read")
+
+	t.Run("Write key can create a board", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", boardsURL, bytes.NewReader(getValidBoard()))
+
+		utils.CheckResponseCode(t, executeWithKey(writeKey.Key, req), http.StatusCreated)
+	})
+
+	t.Run("Read key can list boards", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", boardsURL, nil)
+
+		utils.CheckResponseCode(t, executeWithKey(readKey.Key, req), http.StatusOK)
+	})
+
+	t.Run("Read key can not create a board", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", boardsURL, bytes.NewReader(getValidBoard()))
+
+		utils.CheckResponseCode(t, executeWithKey(readKey.Key, req), http.StatusForbidden)
+	})
+
+	t.Run("Unknown key is rejected", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", boardsURL, nil)
+
+		utils.CheckResponseCode(t, executeWithKey("taco_unknown", req), http.StatusUnauthorized)
+	})
+
+	t.Run("Last use of a key is recorded", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", apiKeysURL, nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var res apikeys.ApiKeyApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		found := false
+		for _, apiKey := range res.ApiKeys {
+			if apiKey.ApiKeyId == readKey.ApiKeyId {
+				found = true
+				utils.AssertBoolEqualsTo(t, apiKey.LastUsedAt != nil, true)
+			}
+		}
+		utils.AssertBoolEqualsTo(t, found, true)
+	})
+
+	t.Run("Listed keys do not expose their hash", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", apiKeysURL, nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.AssertBoolEqualsTo(t, bytes.Contains(response.Body.Bytes(), []byte(`"hash"`)), false)
+		utils.AssertBoolEqualsTo(t, bytes.Contains(response.Body.Bytes(), []byte(writeKey.Key)), false)
+	})
+}
+
+// ---- Test Delete Endpoint ---- //
+func TestDeleteApiKeyEndpoint(t *testing.T) {
+	created := generateApiKey(t, "write")
+
+	t.Run("Revoke an existing API key", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getApiKeyURL(created.ApiKeyId), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+	})
+
+	t.Run("Revoked key is rejected", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", boardsURL, nil)
+
+		utils.CheckResponseCode(t, executeWithKey(created.Key, req), http.StatusUnauthorized)
+	})
+
+	t.Run("Revoke a non existing API key", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getApiKeyURL(bson.NewObjectId()), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Revoke an API key with invalid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", apiKeysURL+"2/", nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,225 @@
+package assignees
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/config"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName = "Assigned board"
+	testingListName  = "Assigned list"
+	testingTaskTitle = "Assigned task"
+	myTasksURL       = "/me/tasks/"
+)
+
+func getMembersURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/members/", boardID.Hex())
+}
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/%s/tasks/%s/", boardID.Hex(), listID.Hex(), taskID.Hex())
+}
+
+func getAssigneeURL(boardID, listID, taskID, userID bson.ObjectId) string {
+	return fmt.Sprintf("%sassignees/%s/", getTaskURL(boardID, listID, taskID), userID.Hex())
+}
+
+func getWatcherURL(boardID, listID, taskID, userID bson.ObjectId) string {
+	return fmt.Sprintf("%swatchers/%s/", getTaskURL(boardID, listID, taskID), userID.Hex())
+}
+
+// execute -> Execute request with given JSON body, authenticated with given token (test user when empty), return the task of the response
+func execute(t *testing.T, token, method, url string, body map[string]interface{}, expectedCode int) models.Task {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	var task models.Task
+	if expectedCode < http.StatusBadRequest {
+		if err := json.Unmarshal(response.Body.Bytes(), &task); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+	}
+	return task
+}
+
+// addMember -> Register a new user and add it to the board with given role, return its ID and token
+func addMember(t *testing.T, boardID bson.ObjectId, role string) (bson.ObjectId, string) {
+	user, token := generator.GenerateUser(t)
+
+	body := map[string]interface{}{"email": user.Email, "role": role}
+	req, _ := http.NewRequest("POST", getMembersURL(boardID), bytes.NewReader(helpers.JsonEncode(body)))
+	utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusCreated)
+
+	return user.UserId, token
+}
+
+// getMyTasks -> Retrieve tasks assigned to the user authenticated with given token
+func getMyTasks(t *testing.T, token string) []models.Task {
+	req, _ := http.NewRequest("GET", myTasksURL, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+	var tasks []models.Task
+	if err := json.Unmarshal(response.Body.Bytes(), &tasks); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return tasks
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+func TestAssignees(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+	taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: testingTaskTitle})
+	assignees := getTaskURL(boardID, listID, taskID) + "assignees/"
+
+	editorID, _ := addMember(t, boardID, models.RoleEditor)
+	viewerID, viewerToken := addMember(t, boardID, models.RoleViewer)
+	stranger, _ := generator.GenerateUser(t)
+
+	t.Run("New tasks have no assignees", func(t *testing.T) {
+		task := execute(t, "", "GET", getTaskURL(boardID, listID, taskID), nil, http.StatusOK)
+
+		utils.AssertIntEqualsTo(t, len(task.Assignees), 0)
+		utils.AssertIntEqualsTo(t, len(task.Watchers), 0)
+	})
+
+	t.Run("Assign board members", func(t *testing.T) {
+		execute(t, "", "POST", assignees, map[string]interface{}{"userId": editorID}, http.StatusOK)
+		task := execute(t, "", "POST", assignees, map[string]interface{}{"userId": viewerID}, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(task.Assignees), fmt.Sprint([]bson.ObjectId{editorID, viewerID}))
+	})
+
+	t.Run("Assigning twice is a no-op", func(t *testing.T) {
+		task := execute(t, "", "POST", assignees, map[string]interface{}{"userId": editorID}, http.StatusOK)
+
+		utils.AssertIntEqualsTo(t, len(task.Assignees), 2)
+	})
+
+	t.Run("Assign a user who is not a member of the board", func(t *testing.T) {
+		execute(t, "", "POST", assignees, map[string]interface{}{"userId": stranger.UserId}, http.StatusBadRequest)
+	})
+
+	t.Run("Viewers can not assign", func(t *testing.T) {
+		execute(t, viewerToken, "POST", assignees, map[string]interface{}{"userId": viewerID}, http.StatusForbidden)
+	})
+
+	t.Run("Tasks assigned to current user", func(t *testing.T) {
+		tasks := getMyTasks(t, viewerToken)
+
+		utils.AssertIntEqualsTo(t, len(tasks), 1)
+		utils.AssertStringEqualsTo(t, tasks[0].TaskId.Hex(), taskID.Hex())
+	})
+
+	t.Run("Unassign a user", func(t *testing.T) {
+		task := execute(t, "", "DELETE", getAssigneeURL(boardID, listID, taskID, editorID), nil, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(task.Assignees), fmt.Sprint([]bson.ObjectId{viewerID}))
+		execute(t, "", "DELETE", getAssigneeURL(boardID, listID, taskID, editorID), nil, http.StatusNotFound)
+	})
+
+	t.Run("Assignees are kept on task update", func(t *testing.T) {
+		task := execute(t, "", "PATCH", getTaskURL(boardID, listID, taskID), map[string]interface{}{"title": "Renamed task"}, http.StatusOK)
+
+		utils.AssertIntEqualsTo(t, len(task.Assignees), 1)
+	})
+
+	t.Run("Removed members are unassigned", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", fmt.Sprintf("%s%s/", getMembersURL(boardID), viewerID.Hex()), nil)
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusOK)
+
+		task := execute(t, "", "GET", getTaskURL(boardID, listID, taskID), nil, http.StatusOK)
+		utils.AssertIntEqualsTo(t, len(task.Assignees), 0)
+		utils.AssertIntEqualsTo(t, len(getMyTasks(t, viewerToken)), 0)
+	})
+
+	t.Run("Concurrent assignments are all kept", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			memberID, _ := addMember(t, boardID, models.RoleEditor)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				execute(t, "", "POST", assignees, map[string]interface{}{"userId": memberID}, http.StatusOK)
+			}()
+		}
+		wg.Wait()
+
+		task := execute(t, "", "GET", getTaskURL(boardID, listID, taskID), nil, http.StatusOK)
+		utils.AssertIntEqualsTo(t, len(task.Assignees), 5)
+	})
+
+	t.Run("Updating a task read before an assignment keeps it assigned", func(t *testing.T) {
+		stale, err := config.GetApp().Store.Tasks.FindByListAndID(listID, taskID)
+		if err != nil {
+			t.Fatalf("[Error], Could not retrieve task: %s", err.Error())
+		}
+		execute(t, "", "POST", assignees, map[string]interface{}{"userId": editorID}, http.StatusOK)
+
+		stale.Title = "Stale task"
+		if err := config.GetApp().Store.Tasks.Update(&stale); err != nil {
+			t.Fatalf("[Error], Could not update task: %s", err.Error())
+		}
+		task := execute(t, "", "GET", getTaskURL(boardID, listID, taskID), nil, http.StatusOK)
+		utils.AssertStringEqualsTo(t, task.Title, "Stale task")
+		utils.AssertIntEqualsTo(t, len(task.Assignees), 6)
+	})
+}
+
+func TestWatchers(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+	taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: testingTaskTitle})
+	watchers := getTaskURL(boardID, listID, taskID) + "watchers/"
+
+	editorID, _ := addMember(t, boardID, models.RoleEditor)
+	viewerID, viewerToken := addMember(t, boardID, models.RoleViewer)
+
+	t.Run("Viewers can watch a task themselves", func(t *testing.T) {
+		task := execute(t, viewerToken, "POST", watchers, nil, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(task.Watchers), fmt.Sprint([]bson.ObjectId{viewerID}))
+	})
+
+	t.Run("Viewers can not make someone else watch a task", func(t *testing.T) {
+		execute(t, viewerToken, "POST", watchers, map[string]interface{}{"userId": editorID}, http.StatusForbidden)
+	})
+
+	t.Run("Editors can make members watch a task", func(t *testing.T) {
+		task := execute(t, "", "POST", watchers, map[string]interface{}{"userId": editorID}, http.StatusOK)
+
+		utils.AssertIntEqualsTo(t, len(task.Watchers), 2)
+	})
+
+	t.Run("Viewers can stop watching a task", func(t *testing.T) {
+		execute(t, viewerToken, "DELETE", getWatcherURL(boardID, listID, taskID, editorID), nil, http.StatusForbidden)
+		task := execute(t, viewerToken, "DELETE", getWatcherURL(boardID, listID, taskID, viewerID), nil, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(task.Watchers), fmt.Sprint([]bson.ObjectId{editorID}))
+	})
+}
@@ -0,0 +1,203 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/config"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/admin"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName = "Audited board"
+	testingListName  = "Audited list"
+	auditURL         = "/admin/audit"
+)
+
+// ID of the administrator of this test program, see APP_ADMIN_USER_IDS
+var adminID = bson.NewObjectId()
+
+func getBoardURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/", boardID.Hex())
+}
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("%slists/%s/tasks/%s/", getBoardURL(boardID), listID.Hex(), taskID.Hex())
+}
+
+// execute -> Execute request with given JSON body, authenticated with given token (test user when empty)
+func execute(t *testing.T, token, method, url string, body interface{}, expectedCode int) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+	return response
+}
+
+// createAdmin -> Create the administrator of this test program with its configured ID, return its token
+func createAdmin(t *testing.T) string {
+	admin := models.NewUser()
+	admin.UserId, admin.Email, admin.Name = adminID, fmt.Sprintf("auditor-%s@taco.test", adminID.Hex()), "Auditor"
+	admin.SetPassword("auditor-password")
+	if err := config.GetApp().Store.Users.Insert(&admin); err != nil {
+		t.Fatalf("Could not create administrator, got error: %s", err.Error())
+	}
+
+	credentials := map[string]interface{}{"email": admin.Email, "password": "auditor-password"}
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewReader(helpers.JsonEncode(credentials)))
+	response := utils.ExecuteAnonymousRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+	var loggedIn struct {
+		Token string `json:"token"`
+	}
+	json.Unmarshal(response.Body.Bytes(), &loggedIn)
+	return loggedIn.Token
+}
+
+// getAudit -> Retrieve audit entries matching given query string, as the administrator
+func getAudit(t *testing.T, adminToken, query string) admin.AuditApiResponse {
+	var response admin.AuditApiResponse
+	if err := json.Unmarshal(execute(t, adminToken, "GET", auditURL+"?"+query, nil, http.StatusOK).Body.Bytes(), &response); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return response
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	os.Setenv("APP_ADMIN_USER_IDS", bson.NewObjectId().Hex()+", "+adminID.Hex())
+	testconfig.Init(m)
+}
+
+func TestAuditLog(t *testing.T) {
+	adminToken := createAdmin(t)
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+	taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "Audit me", Points: 1})
+
+	editor, editorToken := generator.GenerateUser(t)
+	execute(t, "", "POST", getBoardURL(boardID)+"members/", map[string]interface{}{"email": editor.Email, "role": models.RoleEditor}, http.StatusCreated)
+
+	t.Run("Audit log is restricted to administrators", func(t *testing.T) {
+		execute(t, "", "GET", auditURL, nil, http.StatusForbidden)
+		execute(t, editorToken, "GET", auditURL, nil, http.StatusForbidden)
+	})
+
+	t.Run("Responses carry a request ID", func(t *testing.T) {
+		response := execute(t, "", "GET", getBoardURL(boardID), nil, http.StatusOK)
+		utils.AssertNotEmpty(t, response.Header().Get("X-Request-Id"))
+	})
+
+	t.Run("Mutations are recorded with full snapshots", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getTaskURL(boardID, listID, taskID), bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"points": 5})))
+		req.Header.Set("Authorization", "Bearer "+editorToken)
+		req.Header.Set("X-Request-Id", "sprint-planning-42")
+		req.RemoteAddr = "203.0.113.7:52100"
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+		utils.AssertStringEqualsTo(t, response.Header().Get("X-Request-Id"), "sprint-planning-42")
+
+		entries := getAudit(t, adminToken, "entityType=task&entityId="+taskID.Hex())
+		utils.AssertIntEqualsTo(t, entries.Total, 2)
+
+		updated, created := entries.Entries[0], entries.Entries[1]
+		utils.AssertStringEqualsTo(t, updated.Action, models.VerbUpdated)
+		utils.AssertStringEqualsTo(t, updated.ActorId.Hex(), editor.UserId.Hex())
+		utils.AssertStringEqualsTo(t, updated.BoardId.Hex(), boardID.Hex())
+		utils.AssertStringEqualsTo(t, updated.RequestId, "sprint-planning-42")
+		utils.AssertStringEqualsTo(t, updated.ClientIP, "203.0.113.7")
+		utils.AssertFloatEqualsTo(t, updated.Before["points"].(float64), 1)
+		utils.AssertFloatEqualsTo(t, updated.After["points"].(float64), 5)
+		utils.AssertStringEqualsTo(t, fmt.Sprint(updated.Before["title"]), "Audit me")
+		utils.AssertBoolEqualsTo(t, contains(updated.ChangedFields, "points"), true)
+		utils.AssertBoolEqualsTo(t, contains(updated.ChangedFields, "title"), false)
+
+		utils.AssertStringEqualsTo(t, created.Action, models.VerbCreated)
+		utils.AssertBoolEqualsTo(t, created.Before == nil, true)
+		utils.AssertStringEqualsTo(t, fmt.Sprint(created.After["title"]), "Audit me")
+	})
+
+	t.Run("Checklist changes of a commented task only record checklists", func(t *testing.T) {
+		execute(t, "", "POST", getTaskURL(boardID, listID, taskID)+"comments/", map[string]interface{}{"body": "Looks good"}, http.StatusCreated)
+		execute(t, editorToken, "POST", getTaskURL(boardID, listID, taskID)+"checklists/", map[string]interface{}{"name": "Steps"}, http.StatusCreated)
+
+		entries := getAudit(t, adminToken, "entityType=task&entityId="+taskID.Hex())
+		utils.AssertIntEqualsTo(t, entries.Total, 3)
+		updated := entries.Entries[0]
+		utils.AssertBoolEqualsTo(t, contains(updated.ChangedFields, "checklists"), true)
+		utils.AssertBoolEqualsTo(t, contains(updated.ChangedFields, "commentCount"), false)
+		utils.AssertFloatEqualsTo(t, updated.Before["commentCount"].(float64), 1)
+	})
+
+	t.Run("Deletions are recorded and kept", func(t *testing.T) {
+		execute(t, "", "DELETE", getBoardURL(boardID), nil, http.StatusOK)
+
+		entries := getAudit(t, adminToken, "entityId="+boardID.Hex())
+		utils.AssertIntEqualsTo(t, entries.Total, 2)
+		utils.AssertStringEqualsTo(t, entries.Entries[0].Action, models.VerbDeleted)
+		utils.AssertBoolEqualsTo(t, entries.Entries[0].After == nil, true)
+		utils.AssertStringEqualsTo(t, fmt.Sprint(entries.Entries[0].Before["name"]), testingBoardName)
+
+		utils.AssertIntEqualsTo(t, getAudit(t, adminToken, "entityId="+taskID.Hex()).Total, 3)
+	})
+
+	t.Run("Registrations and logins are recorded", func(t *testing.T) {
+		registrations := getAudit(t, adminToken, "entityType=user&entityId="+editor.UserId.Hex())
+		utils.AssertIntEqualsTo(t, registrations.Total, 1)
+		utils.AssertStringEqualsTo(t, registrations.Entries[0].Action, models.VerbCreated)
+		utils.AssertStringEqualsTo(t, registrations.Entries[0].ActorId.Hex(), editor.UserId.Hex())
+		utils.AssertStringEqualsTo(t, fmt.Sprint(registrations.Entries[0].After["email"]), editor.Email)
+
+		logins := getAudit(t, adminToken, "entityType=user&entityId="+adminID.Hex())
+		utils.AssertIntEqualsTo(t, logins.Total, 1)
+		utils.AssertStringEqualsTo(t, logins.Entries[0].Action, models.VerbLoggedIn)
+		utils.AssertStringEqualsTo(t, logins.Entries[0].ActorId.Hex(), adminID.Hex())
+	})
+
+	t.Run("Filter by actor and time range", func(t *testing.T) {
+		// Registration of the editor, then its updates of the task
+		utils.AssertIntEqualsTo(t, getAudit(t, adminToken, "actorId="+editor.UserId.Hex()).Total, 3)
+
+		future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+		past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+		utils.AssertIntEqualsTo(t, getAudit(t, adminToken, "from="+future).Total, 0)
+		utils.AssertIntEqualsTo(t, getAudit(t, adminToken, "to="+past).Total, 0)
+		utils.AssertIntEqualsTo(t, getAudit(t, adminToken, "actorId="+editor.UserId.Hex()+"&from="+past+"&to="+future).Total, 3)
+
+		page := getAudit(t, adminToken, "limit=2&offset=1")
+		utils.AssertIntEqualsTo(t, len(page.Entries), 2)
+		utils.AssertIntEqualsTo(t, page.Offset, 1)
+	})
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		execute(t, adminToken, "GET", auditURL+"?actorId=someone", nil, http.StatusBadRequest)
+		execute(t, adminToken, "GET", auditURL+"?from=yesterday", nil, http.StatusBadRequest)
+	})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
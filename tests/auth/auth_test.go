@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/routes/users"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingUserName     = "Taco Tester"
+	testingUserPassword = "super-secret-password"
+)
+
+func getRegisterUrl() string {
+	return "/auth/register"
+}
+
+func getLoginUrl() string {
+	return "/auth/login"
+}
+
+// Each test registers its own email, so that tests do not depend on each other
+func getUniqueEmail() string {
+	return fmt.Sprintf("user-%s@taco.test", bson.NewObjectId().Hex())
+}
+
+func getRegistration(email, password string) []byte {
+	registration := make(map[string]interface{})
+	registration["email"] = email
+	registration["name"] = testingUserName
+	registration["password"] = password
+	return helpers.JsonEncode(registration)
+}
+
+func getCredentials(email, password string) []byte {
+	credentials := make(map[string]interface{})
+	credentials["email"] = email
+	credentials["password"] = password
+	return helpers.JsonEncode(credentials)
+}
+
+// register a user and return the token issued for it
+func register(t *testing.T, email string) string {
+	req, _ := http.NewRequest("POST", getRegisterUrl(), bytes.NewReader(getRegistration(email, testingUserPassword)))
+	response := utils.ExecuteAnonymousRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+	var res users.TokenApiResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return res.Token
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+// ---- Test Register Endpoint ---- //
+func TestRegisterEndpoint(t *testing.T) {
+	t.Run("Register with valid informations", func(t *testing.T) {
+		email := getUniqueEmail()
+		req, _ := http.NewRequest("POST", getRegisterUrl(), bytes.NewReader(getRegistration(email, testingUserPassword)))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+		var res users.TokenApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertStringEqualsTo(t, res.User.Email, email)
+		utils.AssertStringEqualsTo(t, res.User.Name, testingUserName)
+		utils.AssertNotEmpty(t, res.User.UserId)
+		utils.AssertNotEmpty(t, res.Token)
+
+		// Password hash must never be exposed
+		var raw map[string]map[string]interface{}
+		json.Unmarshal(response.Body.Bytes(), &raw)
+		if _, ok := raw["user"]["passwordHash"]; ok {
+			t.Errorf("[Error], Expected password hash not to be part of the response")
+		}
+	})
+
+	t.Run("Register with already registered email", func(t *testing.T) {
+		email := getUniqueEmail()
+		register(t, email)
+
+		req, _ := http.NewRequest("POST", getRegisterUrl(), bytes.NewReader(getRegistration(email, testingUserPassword)))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusConflict)
+	})
+
+	t.Run("Register with invalid email", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getRegisterUrl(), bytes.NewReader(getRegistration("not-an-email", testingUserPassword)))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Register with too short password", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getRegisterUrl(), bytes.NewReader(getRegistration(getUniqueEmail(), "short")))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
+
+// ---- Test Login Endpoint ---- //
+func TestLoginEndpoint(t *testing.T) {
+	email := getUniqueEmail()
+	register(t, email)
+
+	t.Run("Login with valid credentials", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getLoginUrl(), bytes.NewReader(getCredentials(email, testingUserPassword)))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var res users.TokenApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertStringEqualsTo(t, res.User.Email, email)
+		utils.AssertNotEmpty(t, res.Token)
+	})
+
+	t.Run("Login with wrong password", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getLoginUrl(), bytes.NewReader(getCredentials(email, "wrong-password")))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Login with unknown email", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getLoginUrl(), bytes.NewReader(getCredentials(getUniqueEmail(), testingUserPassword)))
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusUnauthorized)
+	})
+}
+
+// ---- Test Authentication Middleware ---- //
+func TestProtectedEndpoints(t *testing.T) {
+	t.Run("Access boards without token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/boards/", nil)
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Access boards with invalid token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/boards/", nil)
+		req.Header.Set("Authorization", "Bearer not.a.token")
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusUnauthorized)
+	})
+
+	t.Run("Access boards with issued token", func(t *testing.T) {
+		token := register(t, getUniqueEmail())
+
+		req, _ := http.NewRequest("GET", "/boards/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+	})
+
+	t.Run("Access health check without token", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/health", nil)
+		response := utils.ExecuteAnonymousRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+	})
+}
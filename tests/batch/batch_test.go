@@ -0,0 +1,242 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/config"
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/apikeys"
+	"github.com/AmFlint/taco-api-go/routes/batch"
+	"github.com/AmFlint/taco-api-go/routes/lists"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const batchURL = "/batch"
+
+// execute -> Execute request with given JSON body, authenticated with given token (test user when empty), decode the response into v
+func execute(t *testing.T, token, method, url string, body interface{}, expectedCode int, v interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	if v != nil {
+		if err := json.Unmarshal(response.Body.Bytes(), v); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+	}
+}
+
+// runBatch -> Send given requests in a batch (with given query string), as the user of given token (test user when empty)
+func runBatch(t *testing.T, token, query string, requests []batch.SubRequest) []batch.SubResponse {
+	var responses []batch.SubResponse
+	execute(t, token, "POST", batchURL+"?"+query, requests, http.StatusOK, &responses)
+	if len(responses) != len(requests) {
+		t.Fatalf("[Error], Expected %d responses, got %d", len(requests), len(responses))
+	}
+	return responses
+}
+
+// statuses -> Status of each response of a batch
+func statuses(responses []batch.SubResponse) string {
+	codes := []int{}
+	for _, response := range responses {
+		codes = append(codes, response.Status)
+	}
+	return fmt.Sprint(codes)
+}
+
+// createList -> Request of a batch creating a list with given name
+func createList(boardID bson.ObjectId, name string) batch.SubRequest {
+	return batch.SubRequest{Method: "POST", Path: fmt.Sprintf("/boards/%s/lists/", boardID.Hex()), Body: helpers.JsonEncode(map[string]interface{}{"name": name})}
+}
+
+// listNames -> Names of the lists of a board
+func listNames(t *testing.T, boardID bson.ObjectId) string {
+	var response lists.ListApiResponse
+	execute(t, "", "GET", fmt.Sprintf("/boards/%s/lists/", boardID.Hex()), nil, http.StatusOK, &response)
+	names := []string{}
+	for _, list := range response.Lists {
+		names = append(names, list.Name)
+	}
+	return fmt.Sprint(names)
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+func TestBatch(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: "Batched board"})
+
+	t.Run("Requests run in order", func(t *testing.T) {
+		responses := runBatch(t, "", "", []batch.SubRequest{
+			createList(boardID, "Todo"),
+			{Method: "GET", Path: fmt.Sprintf("/boards/%s/lists?unused=1", boardID.Hex())},
+			{Method: "PATCH", Path: fmt.Sprintf("/boards/%s", boardID.Hex()), Body: json.RawMessage(`{"name": "Renamed board"}`)},
+			{Method: "GET", Path: fmt.Sprintf("/boards/%s/", bson.NewObjectId().Hex())},
+			createList(boardID, "Done"),
+		})
+		utils.AssertStringEqualsTo(t, statuses(responses), "[201 200 200 404 201]")
+
+		var list models.List
+		if err := json.Unmarshal(responses[0].Body, &list); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertStringEqualsTo(t, list.Name, "Todo")
+
+		var index lists.ListApiResponse
+		if err := json.Unmarshal(responses[1].Body, &index); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertIntEqualsTo(t, len(index.Lists), 1)
+		utils.AssertStringEqualsTo(t, listNames(t, boardID), "[Todo Done]")
+	})
+
+	t.Run("Responses which are not JSON are returned as strings", func(t *testing.T) {
+		responses := runBatch(t, "", "", []batch.SubRequest{{Method: "GET", Path: "/nowhere"}})
+		utils.AssertIntEqualsTo(t, responses[0].Status, http.StatusNotFound)
+
+		var text string
+		if err := json.Unmarshal(responses[0].Body, &text); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertNotEmpty(t, text)
+	})
+
+	t.Run("Requests run as the user sending the batch", func(t *testing.T) {
+		_, otherToken := generator.GenerateUser(t)
+		responses := runBatch(t, otherToken, "", []batch.SubRequest{
+			{Method: "GET", Path: fmt.Sprintf("/boards/%s", boardID.Hex())},
+			createList(boardID, "Intruder"),
+		})
+		utils.AssertStringEqualsTo(t, statuses(responses), "[404 404]")
+	})
+
+	t.Run("A panicking request is answered with a 500", func(t *testing.T) {
+		config.GetApp().Router.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) { panic("unexpected") })
+		responses := runBatch(t, "", "", []batch.SubRequest{
+			{Method: "GET", Path: "/panic"},
+			{Method: "GET", Path: "/health"},
+		})
+		utils.AssertStringEqualsTo(t, statuses(responses), "[500 200]")
+	})
+
+	t.Run("All or nothing applies every request", func(t *testing.T) {
+		if config.GetApp().Store.Transactor == nil {
+			execute(t, "", "POST", batchURL+"?allOrNothing=true", []batch.SubRequest{createList(boardID, "Review")}, http.StatusBadRequest, nil)
+			t.Skip("Storage does not support transactions")
+		}
+
+		responses := runBatch(t, "", "allOrNothing=true", []batch.SubRequest{
+			createList(boardID, "Review"),
+			createList(boardID, "Archive"),
+		})
+		utils.AssertStringEqualsTo(t, statuses(responses), "[201 201]")
+		utils.AssertStringEqualsTo(t, listNames(t, boardID), "[Todo Done Review Archive]")
+	})
+
+	t.Run("All or nothing rolls back every request when one fails", func(t *testing.T) {
+		if config.GetApp().Store.Transactor == nil {
+			t.Skip("Storage does not support transactions")
+		}
+
+		responses := runBatch(t, "", "allOrNothing=true", []batch.SubRequest{
+			createList(boardID, "Backlog"),
+			{Method: "PATCH", Path: fmt.Sprintf("/boards/%s", boardID.Hex()), Body: json.RawMessage(`{"name": "Rolled back board"}`)},
+			{Method: "GET", Path: "/panic"},
+			createList(boardID, "Icebox"),
+		})
+		utils.AssertStringEqualsTo(t, statuses(responses), "[424 424 500 424]")
+		utils.AssertStringEqualsTo(t, listNames(t, boardID), "[Todo Done Review Archive]")
+
+		var board models.Board
+		execute(t, "", "GET", fmt.Sprintf("/boards/%s", boardID.Hex()), nil, http.StatusOK, &board)
+		utils.AssertStringEqualsTo(t, board.Name, "Renamed board")
+
+		responses = runBatch(t, "", "allOrNothing=true", []batch.SubRequest{
+			createList(boardID, "Backlog"),
+			createList(boardID, ""),
+		})
+		utils.AssertStringEqualsTo(t, statuses(responses), "[424 400]")
+		utils.AssertStringEqualsTo(t, listNames(t, boardID), "[Todo Done Review Archive]")
+	})
+
+	t.Run("All or nothing runs nothing unless every request is routable", func(t *testing.T) {
+		if config.GetApp().Store.Transactor == nil {
+			t.Skip("Storage does not support transactions")
+		}
+
+		responses := runBatch(t, "", "allOrNothing=true", []batch.SubRequest{
+			createList(boardID, "Backlog"),
+			{Method: "DELETE", Path: "/health"},
+			{Method: "GET", Path: "/nowhere"},
+		})
+		utils.AssertStringEqualsTo(t, statuses(responses), "[424 405 424]")
+		utils.AssertStringEqualsTo(t, listNames(t, boardID), "[Todo Done Review Archive]")
+	})
+
+	t.Run("Read-only API keys only run safe requests of a batch", func(t *testing.T) {
+		names := listNames(t, boardID)
+		var key apikeys.CreatedApiKey
+		execute(t, "", "POST", "/me/api-keys", map[string]interface{}{"name": "Batch reader", "scope": models.ScopeRead}, http.StatusCreated, &key)
+
+		requests := []batch.SubRequest{
+			{Method: "GET", Path: fmt.Sprintf("/boards/%s/lists", boardID.Hex())},
+			createList(boardID, "Read-only"),
+			{Method: "GET", Path: "/health"},
+		}
+		req, _ := http.NewRequest("POST", batchURL, bytes.NewReader(helpers.JsonEncode(requests)))
+		req.Header.Set("Authorization", "ApiKey "+key.Key)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var responses []batch.SubResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &responses); err != nil {
+			t.Fatalf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertStringEqualsTo(t, statuses(responses), "[200 403 200]")
+		utils.AssertStringEqualsTo(t, listNames(t, boardID), names)
+	})
+
+	t.Run("Invalid batches", func(t *testing.T) {
+		tooMany := make([]batch.SubRequest, batch.MaxBatchRequests+1)
+		for i := range tooMany {
+			tooMany[i] = batch.SubRequest{Method: "GET", Path: "/health"}
+		}
+		batches := [][]batch.SubRequest{
+			{},
+			tooMany,
+			{{Method: "TRACE", Path: "/health"}},
+			{{Method: "GET", Path: "health"}},
+			{{Method: "GET"}},
+			{{Method: "POST", Path: "/batch/"}},
+			{{Method: "POST", Path: "/boards/../batch"}},
+		}
+		for _, requests := range batches {
+			execute(t, "", "POST", batchURL, requests, http.StatusBadRequest, nil)
+		}
+		execute(t, "", "POST", batchURL+"?allOrNothing=maybe", []batch.SubRequest{{Method: "GET", Path: "/health"}}, http.StatusBadRequest, nil)
+		execute(t, "", "POST", batchURL, map[string]interface{}{"method": "GET", "path": "/health"}, http.StatusBadRequest, nil)
+	})
+
+	t.Run("Anonymous batches are rejected", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", batchURL, bytes.NewReader(helpers.JsonEncode([]batch.SubRequest{{Method: "GET", Path: "/health"}})))
+		response := utils.ExecuteAnonymousRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusUnauthorized)
+	})
+}
@@ -0,0 +1,261 @@
+package boards
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/boards"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName        = "Testing board"
+	testingBoardDescription = "Testing board description"
+	updatedBoardName        = "Updated board"
+	// data creation
+	genBoardForViewName   = "about to be viewed"
+	genBoardForUpdateName = "about to be updated"
+	genBoardForUpdateDesc = "description kept after update"
+	genBoardForDeleteName = "about to be deleted"
+	genListName           = "Board content"
+)
+
+func getBoardsBaseUrl() string {
+	return "/boards/"
+}
+
+func getBoardURL(boardId bson.ObjectId) string {
+	return fmt.Sprintf("%s%s/", getBoardsBaseUrl(), boardId.Hex())
+}
+
+func getInvalidBoardURL() string {
+	return fmt.Sprintf("%s%s/", getBoardsBaseUrl(), "2")
+}
+
+func getValidBoard() []byte {
+	board := make(map[string]interface{})
+	board["name"] = testingBoardName
+	board["description"] = testingBoardDescription
+	return helpers.JsonEncode(board)
+}
+
+func getInvalidBoardEmptyName() []byte {
+	board := make(map[string]interface{})
+	board["name"] = ""
+	return helpers.JsonEncode(board)
+}
+
+func getInvalidBoardNameType() []byte {
+	board := make(map[string]interface{})
+	board["name"] = 12
+	return helpers.JsonEncode(board)
+}
+
+func getValidBoardUpdate() []byte {
+	board := make(map[string]interface{})
+	board["name"] = updatedBoardName
+	return helpers.JsonEncode(board)
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+// ---- Test Create Endpoint ---- //
+func TestCreateBoardEndpoint(t *testing.T) {
+	t.Run("Create Board with valid informations", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getBoardsBaseUrl(), bytes.NewReader(getValidBoard()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+		var createdBoard models.Board
+		if err := json.Unmarshal(response.Body.Bytes(), &createdBoard); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertStringEqualsTo(t, createdBoard.Name, testingBoardName)
+		utils.AssertStringEqualsTo(t, createdBoard.Description, testingBoardDescription)
+		utils.AssertNotEmpty(t, createdBoard.BoardId)
+		utils.AssertNotEmpty(t, createdBoard.CreatedAt)
+	})
+
+	t.Run("Create Board with empty name", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getBoardsBaseUrl(), bytes.NewReader(getInvalidBoardEmptyName()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Create Board with invalid name type", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getBoardsBaseUrl(), bytes.NewReader(getInvalidBoardNameType()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Create Board with empty request body", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getBoardsBaseUrl(), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
+
+// ---- Test Index Endpoint ---- //
+func TestIndexBoardEndpoint(t *testing.T) {
+	testedBoardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: genBoardForViewName})
+
+	req, _ := http.NewRequest("GET", getBoardsBaseUrl(), nil)
+	response := utils.ExecuteRequest(req)
+
+	utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+	var res boards.BoardApiResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+
+	found := false
+	for _, board := range res.Boards {
+		if board.BoardId == testedBoardID {
+			found = true
+		}
+	}
+	utils.AssertBoolEqualsTo(t, found, true)
+}
+
+// ---- Test View Endpoint ---- //
+func TestViewBoardEndpoint(t *testing.T) {
+	testedBoardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: genBoardForViewName})
+
+	t.Run("View an existing board with valid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getBoardURL(testedBoardID), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var board models.Board
+		if err := json.Unmarshal(response.Body.Bytes(), &board); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertStringEqualsTo(t, board.Name, genBoardForViewName)
+	})
+
+	t.Run("View a non existing board with valid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getBoardURL(bson.NewObjectId()), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("View a board with invalid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getInvalidBoardURL(), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
+
+// ---- Test Update Endpoint ---- //
+func TestUpdateBoardEndpoint(t *testing.T) {
+	testedBoardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: genBoardForUpdateName, Description: genBoardForUpdateDesc})
+
+	t.Run("Update a board with valid informations", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getBoardURL(testedBoardID), bytes.NewReader(getValidBoardUpdate()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var board models.Board
+		if err := json.Unmarshal(response.Body.Bytes(), &board); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertStringEqualsTo(t, board.Name, updatedBoardName)
+		utils.AssertStringEqualsTo(t, board.Description, genBoardForUpdateDesc)
+	})
+
+	t.Run("Update a board with empty name", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getBoardURL(testedBoardID), bytes.NewReader(getInvalidBoardEmptyName()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Update a board with invalid name type", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getBoardURL(testedBoardID), bytes.NewReader(getInvalidBoardNameType()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Update a non existing board", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getBoardURL(bson.NewObjectId()), bytes.NewReader(getValidBoardUpdate()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Update a board with invalid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getInvalidBoardURL(), bytes.NewReader(getValidBoardUpdate()))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
+
+// ---- Test Delete Endpoint ---- //
+func TestDeleteBoardEndpoint(t *testing.T) {
+	testedBoardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: genBoardForDeleteName})
+	testedListID := generator.GenerateListAndGetID(t, testedBoardID, &models.List{Name: genListName})
+
+	t.Run("Delete an existing board with valid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getBoardURL(testedBoardID), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var board models.Board
+		if err := json.Unmarshal(response.Body.Bytes(), &board); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertStringEqualsTo(t, board.Name, genBoardForDeleteName)
+	})
+
+	t.Run("Lists of a deleted board are deleted", func(t *testing.T) {
+		listURL := fmt.Sprintf("%slists/%s", getBoardURL(testedBoardID), testedListID.Hex())
+		req, _ := http.NewRequest("GET", listURL, nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Delete a non existing board", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getBoardURL(bson.NewObjectId()), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Delete a board with invalid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getInvalidBoardURL(), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,190 @@
+package checklists
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName     = "Checked board"
+	testingListName      = "Checked list"
+	testingTaskTitle     = "Checked task"
+	testingChecklistName = "Release"
+	updatedChecklistName = "Release steps"
+)
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/%s/tasks/%s/", boardID.Hex(), listID.Hex(), taskID.Hex())
+}
+
+func getChecklistsURL(boardID, listID, taskID bson.ObjectId) string {
+	return getTaskURL(boardID, listID, taskID) + "checklists/"
+}
+
+func getChecklistURL(boardID, listID, taskID, checklistID bson.ObjectId) string {
+	return fmt.Sprintf("%s%s/", getChecklistsURL(boardID, listID, taskID), checklistID.Hex())
+}
+
+func getItemURL(boardID, listID, taskID, checklistID, itemID bson.ObjectId) string {
+	return fmt.Sprintf("%sitems/%s/", getChecklistURL(boardID, listID, taskID, checklistID), itemID.Hex())
+}
+
+// execute -> Execute request with given JSON body, return the task of the response
+func execute(t *testing.T, method, url string, body map[string]interface{}, expectedCode int) models.Task {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	var task models.Task
+	if expectedCode < http.StatusBadRequest {
+		if err := json.Unmarshal(response.Body.Bytes(), &task); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+	}
+	return task
+}
+
+func itemTexts(checklist models.Checklist) []string {
+	texts := []string{}
+	for _, item := range checklist.Items {
+		texts = append(texts, item.Text)
+	}
+	return texts
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+func TestChecklists(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+	taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: testingTaskTitle})
+
+	var checklistID bson.ObjectId
+	var itemIDs []bson.ObjectId
+
+	t.Run("Create a checklist", func(t *testing.T) {
+		task := execute(t, "POST", getChecklistsURL(boardID, listID, taskID), map[string]interface{}{"name": testingChecklistName}, http.StatusCreated)
+
+		utils.AssertIntEqualsTo(t, len(task.Checklists), 1)
+		utils.AssertStringEqualsTo(t, task.Checklists[0].Name, testingChecklistName)
+		checklistID = task.Checklists[0].ChecklistId
+	})
+
+	t.Run("Create a checklist with empty name", func(t *testing.T) {
+		execute(t, "POST", getChecklistsURL(boardID, listID, taskID), map[string]interface{}{"name": ""}, http.StatusBadRequest)
+	})
+
+	t.Run("Rename a checklist", func(t *testing.T) {
+		task := execute(t, "PATCH", getChecklistURL(boardID, listID, taskID, checklistID), map[string]interface{}{"name": updatedChecklistName}, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, task.Checklists[0].Name, updatedChecklistName)
+	})
+
+	t.Run("Add items to a checklist", func(t *testing.T) {
+		var task models.Task
+		for _, text := range []string{"build", "test", "deploy"} {
+			task = execute(t, "POST", getChecklistURL(boardID, listID, taskID, checklistID)+"items/", map[string]interface{}{"text": text}, http.StatusCreated)
+		}
+
+		for _, item := range task.Checklists[0].Items {
+			itemIDs = append(itemIDs, item.ItemId)
+		}
+		utils.AssertIntEqualsTo(t, len(itemIDs), 3)
+		utils.AssertIntEqualsTo(t, task.Progress.Total, 3)
+		utils.AssertIntEqualsTo(t, task.Progress.Checked, 0)
+	})
+
+	t.Run("Check items", func(t *testing.T) {
+		execute(t, "PATCH", getItemURL(boardID, listID, taskID, checklistID, itemIDs[0]), map[string]interface{}{"checked": true}, http.StatusOK)
+		task := execute(t, "PATCH", getItemURL(boardID, listID, taskID, checklistID, itemIDs[1]), map[string]interface{}{"checked": true}, http.StatusOK)
+
+		utils.AssertIntEqualsTo(t, task.Progress.Checked, 2)
+		utils.AssertIntEqualsTo(t, task.Checklists[0].Progress.Checked, 2)
+		utils.AssertIntEqualsTo(t, task.Checklists[0].Progress.Total, 3)
+	})
+
+	t.Run("Move an item", func(t *testing.T) {
+		task := execute(t, "POST", getItemURL(boardID, listID, taskID, checklistID, itemIDs[2])+"move/", map[string]interface{}{"order": 1}, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(itemTexts(task.Checklists[0])), "[deploy build test]")
+	})
+
+	t.Run("Delete an item", func(t *testing.T) {
+		task := execute(t, "DELETE", getItemURL(boardID, listID, taskID, checklistID, itemIDs[0]), nil, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(itemTexts(task.Checklists[0])), "[deploy test]")
+		utils.AssertIntEqualsTo(t, task.Progress.Checked, 1)
+		utils.AssertIntEqualsTo(t, task.Progress.Total, 2)
+	})
+
+	t.Run("Update a non existing item", func(t *testing.T) {
+		execute(t, "PATCH", getItemURL(boardID, listID, taskID, checklistID, bson.NewObjectId()), map[string]interface{}{"checked": true}, http.StatusNotFound)
+	})
+
+	t.Run("Progress is returned with the task", func(t *testing.T) {
+		task := execute(t, "GET", getTaskURL(boardID, listID, taskID), nil, http.StatusOK)
+
+		utils.AssertIntEqualsTo(t, task.Progress.Checked, 1)
+		utils.AssertIntEqualsTo(t, task.Progress.Total, 2)
+	})
+
+	t.Run("Responses hold the comment count of the task", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTaskURL(boardID, listID, taskID)+"comments/", bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"body": "Almost done"})))
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusCreated)
+
+		task := execute(t, "PATCH", getItemURL(boardID, listID, taskID, checklistID, itemIDs[1]), map[string]interface{}{"checked": false}, http.StatusOK)
+		utils.AssertIntEqualsTo(t, task.CommentCount, 1)
+	})
+
+	t.Run("Concurrent item edits are all kept", func(t *testing.T) {
+		texts := []string{"tag", "announce", "archive", "celebrate", "rest"}
+		var wg sync.WaitGroup
+		for _, text := range texts {
+			wg.Add(1)
+			go func(text string) {
+				defer wg.Done()
+				execute(t, "POST", getChecklistURL(boardID, listID, taskID, checklistID)+"items/", map[string]interface{}{"text": text}, http.StatusCreated)
+			}(text)
+		}
+		wg.Wait()
+
+		task := execute(t, "GET", getTaskURL(boardID, listID, taskID), nil, http.StatusOK)
+		utils.AssertIntEqualsTo(t, len(task.Checklists[0].Items), 2+len(texts))
+	})
+
+	t.Run("Task updates keep checklists", func(t *testing.T) {
+		task := execute(t, "PATCH", getTaskURL(boardID, listID, taskID), map[string]interface{}{"title": "Renamed task", "checklists": []interface{}{}}, http.StatusOK)
+		utils.AssertIntEqualsTo(t, len(task.Checklists), 1)
+
+		task = execute(t, "GET", getTaskURL(boardID, listID, taskID), nil, http.StatusOK)
+		utils.AssertIntEqualsTo(t, len(task.Checklists[0].Items), 7)
+	})
+
+	t.Run("Delete a checklist", func(t *testing.T) {
+		task := execute(t, "DELETE", getChecklistURL(boardID, listID, taskID, checklistID), nil, http.StatusOK)
+
+		utils.AssertIntEqualsTo(t, len(task.Checklists), 0)
+		utils.AssertIntEqualsTo(t, task.Progress.Total, 0)
+	})
+
+	t.Run("Delete a non existing checklist", func(t *testing.T) {
+		execute(t, "DELETE", getChecklistURL(boardID, listID, taskID, checklistID), nil, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,258 @@
+package comments
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/comments"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName   = "Discussed board"
+	testingListName    = "Discussed list"
+	testingTaskTitle   = "Discussed task"
+	testingCommentBody = "Looks good to me"
+	updatedCommentBody = "Looks even better now"
+)
+
+// getCommentsURL -> Base URL of the comments of a task
+func getCommentsURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/%s/tasks/%s/comments/", boardID.Hex(), listID.Hex(), taskID.Hex())
+}
+
+func getCommentURL(boardID, listID, taskID, commentID bson.ObjectId) string {
+	return fmt.Sprintf("%s%s/", getCommentsURL(boardID, listID, taskID), commentID.Hex())
+}
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/%s/tasks/%s/", boardID.Hex(), listID.Hex(), taskID.Hex())
+}
+
+func getComment(body interface{}) []byte {
+	comment := make(map[string]interface{})
+	comment["body"] = body
+	return helpers.JsonEncode(comment)
+}
+
+// executeAs -> Execute request authenticated with given bearer token instead of the test program's user
+func executeAs(token string, req *http.Request) int {
+	req.Header.Set("Authorization", "Bearer "+token)
+	return utils.ExecuteRequest(req).Code
+}
+
+// generateTask -> Create a board with a list holding a task, return their IDs
+func generateTask(t *testing.T) (bson.ObjectId, bson.ObjectId, bson.ObjectId) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+	taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: testingTaskTitle})
+	return boardID, listID, taskID
+}
+
+// generateComment -> Comment given task as the test program's user
+func generateComment(t *testing.T, boardID, listID, taskID bson.ObjectId) models.Comment {
+	req, _ := http.NewRequest("POST", getCommentsURL(boardID, listID, taskID), bytes.NewReader(getComment(testingCommentBody)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+	var comment models.Comment
+	if err := json.Unmarshal(response.Body.Bytes(), &comment); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return comment
+}
+
+// addMember -> Register a new user and add it to the board with given role, return its token
+func addMember(t *testing.T, boardID bson.ObjectId, role string) string {
+	user, token := generator.GenerateUser(t)
+
+	member := map[string]interface{}{"email": user.Email, "role": role}
+	req, _ := http.NewRequest("POST", fmt.Sprintf("/boards/%s/members/", boardID.Hex()), bytes.NewReader(helpers.JsonEncode(member)))
+	utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusCreated)
+
+	return token
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+// ---- Test Create Endpoint ---- //
+func TestCreateCommentEndpoint(t *testing.T) {
+	boardID, listID, taskID := generateTask(t)
+
+	t.Run("Create a comment with valid informations", func(t *testing.T) {
+		comment := generateComment(t, boardID, listID, taskID)
+
+		utils.AssertStringEqualsTo(t, comment.Body, testingCommentBody)
+		utils.AssertStringEqualsTo(t, comment.TaskId.Hex(), taskID.Hex())
+		utils.AssertNotEmpty(t, comment.AuthorId)
+		utils.AssertNotEmpty(t, comment.CreatedAt)
+	})
+
+	t.Run("Create a comment with empty body", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getCommentsURL(boardID, listID, taskID), bytes.NewReader(getComment("")))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Create a comment with invalid body type", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getCommentsURL(boardID, listID, taskID), bytes.NewReader(getComment(12)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Create a comment on a non existing task", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getCommentsURL(boardID, listID, bson.NewObjectId()), bytes.NewReader(getComment(testingCommentBody)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Viewer can not comment", func(t *testing.T) {
+		viewerToken := addMember(t, boardID, models.RoleViewer)
+		req, _ := http.NewRequest("POST", getCommentsURL(boardID, listID, taskID), bytes.NewReader(getComment(testingCommentBody)))
+
+		utils.CheckResponseCode(t, executeAs(viewerToken, req), http.StatusForbidden)
+	})
+}
+
+// ---- Test Index Endpoint ---- //
+func TestIndexCommentEndpoint(t *testing.T) {
+	boardID, listID, taskID := generateTask(t)
+	first := generateComment(t, boardID, listID, taskID)
+	second := generateComment(t, boardID, listID, taskID)
+	generateComment(t, boardID, listID, taskID)
+
+	t.Run("List a page of comments", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getCommentsURL(boardID, listID, taskID)+"?limit=2", nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var res comments.CommentApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertIntEqualsTo(t, res.Total, 3)
+		utils.AssertIntEqualsTo(t, len(res.Comments), 2)
+		utils.AssertStringEqualsTo(t, res.Comments[0].CommentId.Hex(), first.CommentId.Hex())
+		utils.AssertStringEqualsTo(t, res.Comments[1].CommentId.Hex(), second.CommentId.Hex())
+	})
+
+	t.Run("List the last page of comments", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getCommentsURL(boardID, listID, taskID)+"?limit=2&offset=2", nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var res comments.CommentApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertIntEqualsTo(t, len(res.Comments), 1)
+	})
+
+	t.Run("List comments with invalid limit", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getCommentsURL(boardID, listID, taskID)+"?limit=1000", nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Task payload holds its comment count", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getTaskURL(boardID, listID, taskID), nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var task models.Task
+		if err := json.Unmarshal(response.Body.Bytes(), &task); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertIntEqualsTo(t, task.CommentCount, 3)
+	})
+}
+
+// ---- Test Update Endpoint ---- //
+func TestUpdateCommentEndpoint(t *testing.T) {
+	boardID, listID, taskID := generateTask(t)
+	comment := generateComment(t, boardID, listID, taskID)
+
+	t.Run("Author edits its comment", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getCommentURL(boardID, listID, taskID, comment.CommentId), bytes.NewReader(getComment(updatedCommentBody)))
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var updated models.Comment
+		if err := json.Unmarshal(response.Body.Bytes(), &updated); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertStringEqualsTo(t, updated.Body, updatedCommentBody)
+	})
+
+	t.Run("Another member can not edit the comment", func(t *testing.T) {
+		editorToken := addMember(t, boardID, models.RoleEditor)
+		req, _ := http.NewRequest("PATCH", getCommentURL(boardID, listID, taskID, comment.CommentId), bytes.NewReader(getComment(updatedCommentBody)))
+
+		utils.CheckResponseCode(t, executeAs(editorToken, req), http.StatusForbidden)
+	})
+
+	t.Run("Edit a non existing comment", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getCommentURL(boardID, listID, taskID, bson.NewObjectId()), bytes.NewReader(getComment(updatedCommentBody)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+}
+
+// ---- Test Delete Endpoint ---- //
+func TestDeleteCommentEndpoint(t *testing.T) {
+	boardID, listID, taskID := generateTask(t)
+	comment := generateComment(t, boardID, listID, taskID)
+
+	t.Run("Editor can not delete the comment of another member", func(t *testing.T) {
+		editorToken := addMember(t, boardID, models.RoleEditor)
+		req, _ := http.NewRequest("DELETE", getCommentURL(boardID, listID, taskID, comment.CommentId), nil)
+
+		utils.CheckResponseCode(t, executeAs(editorToken, req), http.StatusForbidden)
+	})
+
+	t.Run("Delete an existing comment", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getCommentURL(boardID, listID, taskID, comment.CommentId), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+	})
+
+	t.Run("Delete a comment with invalid ID", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getCommentsURL(boardID, listID, taskID)+"2/", nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Comments of a deleted task are deleted", func(t *testing.T) {
+		generateComment(t, boardID, listID, taskID)
+		req, _ := http.NewRequest("DELETE", getTaskURL(boardID, listID, taskID), nil)
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusOK)
+
+		req, _ = http.NewRequest("GET", getCommentsURL(boardID, listID, taskID), nil)
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusNotFound)
+	})
+}
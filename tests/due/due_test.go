@@ -0,0 +1,146 @@
+package due
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName = "Scheduled board"
+	testingListName  = "Scheduled list"
+)
+
+func getTasksURL(boardID, listID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/%s/tasks/", boardID.Hex(), listID.Hex())
+}
+
+func getBoardTasksURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/tasks/", boardID.Hex())
+}
+
+// inDays -> Now shifted by given number of days
+func inDays(days int) *time.Time {
+	date := time.Now().UTC().AddDate(0, 0, days)
+	return &date
+}
+
+// execute -> Execute request with given JSON body, return the response
+func execute(t *testing.T, method, url string, body interface{}, expectedCode int) []byte {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+	return response.Body.Bytes()
+}
+
+// getTitles -> Titles of the tasks listed by given endpoint, in order
+func getTitles(t *testing.T, url string) []string {
+	var tasks []models.Task
+	if err := json.Unmarshal(execute(t, "GET", url, nil, http.StatusOK), &tasks); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+
+	titles := []string{}
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	return titles
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+func TestTaskDates(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+
+	t.Run("Create a task with dates", func(t *testing.T) {
+		task := generator.GenerateTask(t, boardID, listID, &models.Task{Title: "Sprint", StartAt: inDays(1), DueAt: inDays(14)})
+
+		utils.AssertNotEmpty(t, task.StartAt)
+		utils.AssertNotEmpty(t, task.DueAt)
+		utils.AssertBoolEqualsTo(t, task.DueAt.After(*task.StartAt), true)
+		utils.AssertBoolEqualsTo(t, task.DueComplete, false)
+	})
+
+	t.Run("Create a task due before it starts", func(t *testing.T) {
+		execute(t, "POST", getTasksURL(boardID, listID), models.Task{Title: "Backwards", StartAt: inDays(2), DueAt: inDays(1)}, http.StatusBadRequest)
+	})
+
+	t.Run("Update dates of a task", func(t *testing.T) {
+		taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "Rescheduled", DueAt: inDays(3)})
+		taskURL := getTasksURL(boardID, listID) + taskID.Hex() + "/"
+
+		execute(t, "PATCH", taskURL, map[string]interface{}{"startAt": inDays(5)}, http.StatusBadRequest)
+		execute(t, "PATCH", taskURL, map[string]interface{}{"dueAt": "tomorrow"}, http.StatusBadRequest)
+
+		var task models.Task
+		json.Unmarshal(execute(t, "PATCH", taskURL, map[string]interface{}{"dueAt": nil, "dueComplete": true}, http.StatusOK), &task)
+		utils.AssertBoolEqualsTo(t, task.DueAt == nil, true)
+		utils.AssertBoolEqualsTo(t, task.DueComplete, true)
+	})
+
+	t.Run("Null attributes leave a task unchanged", func(t *testing.T) {
+		taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "Nulled", DueAt: inDays(3), DueComplete: true})
+		taskURL := getTasksURL(boardID, listID) + taskID.Hex() + "/"
+
+		var task models.Task
+		body := map[string]interface{}{"dueComplete": nil, "title": nil, "description": nil, "points": nil, "status": nil}
+		json.Unmarshal(execute(t, "PATCH", taskURL, body, http.StatusOK), &task)
+		utils.AssertBoolEqualsTo(t, task.DueComplete, true)
+		utils.AssertStringEqualsTo(t, task.Title, "Nulled")
+	})
+}
+
+func TestDueEndpoints(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+	otherListID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+
+	lateID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "late", DueAt: inDays(-1)})
+	generator.GenerateTask(t, boardID, otherListID, &models.Task{Title: "very late", DueAt: inDays(-5)})
+	generator.GenerateTask(t, boardID, listID, &models.Task{Title: "done", DueAt: inDays(-2), DueComplete: true})
+	soonID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "soon", DueAt: inDays(3)})
+	generator.GenerateTask(t, boardID, listID, &models.Task{Title: "later", DueAt: inDays(10)})
+	generator.GenerateTask(t, boardID, listID, &models.Task{Title: "unscheduled"})
+
+	t.Run("Overdue tasks of a board", func(t *testing.T) {
+		titles := getTitles(t, getBoardTasksURL(boardID)+"overdue")
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(titles), "[very late late]")
+	})
+
+	t.Run("Tasks of a board due within the next days", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(t, getBoardTasksURL(boardID)+"due")), "[soon]")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(t, getBoardTasksURL(boardID)+"due?days=14")), "[soon later]")
+	})
+
+	t.Run("Invalid number of days", func(t *testing.T) {
+		execute(t, "GET", getBoardTasksURL(boardID)+"due?days=0", nil, http.StatusBadRequest)
+		execute(t, "GET", getBoardTasksURL(boardID)+"due?days=week", nil, http.StatusBadRequest)
+	})
+
+	t.Run("Due tasks assigned to current user", func(t *testing.T) {
+		for _, taskID := range []bson.ObjectId{lateID, soonID} {
+			execute(t, "POST", getTasksURL(boardID, listID)+taskID.Hex()+"/assignees/", nil, http.StatusOK)
+		}
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(t, "/me/tasks/overdue")), "[late]")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(t, "/me/tasks/due?days=5")), "[soon]")
+	})
+}
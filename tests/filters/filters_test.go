@@ -0,0 +1,216 @@
+package filters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/filters"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// Page of tasks returned by filter endpoints
+type taskPage struct {
+	Items      []models.Task `json:"items"`
+	Total      int           `json:"total"`
+	NextOffset *int          `json:"nextOffset"`
+}
+
+func getBoardURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/", boardID.Hex())
+}
+
+func getFilterURL(boardID, filterID bson.ObjectId) string {
+	return fmt.Sprintf("%sfilters/%s/", getBoardURL(boardID), filterID.Hex())
+}
+
+// execute -> Execute request with given JSON body, authenticated with given token (test user when empty), decode the response into v
+func execute(t *testing.T, token, method, url string, body interface{}, expectedCode int, v interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	if v != nil {
+		if err := json.Unmarshal(response.Body.Bytes(), v); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+	}
+}
+
+// filterTasks -> Titles of the tasks of a board selected by given filter expression
+func filterTasks(t *testing.T, boardID bson.ObjectId, expression string) string {
+	var page taskPage
+	execute(t, "", "GET", getBoardURL(boardID)+"tasks?filter="+url.QueryEscape(expression), nil, http.StatusOK, &page)
+	return titles(page.Items)
+}
+
+// filterError -> Error message of an invalid filter expression
+func filterError(t *testing.T, boardID bson.ObjectId, expression string) string {
+	var response struct {
+		Message string `json:"message"`
+	}
+	execute(t, "", "GET", getBoardURL(boardID)+"tasks?filter="+url.QueryEscape(expression), nil, http.StatusBadRequest, &response)
+	return response.Message
+}
+
+func titles(tasks []models.Task) string {
+	titles := []string{}
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	return strings.Join(titles, ", ")
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+// generateBoard -> Board holding labels bug and "Needs review", and four tasks across two lists
+func generateBoard(t *testing.T) bson.ObjectId {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: "Filtered board"})
+	var bug, review models.Label
+	execute(t, "", "POST", getBoardURL(boardID)+"labels/", map[string]interface{}{"name": "bug", "color": "#d73a4a"}, http.StatusCreated, &bug)
+	execute(t, "", "POST", getBoardURL(boardID)+"labels/", map[string]interface{}{"name": "Needs review", "color": "#0e8a16"}, http.StatusCreated, &review)
+
+	todo := generator.GenerateListAndGetID(t, boardID, &models.List{Name: "Todo"})
+	later := generator.GenerateListAndGetID(t, boardID, &models.List{Name: "Later"})
+	soon, nextMonth, yesterday := time.Now().Add(48*time.Hour), time.Now().AddDate(0, 1, 0), time.Now().AddDate(0, 0, -1)
+	generator.GenerateTask(t, boardID, todo, &models.Task{Title: "Crash on login", Points: 8, Labels: []bson.ObjectId{bug.LabelId}, DueAt: &soon})
+	typo := generator.GenerateTask(t, boardID, todo, &models.Task{Title: "Typo in footer", Points: 1, Labels: []bson.ObjectId{bug.LabelId}})
+	generator.GenerateTask(t, boardID, later, &models.Task{Title: "Review API", Points: 5, Labels: []bson.ObjectId{review.LabelId}, DueAt: &nextMonth})
+	generator.GenerateTask(t, boardID, later, &models.Task{Title: "Old login ticket", Points: 13, DueAt: &yesterday})
+
+	url := fmt.Sprintf("%slists/%s/tasks/%s", getBoardURL(boardID), todo.Hex(), typo.TaskId.Hex())
+	execute(t, "", "PATCH", url, map[string]interface{}{"status": true}, http.StatusOK, nil)
+	return boardID
+}
+
+func TestFilterExpressions(t *testing.T) {
+	boardID := generateBoard(t)
+
+	t.Run("Every clause must match", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "status:false points>5 label:bug due<7d"), "Crash on login")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "status:false sort:title"), "Crash on login, Old login ticket, Review API")
+	})
+
+	t.Run("Points comparisons", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "points>=5 points<13 sort:-points"), "Crash on login, Review API")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "points:5"), "Review API")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "points>5 points<8"), "")
+	})
+
+	t.Run("Labels by name", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, `label:"needs REVIEW"`), "Review API")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "label:bug sort:-title"), "Typo in footer, Crash on login")
+	})
+
+	t.Run("Due dates", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "due<0d"), "Old login ticket")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "due>1w"), "Review API")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "due:none"), "Typo in footer")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "due>"+time.Now().AddDate(0, 0, 20).Format("2006-01-02")), "Review API")
+	})
+
+	t.Run("Bare words search titles", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "LOGIN sort:title"), "Crash on login, Old login ticket")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, `"on login"`), "Crash on login")
+		utils.AssertStringEqualsTo(t, filterTasks(t, boardID, "title:typo"), "Typo in footer")
+	})
+
+	t.Run("Empty filter selects every task of the board", func(t *testing.T) {
+		var page taskPage
+		execute(t, "", "GET", getBoardURL(boardID)+"tasks?limit=3", nil, http.StatusOK, &page)
+		utils.AssertIntEqualsTo(t, len(page.Items), 3)
+		utils.AssertIntEqualsTo(t, page.Total, 4)
+		utils.AssertIntEqualsTo(t, *page.NextOffset, 3)
+	})
+
+	t.Run("Invalid expressions", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, filterError(t, boardID, "status:false stauts:true"), `Invalid filter: unknown field "stauts", expected one of status, points, label, due, title, sort (at character 14)`)
+		utils.AssertStringEqualsTo(t, filterError(t, boardID, "points>many"), `Invalid filter: points "many" must be a number (at character 1)`)
+		utils.AssertStringEqualsTo(t, filterError(t, boardID, "label:feature"), `Invalid filter: label "feature" does not exist on this board (at character 1)`)
+		utils.AssertStringEqualsTo(t, filterError(t, boardID, `title:"open`), "Invalid filter: unterminated quote (at character 1)")
+		for _, expression := range []string{"status:maybe", "status>true", "due<soon", "due>=7d", "due:none due<7d", "sort:description", "points:", strings.Repeat("a ", 21)} {
+			filterError(t, boardID, expression)
+		}
+	})
+}
+
+func TestSavedFilters(t *testing.T) {
+	boardID := generateBoard(t)
+	viewer, viewerToken := generator.GenerateUser(t)
+	execute(t, "", "POST", getBoardURL(boardID)+"members/", map[string]interface{}{"email": viewer.Email, "role": models.RoleViewer}, http.StatusCreated, nil)
+
+	var filter models.Filter
+	t.Run("Save a filter", func(t *testing.T) {
+		execute(t, "", "POST", getBoardURL(boardID)+"filters/", map[string]interface{}{"name": "Open bugs", "query": "label:bug status:false"}, http.StatusCreated, &filter)
+		utils.AssertStringEqualsTo(t, filter.Name, "Open bugs")
+		utils.AssertStringEqualsTo(t, filter.BoardId.Hex(), boardID.Hex())
+		utils.AssertNotEmpty(t, filter.CreatedBy)
+
+		var other models.Filter
+		execute(t, "", "POST", getBoardURL(boardID)+"filters/", map[string]interface{}{"name": "Due soon", "query": "due<7d"}, http.StatusCreated, &other)
+
+		var response filters.FilterApiResponse
+		execute(t, viewerToken, "GET", getBoardURL(boardID)+"filters/", nil, http.StatusOK, &response)
+		utils.AssertIntEqualsTo(t, len(response.Filters), 2)
+		utils.AssertStringEqualsTo(t, response.Filters[0].Name, "Due soon")
+	})
+
+	t.Run("Invalid filters are not saved", func(t *testing.T) {
+		execute(t, "", "POST", getBoardURL(boardID)+"filters/", map[string]interface{}{"name": "Broken", "query": "label:feature"}, http.StatusBadRequest, nil)
+		execute(t, "", "POST", getBoardURL(boardID)+"filters/", map[string]interface{}{"name": "", "query": "status:true"}, http.StatusBadRequest, nil)
+		execute(t, "", "POST", getBoardURL(boardID)+"filters/", map[string]interface{}{"name": "Empty"}, http.StatusBadRequest, nil)
+		execute(t, "", "PATCH", getFilterURL(boardID, filter.FilterId), map[string]interface{}{"query": "points>"}, http.StatusBadRequest, nil)
+	})
+
+	t.Run("Members run shared filters", func(t *testing.T) {
+		var page taskPage
+		execute(t, viewerToken, "GET", getFilterURL(boardID, filter.FilterId)+"tasks", nil, http.StatusOK, &page)
+		utils.AssertStringEqualsTo(t, titles(page.Items), "Crash on login")
+	})
+
+	t.Run("Only editors manage filters", func(t *testing.T) {
+		execute(t, viewerToken, "POST", getBoardURL(boardID)+"filters/", map[string]interface{}{"name": "Mine", "query": "status:true"}, http.StatusForbidden, nil)
+		execute(t, viewerToken, "DELETE", getFilterURL(boardID, filter.FilterId), nil, http.StatusForbidden, nil)
+	})
+
+	t.Run("Update a filter", func(t *testing.T) {
+		execute(t, "", "PATCH", getFilterURL(boardID, filter.FilterId), map[string]interface{}{"query": "label:bug"}, http.StatusOK, &filter)
+		utils.AssertStringEqualsTo(t, filter.Name, "Open bugs")
+
+		var page taskPage
+		execute(t, "", "GET", getFilterURL(boardID, filter.FilterId)+"tasks?limit=1", nil, http.StatusOK, &page)
+		utils.AssertIntEqualsTo(t, page.Total, 2)
+	})
+
+	t.Run("Delete a filter", func(t *testing.T) {
+		execute(t, "", "DELETE", getFilterURL(boardID, filter.FilterId), nil, http.StatusOK, nil)
+		execute(t, "", "GET", getFilterURL(boardID, filter.FilterId), nil, http.StatusNotFound, nil)
+		execute(t, "", "GET", getFilterURL(boardID, filter.FilterId)+"tasks", nil, http.StatusNotFound, nil)
+	})
+
+	t.Run("Filters of other boards are not found", func(t *testing.T) {
+		otherBoardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: "Other board"})
+		var other models.Filter
+		execute(t, "", "POST", getBoardURL(otherBoardID)+"filters/", map[string]interface{}{"name": "Other", "query": "status:true"}, http.StatusCreated, &other)
+		execute(t, "", "GET", getFilterURL(boardID, other.FilterId), nil, http.StatusNotFound, nil)
+	})
+}
@@ -0,0 +1,238 @@
+package labels
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/labels"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName  = "Labelled board"
+	testingListName   = "Labelled list"
+	testingTaskTitle  = "Labelled task"
+	testingLabelName  = "bug"
+	testingLabelColor = "#d73a4a"
+	updatedLabelName  = "defect"
+)
+
+func getLabelsURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/labels/", boardID.Hex())
+}
+
+func getLabelURL(boardID, labelID bson.ObjectId) string {
+	return fmt.Sprintf("%s%s/", getLabelsURL(boardID), labelID.Hex())
+}
+
+func getTasksURL(boardID, listID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/%s/tasks/", boardID.Hex(), listID.Hex())
+}
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("%s%s/", getTasksURL(boardID, listID), taskID.Hex())
+}
+
+func getLabel(name, color interface{}) []byte {
+	label := make(map[string]interface{})
+	label["name"] = name
+	label["color"] = color
+	return helpers.JsonEncode(label)
+}
+
+func getTaskWithLabels(labelIDs ...bson.ObjectId) []byte {
+	task := make(map[string]interface{})
+	task["title"] = testingTaskTitle
+	task["labels"] = labelIDs
+	return helpers.JsonEncode(task)
+}
+
+func decodeTask(t *testing.T, body []byte) models.Task {
+	var task models.Task
+	if err := json.Unmarshal(body, &task); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return task
+}
+
+// generateLabel -> Create a label on given board
+func generateLabel(t *testing.T, boardID bson.ObjectId, name string) models.Label {
+	req, _ := http.NewRequest("POST", getLabelsURL(boardID), bytes.NewReader(getLabel(name, testingLabelColor)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+	var label models.Label
+	if err := json.Unmarshal(response.Body.Bytes(), &label); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return label
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+// ---- Test Create Endpoint ---- //
+func TestCreateLabelEndpoint(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+
+	t.Run("Create a label with valid informations", func(t *testing.T) {
+		label := generateLabel(t, boardID, testingLabelName)
+
+		utils.AssertStringEqualsTo(t, label.Name, testingLabelName)
+		utils.AssertStringEqualsTo(t, label.Color, testingLabelColor)
+		utils.AssertStringEqualsTo(t, label.BoardId.Hex(), boardID.Hex())
+		utils.AssertNotEmpty(t, label.LabelId)
+	})
+
+	t.Run("Create a label with empty name", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getLabelsURL(boardID), bytes.NewReader(getLabel("", testingLabelColor)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Create a label with invalid color", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getLabelsURL(boardID), bytes.NewReader(getLabel(testingLabelName, "red")))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
+
+// ---- Test Index Endpoint ---- //
+func TestIndexLabelEndpoint(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	generateLabel(t, boardID, "feature")
+	generateLabel(t, boardID, "chore")
+
+	req, _ := http.NewRequest("GET", getLabelsURL(boardID), nil)
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+	var res labels.LabelApiResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+
+	utils.AssertIntEqualsTo(t, len(res.Labels), 2)
+	utils.AssertStringEqualsTo(t, res.Labels[0].Name, "chore")
+	utils.AssertStringEqualsTo(t, res.Labels[1].Name, "feature")
+}
+
+// ---- Test Update Endpoint ---- //
+func TestUpdateLabelEndpoint(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	label := generateLabel(t, boardID, testingLabelName)
+
+	t.Run("Update a label name", func(t *testing.T) {
+		body := helpers.JsonEncode(map[string]interface{}{"name": updatedLabelName})
+		req, _ := http.NewRequest("PATCH", getLabelURL(boardID, label.LabelId), bytes.NewReader(body))
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var updated models.Label
+		if err := json.Unmarshal(response.Body.Bytes(), &updated); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertStringEqualsTo(t, updated.Name, updatedLabelName)
+		utils.AssertStringEqualsTo(t, updated.Color, testingLabelColor)
+	})
+
+	t.Run("Update a label with invalid color", func(t *testing.T) {
+		body := helpers.JsonEncode(map[string]interface{}{"color": "#12"})
+		req, _ := http.NewRequest("PATCH", getLabelURL(boardID, label.LabelId), bytes.NewReader(body))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Update a label of another board", func(t *testing.T) {
+		otherBoardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+		body := helpers.JsonEncode(map[string]interface{}{"name": updatedLabelName})
+		req, _ := http.NewRequest("PATCH", getLabelURL(otherBoardID, label.LabelId), bytes.NewReader(body))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+}
+
+// ---- Test Labels attached to Tasks ---- //
+func TestTaskLabels(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: testingListName})
+	bug := generateLabel(t, boardID, "bug")
+	feature := generateLabel(t, boardID, "feature")
+	otherBoardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	foreign := generateLabel(t, otherBoardID, "foreign")
+
+	var taskID bson.ObjectId
+
+	t.Run("Create a task with labels of its board", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTasksURL(boardID, listID), bytes.NewReader(getTaskWithLabels(bug.LabelId, feature.LabelId, bug.LabelId)))
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+		task := decodeTask(t, response.Body.Bytes())
+		taskID = task.TaskId
+		utils.AssertIntEqualsTo(t, len(task.Labels), 2)
+		utils.AssertStringEqualsTo(t, task.Labels[0].Hex(), bug.LabelId.Hex())
+		utils.AssertStringEqualsTo(t, task.Labels[1].Hex(), feature.LabelId.Hex())
+	})
+
+	t.Run("Create a task with a label of another board", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTasksURL(boardID, listID), bytes.NewReader(getTaskWithLabels(foreign.LabelId)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Update a task with an unknown label", func(t *testing.T) {
+		body := helpers.JsonEncode(map[string]interface{}{"labels": []bson.ObjectId{bson.NewObjectId()}})
+		req, _ := http.NewRequest("PATCH", getTaskURL(boardID, listID, taskID), bytes.NewReader(body))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Update a task with an invalid label ID", func(t *testing.T) {
+		body := helpers.JsonEncode(map[string]interface{}{"labels": []string{"2"}})
+		req, _ := http.NewRequest("PATCH", getTaskURL(boardID, listID, taskID), bytes.NewReader(body))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Deleted label is detached from tasks", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getLabelURL(boardID, bug.LabelId), nil)
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusOK)
+
+		req, _ = http.NewRequest("GET", getTaskURL(boardID, listID, taskID), nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		task := decodeTask(t, response.Body.Bytes())
+		utils.AssertIntEqualsTo(t, len(task.Labels), 1)
+		utils.AssertStringEqualsTo(t, task.Labels[0].Hex(), feature.LabelId.Hex())
+	})
+
+	t.Run("Task without labels has an empty list", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTasksURL(boardID, listID), bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"title": testingTaskTitle})))
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+		utils.AssertBoolEqualsTo(t, bytes.Contains(response.Body.Bytes(), []byte(`"labels":[]`)), true)
+	})
+}
@@ -8,7 +8,9 @@ import (
 	"testing"
 
 	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/config"
 	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/lists"
 	"github.com/AmFlint/taco-api-go/tests/utils"
 	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
 	"gopkg.in/mgo.v2/bson"
@@ -26,6 +28,9 @@ const (
 	genListForViewName = "about to be viewed"
 	// update endpoint
 	genListForUpdateName = "to be updated"
+	// parent boards
+	genBoardName = "Lists testing board"
+	genOtherBoardName = "Another testing board"
 )
 
 // TODO: Create Helpers for Resource creations -> Tests run in parrallell which means reusing an id from above test may not work
@@ -60,6 +65,14 @@ func getListForUpdate() *models.List {
 	return &list
 }
 
+func getBoard() *models.Board {
+	return &models.Board{Name: genBoardName}
+}
+
+func getOtherBoard() *models.Board {
+	return &models.Board{Name: genOtherBoardName}
+}
+
 // Configuration for basic Lists
 
 func getValidList() []byte {
@@ -86,24 +99,58 @@ func getValidListUpdate() []byte {
 	return helpers.JsonEncode(list)
 }
 
-/* ------------------------------------------
-   -------------- Test Suite ----------------
-   ------------------------------------------ */
+func getListReorder(ids ...bson.ObjectId) []byte {
+	reorder := make(map[string]interface{})
+	reorder["lists"] = ids
+	return helpers.JsonEncode(reorder)
+}
 
-var (
-	boardId bson.ObjectId
-)
+func getListOrderUpdate(order int) []byte {
+	list := make(map[string]interface{})
+	list["order"] = order
+	return helpers.JsonEncode(list)
+}
+
+// Retrieve IDs of a board's lists from the index endpoint, and check that orders are contiguous
+func getBoardListIDs(t *testing.T, boardId bson.ObjectId) []bson.ObjectId {
+	req, _ := http.NewRequest("GET", getListsBaseUrl(boardId), nil)
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+	var res lists.ListApiResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+		t.Fatalf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+
+	ids := make([]bson.ObjectId, len(res.Lists))
+	for i, list := range res.Lists {
+		utils.AssertIntEqualsTo(t, list.Order, i+1)
+		ids[i] = list.ListId
+	}
+	return ids
+}
 
-func init() {
-	boardId = bson.NewObjectId()
+func assertListIDsEqual(t *testing.T, got, expected []bson.ObjectId) {
+	if len(got) != len(expected) {
+		t.Fatalf("[Error], Expected %d lists, got %d", len(expected), len(got))
+	}
+	for i := range expected {
+		utils.AssertStringEqualsTo(t, got[i].Hex(), expected[i].Hex())
+	}
 }
 
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
 func TestMain(m *testing.M) {
 	testconfig.Init(m)
 }
 
 // ---- Test Create Endpoint ---- //
 func TestCreateListEndpoint(t *testing.T) {
+	boardId := generator.GenerateBoardAndGetID(t, getBoard())
+
 	t.Run("Create List with valid informations", func(t *testing.T) {
 		listURL := getListsBaseUrl(boardId)
 		list := getValidList()
@@ -119,8 +166,9 @@ func TestCreateListEndpoint(t *testing.T) {
 		utils.CheckResponseCode(t, response.Code, http.StatusCreated)
 
 		utils.AssertStringEqualsTo(t, createdList.Name, TESTING__LIST_NAME)
-		// TODO: Implement "order" tests when board is implemented
-		//utils.AssertIntEqualsTo(t, createdList.Order, 1)
+		utils.AssertStringEqualsTo(t, createdList.BoardId.Hex(), boardId.Hex())
+		// First list of the board
+		utils.AssertIntEqualsTo(t, createdList.Order, TESTING__LIST_ORDER)
 	})
 
 	t.Run("Create List with invalid user data - empty title", func(t *testing.T) {
@@ -147,11 +195,21 @@ func TestCreateListEndpoint(t *testing.T) {
 		response := utils.ExecuteRequest(req)
 		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
 	})
+
+	t.Run("Create List in a non existing board", func(t *testing.T) {
+		listURL := getListsBaseUrl(bson.NewObjectId())
+		list := getValidList()
+
+		req, _ := http.NewRequest("POST", listURL, bytes.NewReader(list))
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
 }
 
 // ---- Test View Endpoint ---- //
 func TestViewListHandler(t *testing.T) {
-	testedListID := generator.GenerateListAndGetID(t, getListForView())
+	boardId := generator.GenerateBoardAndGetID(t, getBoard())
+	testedListID := generator.GenerateListAndGetID(t, boardId, getListForView())
 
 	t.Run("View an existing list with valid ID", func(t *testing.T) {
 		listURL := getlistURL(boardId, testedListID)
@@ -179,6 +237,16 @@ func TestViewListHandler(t *testing.T) {
 			utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
 	})
 
+	t.Run("View an existing list from another board", func(t *testing.T) {
+		otherBoardId := generator.GenerateBoardAndGetID(t, getOtherBoard())
+		listURL := getlistURL(otherBoardId, testedListID)
+
+		req, _ := http.NewRequest("GET", listURL, nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
 	t.Run("View a list with invalid ID", func(t *testing.T) {
 		listURL := getInvalidlistURL(boardId)
 
@@ -191,7 +259,18 @@ func TestViewListHandler(t *testing.T) {
 
 // ---- Test Delete EndPoint ---- //
 func TestDeleteListHandler(t *testing.T) {
-	testedListID := generator.GenerateListAndGetID(t, getListForDelete())
+	boardId := generator.GenerateBoardAndGetID(t, getBoard())
+	testedListID := generator.GenerateListAndGetID(t, boardId, getListForDelete())
+
+	t.Run("Delete an existing list from another board", func(t *testing.T) {
+		otherBoardId := generator.GenerateBoardAndGetID(t, getOtherBoard())
+		listURL := getlistURL(otherBoardId, testedListID)
+
+		req, _ := http.NewRequest("DELETE", listURL, nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
 
 	t.Run("Delete an Existing task with valid Id", func(t *testing.T) {
 		listURL := getlistURL(boardId, testedListID)
@@ -231,7 +310,8 @@ func TestDeleteListHandler(t *testing.T) {
 }
 
 func TestUpdateListHandler(t *testing.T) {
-	testedListID := generator.GenerateListAndGetID(t, getListForUpdate())
+	boardId := generator.GenerateBoardAndGetID(t, getBoard())
+	testedListID := generator.GenerateListAndGetID(t, boardId, getListForUpdate())
 
 	t.Run("Update a list with valid informations", func(t *testing.T) {
 		listURL := getlistURL(boardId, testedListID)
@@ -260,6 +340,17 @@ func TestUpdateListHandler(t *testing.T) {
 		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
 	})
 
+	t.Run("Update an existing list from another board", func(t *testing.T) {
+		otherBoardId := generator.GenerateBoardAndGetID(t, getOtherBoard())
+		listURL := getlistURL(otherBoardId, testedListID)
+		list := getValidListUpdate()
+
+		req, _ := http.NewRequest("PATCH", listURL, bytes.NewReader(list))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
 	t.Run("Update non existing List", func(t *testing.T) {
 		listURL := getlistURL(boardId, bson.NewObjectId())
 		list := getValidListUpdate()
@@ -270,6 +361,31 @@ func TestUpdateListHandler(t *testing.T) {
 		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
 	})
 
+	t.Run("Update a list with null or wrongly typed attributes", func(t *testing.T) {
+		listURL := getlistURL(boardId, testedListID)
+		bodies := []map[string]interface{}{
+			{"name": nil},
+			{"order": nil},
+			{"name": 5},
+			{"order": "first"},
+		}
+		for _, body := range bodies {
+			req, _ := http.NewRequest("PATCH", listURL, bytes.NewReader(helpers.JsonEncode(body)))
+			utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusBadRequest)
+		}
+
+		req, _ := http.NewRequest("PATCH", listURL, bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"name": "Null order", "order": nil})))
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var updatedList models.List
+		if err := json.Unmarshal(response.Body.Bytes(), &updatedList); err != nil {
+			t.Error("[Error] in Update List endpoint, could not unmarshal response body")
+		}
+		utils.AssertStringEqualsTo(t, updatedList.Name, "Null order")
+		utils.AssertIntEqualsTo(t, updatedList.Order, 1)
+	})
+
 	t.Run("Update list with empty request body", func(t *testing.T) {
 		listURL := getlistURL(boardId, testedListID)
 
@@ -289,3 +405,161 @@ func TestUpdateListHandler(t *testing.T) {
 		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
 	})
 }
+
+// ---- Test Index and Reorder Endpoints ---- //
+func TestOrderListHandlers(t *testing.T) {
+	boardId := generator.GenerateBoardAndGetID(t, getBoard())
+	first := generator.GenerateListAndGetID(t, boardId, getListForView())
+	second := generator.GenerateListAndGetID(t, boardId, getListForView())
+	third := generator.GenerateListAndGetID(t, boardId, getListForView())
+
+	t.Run("Index lists sorted by creation order", func(t *testing.T) {
+		assertListIDsEqual(t, getBoardListIDs(t, boardId), []bson.ObjectId{first, second, third})
+	})
+
+	t.Run("Index lists of a non existing board", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getListsBaseUrl(bson.NewObjectId()), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Reorder every list of the board", func(t *testing.T) {
+		reorderURL := fmt.Sprintf("%sreorder", getListsBaseUrl(boardId))
+		req, _ := http.NewRequest("POST", reorderURL, bytes.NewReader(getListReorder(third, first, second)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+		assertListIDsEqual(t, getBoardListIDs(t, boardId), []bson.ObjectId{third, first, second})
+	})
+
+	t.Run("Reorder with missing lists", func(t *testing.T) {
+		reorderURL := fmt.Sprintf("%sreorder", getListsBaseUrl(boardId))
+		req, _ := http.NewRequest("POST", reorderURL, bytes.NewReader(getListReorder(third, first)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Reorder with duplicated lists", func(t *testing.T) {
+		reorderURL := fmt.Sprintf("%sreorder", getListsBaseUrl(boardId))
+		req, _ := http.NewRequest("POST", reorderURL, bytes.NewReader(getListReorder(third, first, first)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Reorder with a list of another board", func(t *testing.T) {
+		otherBoardId := generator.GenerateBoardAndGetID(t, getOtherBoard())
+		foreign := generator.GenerateListAndGetID(t, otherBoardId, getListForView())
+
+		reorderURL := fmt.Sprintf("%sreorder", getListsBaseUrl(boardId))
+		req, _ := http.NewRequest("POST", reorderURL, bytes.NewReader(getListReorder(third, first, foreign)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Move a list through the update endpoint", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getlistURL(boardId, second), bytes.NewReader(getListOrderUpdate(1)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var updatedList models.List
+		if err := json.Unmarshal(response.Body.Bytes(), &updatedList); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertIntEqualsTo(t, updatedList.Order, 1)
+		utils.AssertStringEqualsTo(t, updatedList.Name, genListForViewName)
+
+		assertListIDsEqual(t, getBoardListIDs(t, boardId), []bson.ObjectId{second, third, first})
+	})
+
+	t.Run("Updating a list read before a move keeps its order", func(t *testing.T) {
+		stale, err := config.GetApp().Store.Lists.FindByBoardAndID(boardId, first)
+		if err != nil {
+			t.Fatalf("Could not find list, got error: %s", err.Error())
+		}
+		req, _ := http.NewRequest("PATCH", getlistURL(boardId, first), bytes.NewReader(getListOrderUpdate(1)))
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusOK)
+
+		stale.Name = UPDATED__LIST_NAME
+		if err := config.GetApp().Store.Lists.Update(&stale); err != nil {
+			t.Fatalf("Could not update list, got error: %s", err.Error())
+		}
+		assertListIDsEqual(t, getBoardListIDs(t, boardId), []bson.ObjectId{first, second, third})
+	})
+
+	t.Run("Deleting a list renumbers its board", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getlistURL(boardId, third), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+		assertListIDsEqual(t, getBoardListIDs(t, boardId), []bson.ObjectId{first, second})
+	})
+}
+
+// ---- Test Index Endpoint with embedded tasks ---- //
+func TestIndexListWithTasksHandler(t *testing.T) {
+	boardId := generator.GenerateBoardAndGetID(t, getBoard())
+	filledListId := generator.GenerateListAndGetID(t, boardId, getListForView())
+	emptyListId := generator.GenerateListAndGetID(t, boardId, getListForView())
+
+	firstTask := generator.GenerateTaskAndGetID(t, boardId, filledListId, &models.Task{Title: "first", Points: 3})
+	secondTask := generator.GenerateTaskAndGetID(t, boardId, filledListId, &models.Task{Title: "second", Points: 5})
+
+	// Mark second task as done
+	taskURL := fmt.Sprintf("%stasks/%s", getlistURL(boardId, filledListId), secondTask.Hex())
+	req, _ := http.NewRequest("PATCH", taskURL, bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"status": true})))
+	utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusOK)
+
+	t.Run("Index lists with embedded tasks", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getListsBaseUrl(boardId), nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var res lists.ListApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Fatalf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertIntEqualsTo(t, len(res.Lists), 2)
+		utils.AssertStringEqualsTo(t, res.Lists[0].ListId.Hex(), filledListId.Hex())
+		utils.AssertIntEqualsTo(t, len(res.Lists[0].Tasks), 2)
+		utils.AssertStringEqualsTo(t, res.Lists[0].Tasks[0].TaskId.Hex(), firstTask.Hex())
+		utils.AssertStringEqualsTo(t, res.Lists[0].Tasks[1].TaskId.Hex(), secondTask.Hex())
+		utils.AssertStringEqualsTo(t, res.Lists[1].ListId.Hex(), emptyListId.Hex())
+		utils.AssertIntEqualsTo(t, len(res.Lists[1].Tasks), 0)
+	})
+
+	t.Run("Index lists with task summaries", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getListsBaseUrl(boardId)+"?summary=true", nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var res lists.ListApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Fatalf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+
+		utils.AssertIntEqualsTo(t, len(res.Lists), 2)
+		summary := res.Lists[0].Summary
+		if summary == nil {
+			t.Fatal("[Error], Expected list summary to be returned")
+		}
+		utils.AssertIntEqualsTo(t, len(res.Lists[0].Tasks), 0)
+		utils.AssertIntEqualsTo(t, summary.Count, 2)
+		utils.AssertIntEqualsTo(t, summary.Done, 1)
+		utils.AssertFloatEqualsTo(t, summary.Points, 8)
+		utils.AssertFloatEqualsTo(t, summary.DonePoints, 5)
+		utils.AssertIntEqualsTo(t, res.Lists[1].Summary.Count, 0)
+	})
+
+	t.Run("Index lists with invalid summary flag", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getListsBaseUrl(boardId)+"?summary=maybe", nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+}
@@ -0,0 +1,253 @@
+package members
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/boards"
+	"github.com/AmFlint/taco-api-go/routes/members"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName = "Shared board"
+	testingListName  = "Shared list"
+	testingTaskTitle = "Shared task"
+)
+
+func getBoardURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/", boardID.Hex())
+}
+
+func getMembersURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/members/", boardID.Hex())
+}
+
+func getMemberURL(boardID, userID bson.ObjectId) string {
+	return fmt.Sprintf("%s%s/", getMembersURL(boardID), userID.Hex())
+}
+
+func getListsURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/", boardID.Hex())
+}
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/lists/%s/tasks/%s/", boardID.Hex(), listID.Hex(), taskID.Hex())
+}
+
+func getMemberCreate(email, role string) []byte {
+	member := make(map[string]interface{})
+	member["email"] = email
+	member["role"] = role
+	return helpers.JsonEncode(member)
+}
+
+func getMemberUpdate(role string) []byte {
+	member := make(map[string]interface{})
+	member["role"] = role
+	return helpers.JsonEncode(member)
+}
+
+func getValidList() []byte {
+	list := make(map[string]interface{})
+	list["name"] = testingListName
+	return helpers.JsonEncode(list)
+}
+
+// executeAs -> Execute request authenticated with given bearer token instead of the test program's user
+func executeAs(token string, req *http.Request) int {
+	req.Header.Set("Authorization", "Bearer "+token)
+	return utils.ExecuteRequest(req).Code
+}
+
+// addMember -> Register a new user and add it to the board with given role, return its ID and token
+func addMember(t *testing.T, boardID bson.ObjectId, role string) (bson.ObjectId, string) {
+	user, token := generator.GenerateUser(t)
+
+	req, _ := http.NewRequest("POST", getMembersURL(boardID), bytes.NewReader(getMemberCreate(user.Email, role)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+
+	return user.UserId, token
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+// ---- Test Index Endpoint ---- //
+func TestIndexMemberEndpoint(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	viewerID, _ := addMember(t, boardID, models.RoleViewer)
+
+	req, _ := http.NewRequest("GET", getMembersURL(boardID), nil)
+	response := utils.ExecuteRequest(req)
+
+	utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+	var res members.MemberApiResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+
+	// Board creator is its owner
+	utils.AssertIntEqualsTo(t, len(res.Members), 2)
+	utils.AssertStringEqualsTo(t, res.Members[0].Role, models.RoleOwner)
+	utils.AssertStringEqualsTo(t, res.Members[1].Role, models.RoleViewer)
+	utils.AssertStringEqualsTo(t, res.Members[1].UserId.Hex(), viewerID.Hex())
+	utils.AssertNotEmpty(t, res.Members[1].Email)
+}
+
+// ---- Test Create Endpoint ---- //
+func TestCreateMemberEndpoint(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+
+	t.Run("Add member with unknown email", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getMembersURL(boardID), bytes.NewReader(getMemberCreate("nobody@taco.test", models.RoleEditor)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Add member with invalid role", func(t *testing.T) {
+		user, _ := generator.GenerateUser(t)
+		req, _ := http.NewRequest("POST", getMembersURL(boardID), bytes.NewReader(getMemberCreate(user.Email, "admin")))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Add member twice", func(t *testing.T) {
+		user, _ := generator.GenerateUser(t)
+		req, _ := http.NewRequest("POST", getMembersURL(boardID), bytes.NewReader(getMemberCreate(user.Email, models.RoleEditor)))
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusCreated)
+
+		req, _ = http.NewRequest("POST", getMembersURL(boardID), bytes.NewReader(getMemberCreate(user.Email, models.RoleViewer)))
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusConflict)
+	})
+}
+
+// ---- Test Access Control on the Board subtree ---- //
+func TestBoardAccessControl(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	list := generator.GenerateList(t, boardID, &models.List{Name: testingListName})
+	taskID := generator.GenerateTaskAndGetID(t, boardID, list.ListId, &models.Task{Title: testingTaskTitle})
+
+	_, viewerToken := addMember(t, boardID, models.RoleViewer)
+	_, editorToken := addMember(t, boardID, models.RoleEditor)
+	_, strangerToken := generator.GenerateUser(t)
+
+	t.Run("Non member can not see the board", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getBoardURL(boardID), nil)
+		utils.CheckResponseCode(t, executeAs(strangerToken, req), http.StatusNotFound)
+
+		req, _ = http.NewRequest("GET", getListsURL(boardID), nil)
+		utils.CheckResponseCode(t, executeAs(strangerToken, req), http.StatusNotFound)
+
+		req, _ = http.NewRequest("GET", getTaskURL(boardID, list.ListId, taskID), nil)
+		utils.CheckResponseCode(t, executeAs(strangerToken, req), http.StatusNotFound)
+
+		req, _ = http.NewRequest("GET", getMembersURL(boardID), nil)
+		utils.CheckResponseCode(t, executeAs(strangerToken, req), http.StatusNotFound)
+	})
+
+	t.Run("Non member does not get the board in its index", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/boards/", nil)
+		req.Header.Set("Authorization", "Bearer "+strangerToken)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var res boards.BoardApiResponse
+		if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertIntEqualsTo(t, len(res.Boards), 0)
+	})
+
+	t.Run("Viewer can read but not write", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getBoardURL(boardID), nil)
+		utils.CheckResponseCode(t, executeAs(viewerToken, req), http.StatusOK)
+
+		req, _ = http.NewRequest("GET", getTaskURL(boardID, list.ListId, taskID), nil)
+		utils.CheckResponseCode(t, executeAs(viewerToken, req), http.StatusOK)
+
+		req, _ = http.NewRequest("POST", getListsURL(boardID), bytes.NewReader(getValidList()))
+		utils.CheckResponseCode(t, executeAs(viewerToken, req), http.StatusForbidden)
+
+		req, _ = http.NewRequest("PATCH", getTaskURL(boardID, list.ListId, taskID), bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"title": "hijacked"})))
+		utils.CheckResponseCode(t, executeAs(viewerToken, req), http.StatusForbidden)
+
+		req, _ = http.NewRequest("DELETE", getTaskURL(boardID, list.ListId, taskID), nil)
+		utils.CheckResponseCode(t, executeAs(viewerToken, req), http.StatusForbidden)
+	})
+
+	t.Run("Editor can write lists and tasks but not manage the board", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getListsURL(boardID), bytes.NewReader(getValidList()))
+		utils.CheckResponseCode(t, executeAs(editorToken, req), http.StatusCreated)
+
+		req, _ = http.NewRequest("PATCH", getTaskURL(boardID, list.ListId, taskID), bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"title": "edited"})))
+		utils.CheckResponseCode(t, executeAs(editorToken, req), http.StatusOK)
+
+		req, _ = http.NewRequest("PATCH", getBoardURL(boardID), bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"name": "renamed"})))
+		utils.CheckResponseCode(t, executeAs(editorToken, req), http.StatusForbidden)
+
+		req, _ = http.NewRequest("DELETE", getBoardURL(boardID), nil)
+		utils.CheckResponseCode(t, executeAs(editorToken, req), http.StatusForbidden)
+
+		user, _ := generator.GenerateUser(t)
+		req, _ = http.NewRequest("POST", getMembersURL(boardID), bytes.NewReader(getMemberCreate(user.Email, models.RoleViewer)))
+		utils.CheckResponseCode(t, executeAs(editorToken, req), http.StatusForbidden)
+	})
+}
+
+// ---- Test Update and Delete Endpoints ---- //
+func TestUpdateDeleteMemberEndpoints(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: testingBoardName})
+	memberID, memberToken := addMember(t, boardID, models.RoleViewer)
+
+	t.Run("Promote viewer to editor", func(t *testing.T) {
+		req, _ := http.NewRequest("PATCH", getMemberURL(boardID, memberID), bytes.NewReader(getMemberUpdate(models.RoleEditor)))
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusOK)
+
+		req, _ = http.NewRequest("POST", getListsURL(boardID), bytes.NewReader(getValidList()))
+		utils.CheckResponseCode(t, executeAs(memberToken, req), http.StatusCreated)
+	})
+
+	t.Run("Last owner can not be demoted nor removed", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getMembersURL(boardID), nil)
+		var res members.MemberApiResponse
+		json.Unmarshal(utils.ExecuteRequest(req).Body.Bytes(), &res)
+		ownerID := res.Members[0].UserId
+
+		req, _ = http.NewRequest("PATCH", getMemberURL(boardID, ownerID), bytes.NewReader(getMemberUpdate(models.RoleViewer)))
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusConflict)
+
+		req, _ = http.NewRequest("DELETE", getMemberURL(boardID, ownerID), nil)
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusConflict)
+	})
+
+	t.Run("Member leaves the board", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getMemberURL(boardID, memberID), nil)
+		utils.CheckResponseCode(t, executeAs(memberToken, req), http.StatusOK)
+
+		req, _ = http.NewRequest("GET", getBoardURL(boardID), nil)
+		utils.CheckResponseCode(t, executeAs(memberToken, req), http.StatusNotFound)
+	})
+
+	t.Run("Remove unknown member", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getMemberURL(boardID, bson.NewObjectId()), nil)
+		utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,124 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/routes/search"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+)
+
+const searchURL = "/search"
+
+// execute -> Execute request with given JSON body, authenticated with given token (test user when empty)
+func execute(t *testing.T, token, method, url string, body interface{}, expectedCode int, v interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	if v != nil {
+		if err := json.Unmarshal(response.Body.Bytes(), v); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+	}
+}
+
+// getResults -> Search with given query string, as the user of given token (test user when empty)
+func getResults(t *testing.T, token, query string) search.SearchApiResponse {
+	var response search.SearchApiResponse
+	execute(t, token, "GET", searchURL+"?"+query, nil, http.StatusOK, &response)
+	return response
+}
+
+// describe -> Type and title of each result, e.g. "task Write docs"
+func describe(results []search.SearchResult) []string {
+	descriptions := []string{}
+	for _, result := range results {
+		descriptions = append(descriptions, result.Type+" "+result.Title)
+	}
+	return descriptions
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+func TestSearch(t *testing.T) {
+	boardID := generator.GenerateBoardAndGetID(t, &models.Board{Name: "Searched board"})
+	listID := generator.GenerateListAndGetID(t, boardID, &models.List{Name: "Sprint"})
+	generator.GenerateListAndGetID(t, boardID, &models.List{Name: "Kiwi ideas"})
+	taskID := generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "Fix kiwi checkout", Description: "Orders of kiwis fail <sometimes> when KIWI stock is empty"})
+	generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "Write docs", Description: strings.Repeat("Explain the basket. ", 5) + "Kiwi is the default fruit."})
+	generator.GenerateTaskAndGetID(t, boardID, listID, &models.Task{Title: "Unrelated", Description: "Bananas"})
+
+	// Boards of other users are never searched
+	_, otherToken := generator.GenerateUser(t)
+	var otherBoard models.Board
+	execute(t, otherToken, "POST", "/boards/", map[string]interface{}{"name": "Hidden board"}, http.StatusCreated, &otherBoard)
+	var otherList models.List
+	execute(t, otherToken, "POST", fmt.Sprintf("/boards/%s/lists/", otherBoard.BoardId.Hex()), map[string]interface{}{"name": "Kiwi secrets"}, http.StatusCreated, &otherList)
+
+	t.Run("Results are ranked by relevance", func(t *testing.T) {
+		response := getResults(t, "", "q=kiwi")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(response.Results)), "[task Fix kiwi checkout list Kiwi ideas task Write docs]")
+
+		best := response.Results[0]
+		utils.AssertStringEqualsTo(t, best.TaskId.Hex(), taskID.Hex())
+		utils.AssertStringEqualsTo(t, best.ListId.Hex(), listID.Hex())
+		utils.AssertStringEqualsTo(t, best.BoardId.Hex(), boardID.Hex())
+		utils.AssertBoolEqualsTo(t, best.Score > response.Results[1].Score, true)
+		// Terms count more in names and titles than in descriptions
+		utils.AssertBoolEqualsTo(t, response.Results[1].Score > response.Results[2].Score, true)
+	})
+
+	t.Run("Snippets highlight terms", func(t *testing.T) {
+		results := getResults(t, "", "q=Kiwi").Results
+
+		// Descriptions are excerpted around the first occurrence, and escaped
+		utils.AssertStringEqualsTo(t, results[0].Snippet, "Orders of <mark>kiwi</mark>s fail &lt;sometimes&gt; when <mark>KIWI</mark> stock is empty")
+		utils.AssertStringEqualsTo(t, results[2].Snippet, "…"+strings.Repeat("Explain the basket. ", 2)+"<mark>Kiwi</mark> is the default fruit.")
+		utils.AssertStringEqualsTo(t, results[1].Snippet, "<mark>Kiwi</mark> ideas")
+		utils.AssertStringEqualsTo(t, results[1].TaskId.Hex(), "")
+	})
+
+	t.Run("Results match any term", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(getResults(t, "", "q=banana+sprint").Results)), "[list Sprint task Unrelated]")
+		utils.AssertIntEqualsTo(t, len(getResults(t, "", "q=durian").Results), 0)
+	})
+
+	t.Run("Paginate results", func(t *testing.T) {
+		response := getResults(t, "", "q=kiwi&limit=1&offset=1")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(response.Results)), "[list Kiwi ideas]")
+		utils.AssertIntEqualsTo(t, response.Limit, 1)
+		utils.AssertIntEqualsTo(t, response.Offset, 1)
+	})
+
+	t.Run("Only boards of the user are searched", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, fmt.Sprint(describe(getResults(t, otherToken, "q=kiwi").Results)), "[list Kiwi secrets]")
+	})
+
+	t.Run("Invalid searches", func(t *testing.T) {
+		words := []string{}
+		for i := 0; i <= search.MaxSearchTerms; i++ {
+			words = append(words, fmt.Sprintf("word%d", i))
+		}
+		for _, query := range []string{"", "q=+-+", "q=" + strings.Join(words, "+"), "q=kiwi&limit=100&offset=450", "q=" + strings.Repeat("a", 201)} {
+			execute(t, "", "GET", searchURL+"?"+query, nil, http.StatusBadRequest, nil)
+		}
+	})
+}
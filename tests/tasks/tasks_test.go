@@ -2,6 +2,7 @@ package tasks
 
 import (
 	"testing"
+	"github.com/AmFlint/taco-api-go/config"
 	"github.com/AmFlint/taco-api-go/models"
 	"net/http"
 	"bytes"
@@ -12,6 +13,7 @@ import (
 	"fmt"
 	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
 	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	routes "github.com/AmFlint/taco-api-go/routes/tasks"
 )
 
 /* -----------------------------------------------------------------
@@ -43,6 +45,10 @@ const (
 	genTaskForViewTitle = "About to be viewed title"
 	genTaskForViewDescription = "About to be viewed description"
 	genTaskForViewPoints = 5
+	// Parent board/list
+	genBoardName = "Tasks testing board"
+	genListName = "Tasks testing list"
+	genOtherListName = "Another testing list"
 )
 
 // get Base URL for Tasks endpoints
@@ -55,6 +61,11 @@ func getTaskUrl(boardId, listId, taskId bson.ObjectId) string {
 	return fmt.Sprintf("%s/%s", getBaseUrl(boardId, listId), taskId.Hex())
 }
 
+// Get Move URL for a task
+func getTaskMoveUrl(boardId, listId, taskId bson.ObjectId) string {
+	return fmt.Sprintf("%s/move", getTaskUrl(boardId, listId, taskId))
+}
+
 // Get and invalid URL (bad format) for task endpoints
 func getInvalidTaskUrl(boardId, listId bson.ObjectId) string {
 	return fmt.Sprintf("%s/%s", getBaseUrl(boardId, listId), "0")
@@ -160,8 +171,96 @@ func getTaskUpdateInvalidTitle() []byte {
 	return helpers.JsonEncode(task)
 }
 
+func getTaskMove(listId bson.ObjectId, order int) []byte {
+	move := make(map[string]interface{})
+	move["listId"] = listId.Hex()
+	move["order"] = order
+	return helpers.JsonEncode(move)
+}
+
 /* ----------------------- Local Test Helpers ------------------------ */
 
+// Page of tasks returned by the index endpoint
+type taskPage struct {
+	Items      []models.Task `json:"items"`
+	Total      int           `json:"total"`
+	Limit      int           `json:"limit"`
+	NextOffset *int          `json:"nextOffset"`
+}
+
+// Retrieve a page of tasks from the index endpoint, with given query string
+func getTaskPage(t *testing.T, boardId, listId bson.ObjectId, query string) taskPage {
+	req, _ := http.NewRequest("GET", getBaseUrl(boardId, listId)+"?"+query, nil)
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+	var page taskPage
+	if err := json.Unmarshal(response.Body.Bytes(), &page); err != nil {
+		t.Fatalf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return page
+}
+
+// Titles of given tasks, in order
+func getTitles(tasks []models.Task) []string {
+	titles := make([]string, len(tasks))
+	for i, task := range tasks {
+		titles[i] = task.Title
+	}
+	return titles
+}
+
+// Retrieve IDs of a list's tasks, following the index endpoint order, and check that orders are contiguous
+func getListTaskIDs(t *testing.T, boardId, listId bson.ObjectId) []bson.ObjectId {
+	tasks := getTaskPage(t, boardId, listId, "limit=100").Items
+
+	ids := make([]bson.ObjectId, len(tasks))
+	for i, task := range tasks {
+		utils.AssertIntEqualsTo(t, task.Order, i+1)
+		ids[i] = task.TaskId
+	}
+	return ids
+}
+
+func assertTaskIDsEqual(t *testing.T, got, expected []bson.ObjectId) {
+	if len(got) != len(expected) {
+		t.Fatalf("[Error], Expected %d tasks, got %d", len(expected), len(got))
+	}
+	for i := range expected {
+		utils.AssertStringEqualsTo(t, got[i].Hex(), expected[i].Hex())
+	}
+}
+
+// Send a bulk operation on tasks of a board, and decode its response
+func postBulk(t *testing.T, boardId bson.ObjectId, body map[string]interface{}, expectedCode int) routes.TaskBulkApiResponse {
+	url := fmt.Sprintf("/boards/%s/tasks/bulk", boardId.Hex())
+	req, _ := http.NewRequest("POST", url, bytes.NewReader(helpers.JsonEncode(body)))
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	var res routes.TaskBulkApiResponse
+	if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+		t.Fatalf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+	return res
+}
+
+// Hexadecimal representation of given task IDs, as sent in request bodies
+func hexIDs(ids ...bson.ObjectId) []string {
+	hex := make([]string, len(ids))
+	for i, id := range ids {
+		hex[i] = id.Hex()
+	}
+	return hex
+}
+
+// Generate the Board and List tasks are attached to
+func generateParents(t *testing.T) (bson.ObjectId, bson.ObjectId) {
+	boardId := generator.GenerateBoardAndGetID(t, &models.Board{Name: genBoardName})
+	listId := generator.GenerateListAndGetID(t, boardId, &models.List{Name: genListName})
+	return boardId, listId
+}
+
 func checkResponseCodeAndErrorMessage(t *testing.T, code int, body []byte) {
 	utils.CheckResponseCode(t, code, http.StatusBadRequest)
 	var res utils.ErrorResponse
@@ -176,15 +275,6 @@ func checkResponseCodeAndErrorMessage(t *testing.T, code int, body []byte) {
    ------------------------ TEST SUITE -----------------------------
    ----------------------------------------------------------------- */
 
-var (
-	boardId, listId bson.ObjectId
-)
-
-func init() {
-	boardId = bson.NewObjectId()
-	listId = bson.NewObjectId()
-}
-
 func TestMain(m *testing.M) {
 	testconfig.Init(m)
 }
@@ -194,6 +284,8 @@ func TestMain(m *testing.M) {
    -------------------------------- */
 
 func TestCreateTaskEndpoint(t *testing.T) {
+	boardId, listId := generateParents(t)
+
 	// -- Test to create a task with valid body -> Should Create task -> Return 200 w/ Task Object -- //
 	t.Run("Create a Task With Valid Informations", func(t *testing.T) {
 		body := getTaskValid()
@@ -220,6 +312,9 @@ func TestCreateTaskEndpoint(t *testing.T) {
 		utils.AssertFloatEqualsTo(t, responseTask.Points, testingTaskPoints)
 
 		utils.AssertBoolEqualsTo(t, responseTask.Status, false)
+		// Assert that task is attached to its parent list and board
+		utils.AssertStringEqualsTo(t, responseTask.ListId.Hex(), listId.Hex())
+		utils.AssertStringEqualsTo(t, responseTask.BoardId.Hex(), boardId.Hex())
 	})
 
 	// -- Test to create a task with invalid body (invalid points type) -> Should NOT Create task -> Return 400 w/ Msg/Code object -- //
@@ -256,6 +351,15 @@ func TestCreateTaskEndpoint(t *testing.T) {
 		checkResponseCodeAndErrorMessage(t, response.Code, response.Body.Bytes())
 	})
 
+	t.Run("Create a Task in a non existing list", func(t *testing.T) {
+		body := getTaskValid()
+		req, _ := http.NewRequest("POST", getBaseUrl(boardId, bson.NewObjectId()), bytes.NewReader(body))
+
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
 	t.Run("Create a Task with empty request body", func(t *testing.T) {
 		req, _ := http.NewRequest("POST", getBaseUrl(boardId, listId), nil)
 		// Execute Request and retrieve response
@@ -265,13 +369,113 @@ func TestCreateTaskEndpoint(t *testing.T) {
 	})
 }
 
+/* --------------------------------
+   ----- Index Tasks Endpoint ----
+   -------------------------------- */
+
+func TestIndexTaskEndpoint(t *testing.T) {
+	boardId, listId := generateParents(t)
+	otherListId := generator.GenerateListAndGetID(t, boardId, &models.List{Name: genOtherListName})
+
+	testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForView())
+	generator.GenerateTaskAndGetID(t, boardId, otherListId, getTaskForView())
+
+	t.Run("List tasks of a list", func(t *testing.T) {
+		page := getTaskPage(t, boardId, listId, "")
+		tasks := page.Items
+
+		// Only tasks attached to requested list are returned
+		utils.AssertIntEqualsTo(t, len(tasks), 1)
+		utils.AssertIntEqualsTo(t, page.Total, 1)
+		utils.AssertIntEqualsTo(t, page.Limit, helpers.DefaultPageLimit)
+		utils.AssertBoolEqualsTo(t, page.NextOffset == nil, true)
+		if len(tasks) == 1 {
+			utils.AssertStringEqualsTo(t, tasks[0].TaskId.Hex(), testedTaskID.Hex())
+		}
+	})
+
+	t.Run("List tasks of a non existing list", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", getBaseUrl(boardId, bson.NewObjectId()), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Tasks of a deleted list are not reachable anymore", func(t *testing.T) {
+		listUrl := fmt.Sprintf("/boards/%s/lists/%s", boardId.Hex(), listId.Hex())
+		req, _ := http.NewRequest("DELETE", listUrl, nil)
+		response := utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		req, _ = http.NewRequest("GET", getTaskUrl(boardId, listId, testedTaskID), nil)
+		response = utils.ExecuteRequest(req)
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+}
+
+func TestIndexTaskQueries(t *testing.T) {
+	boardId, listId := generateParents(t)
+	for _, task := range []models.Task{
+		{Title: "Write specs", Points: 3},
+		{Title: "Deploy API", Points: 8, Status: true},
+		{Title: "write docs", Points: 1},
+		{Title: "Review 100% of PRs", Points: 5, Status: true},
+	} {
+		taskId := generator.GenerateTaskAndGetID(t, boardId, listId, &task)
+		// Tasks are created with default status
+		if task.Status {
+			req, _ := http.NewRequest("PATCH", getBaseUrl(boardId, listId)+"/"+taskId.Hex(), bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"status": true})))
+			utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusOK)
+		}
+	}
+
+	t.Run("Paginate tasks", func(t *testing.T) {
+		page := getTaskPage(t, boardId, listId, "limit=3")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(page.Items)), "[Write specs Deploy API write docs]")
+		utils.AssertIntEqualsTo(t, page.Total, 4)
+		utils.AssertIntEqualsTo(t, *page.NextOffset, 3)
+
+		page = getTaskPage(t, boardId, listId, "limit=3&offset=3")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(page.Items)), "[Review 100% of PRs]")
+		utils.AssertBoolEqualsTo(t, page.NextOffset == nil, true)
+	})
+
+	t.Run("Sort tasks", func(t *testing.T) {
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(getTaskPage(t, boardId, listId, "sort=points").Items)), "[write docs Write specs Review 100% of PRs Deploy API]")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(getTaskPage(t, boardId, listId, "sort=-points&limit=2").Items)), "[Deploy API Review 100% of PRs]")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(getTaskPage(t, boardId, listId, "sort=createdAt&limit=1").Items)), "[Write specs]")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(getTaskPage(t, boardId, listId, "sort=title").Items)), "[Deploy API Review 100% of PRs Write specs write docs]")
+	})
+
+	t.Run("Filter tasks", func(t *testing.T) {
+		page := getTaskPage(t, boardId, listId, "status=true")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(page.Items)), "[Deploy API Review 100% of PRs]")
+		utils.AssertIntEqualsTo(t, page.Total, 2)
+
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(getTaskPage(t, boardId, listId, "pointsMin=3&pointsMax=5").Items)), "[Write specs Review 100% of PRs]")
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(getTaskPage(t, boardId, listId, "title=WRITE&status=false").Items)), "[Write specs write docs]")
+		// Wildcards are matched literally
+		utils.AssertStringEqualsTo(t, fmt.Sprint(getTitles(getTaskPage(t, boardId, listId, "title=0%25").Items)), "[Review 100% of PRs]")
+		utils.AssertIntEqualsTo(t, getTaskPage(t, boardId, listId, "title=_").Total, 0)
+	})
+
+	t.Run("Invalid queries", func(t *testing.T) {
+		for _, query := range []string{"limit=1000", "limit=0", "sort=description", "status=done", "pointsMin=many", "pointsMin=5&pointsMax=3"} {
+			req, _ := http.NewRequest("GET", getBaseUrl(boardId, listId)+"?"+query, nil)
+			utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusBadRequest)
+		}
+	})
+}
+
 /* --------------------------------
    ----- View Task Endpoint ----
    -------------------------------- */
 
 func TestViewTaskEndpoint(t *testing.T) {
+	boardId, listId := generateParents(t)
+
 	// Generate Task to test
-	testedTaskID := generator.GenerateTaskAndGetID(t, getTaskForView())
+	testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForView())
 
 	// Testing view existing Task with Valid ObjectID
 	t.Run("View existing task with valid object id", func(t *testing.T) {
@@ -308,6 +512,26 @@ func TestViewTaskEndpoint(t *testing.T) {
 		// TODO: Verify server response contains error message: create error response Struct
 	})
 
+	t.Run("View existing task from another list", func(t *testing.T) {
+		otherListId := generator.GenerateListAndGetID(t, boardId, &models.List{Name: genOtherListName})
+		taskUrl := getTaskUrl(boardId, otherListId, testedTaskID)
+		req, _ := http.NewRequest("GET", taskUrl, nil)
+
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("View existing task from another board", func(t *testing.T) {
+		otherBoardId := generator.GenerateBoardAndGetID(t, &models.Board{Name: genBoardName})
+		taskUrl := getTaskUrl(otherBoardId, listId, testedTaskID)
+		req, _ := http.NewRequest("GET", taskUrl, nil)
+
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
 	t.Run("view task with invalid object id", func(t *testing.T) {
 		taskUrl := getInvalidTaskUrl(boardId, listId)
 
@@ -319,9 +543,11 @@ func TestViewTaskEndpoint(t *testing.T) {
 }
 
 func TestUpdateTaskEndpoint(t *testing.T) {
+	boardId, listId := generateParents(t)
+
 	t.Run("Update Task with valid informations (title, status, points) - on existing task", func(t *testing.T) {
 		// Generating Task To test≤
-		testedTaskID := generator.GenerateTaskAndGetID(t, getTaskForUpdate())
+		testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForUpdate())
 		taskUrl := getTaskUrl(boardId, listId, testedTaskID)
 		body := getTaskUpdateValidNoDescription()
 
@@ -347,7 +573,7 @@ func TestUpdateTaskEndpoint(t *testing.T) {
 
 	t.Run("Update Task - only description with valid request", func(t *testing.T) {
 		// Generating Task To test≤
-		testedTaskID := generator.GenerateTaskAndGetID(t, getTaskForUpdate())
+		testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForUpdate())
 		taskUrl := getTaskUrl(boardId, listId, testedTaskID)
 		body := getTaskUpdateValidDescription()
 
@@ -373,7 +599,7 @@ func TestUpdateTaskEndpoint(t *testing.T) {
 
 	t.Run("Update existing task with invalid points (negative)", func(t *testing.T) {
 		// Generating Task To test≤
-		testedTaskID := generator.GenerateTaskAndGetID(t, getTaskForUpdate())
+		testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForUpdate())
 		taskUrl := getTaskUrl(boardId, listId, testedTaskID)
 		body := getTaskUpdateInvalidPoints()
 
@@ -386,7 +612,7 @@ func TestUpdateTaskEndpoint(t *testing.T) {
 
 	t.Run("Update existing task with empty title", func(t *testing.T) {
 		// Generating Task To test≤
-		testedTaskID := generator.GenerateTaskAndGetID(t, getTaskForUpdate())
+		testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForUpdate())
 		taskUrl := getTaskUrl(boardId, listId, testedTaskID)
 		body := getTaskUpdateInvalidTitle()
 
@@ -417,7 +643,7 @@ func TestUpdateTaskEndpoint(t *testing.T) {
 
 	t.Run("Update task with empty request body", func(t *testing.T) {
 		// Generating Task To test≤
-		testedTaskID := generator.GenerateTaskAndGetID(t, getTaskForUpdate())
+		testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForUpdate())
 		taskUrl := getTaskUrl(boardId, listId, testedTaskID)
 		req, _ := http.NewRequest("PATCH", taskUrl, bytes.NewReader(nil))
 		response := utils.ExecuteRequest(req)
@@ -427,8 +653,10 @@ func TestUpdateTaskEndpoint(t *testing.T) {
 }
 
 func TestDeleteTaskEndpoint(t *testing.T) {
+	boardId, listId := generateParents(t)
+
 	// Generating Task To test
-	testedTaskID := generator.GenerateTaskAndGetID(t, getTaskForDelete())
+	testedTaskID := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForDelete())
 
 	// Delete existing Resource with valid Object Id
 	t.Run("Delete Existing Task with Valid ObjectId", func(t *testing.T) {
@@ -474,3 +702,213 @@ func TestDeleteTaskEndpoint(t *testing.T) {
 		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
 	})
 }
+
+func TestMoveTaskEndpoint(t *testing.T) {
+	boardId, listId := generateParents(t)
+	otherListId := generator.GenerateListAndGetID(t, boardId, &models.List{Name: genOtherListName})
+
+	first := generator.GenerateTask(t, boardId, listId, getTaskForView())
+	second := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForView())
+	third := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForView())
+	other := generator.GenerateTaskAndGetID(t, boardId, otherListId, getTaskForView())
+
+	t.Run("New tasks are appended at the end of their list", func(t *testing.T) {
+		utils.AssertIntEqualsTo(t, first.Order, 1)
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, listId), []bson.ObjectId{first.TaskId, second, third})
+	})
+
+	t.Run("Reorder a task inside its list", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTaskMoveUrl(boardId, listId, third), bytes.NewReader(getTaskMove(listId, 1)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var task models.Task
+		if err := json.Unmarshal(response.Body.Bytes(), &task); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertIntEqualsTo(t, task.Order, 1)
+
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, listId), []bson.ObjectId{third, first.TaskId, second})
+	})
+
+	t.Run("Move a task to another list", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTaskMoveUrl(boardId, listId, first.TaskId), bytes.NewReader(getTaskMove(otherListId, 1)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+
+		var task models.Task
+		if err := json.Unmarshal(response.Body.Bytes(), &task); err != nil {
+			t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+		}
+		utils.AssertStringEqualsTo(t, task.ListId.Hex(), otherListId.Hex())
+
+		// Both source and target lists are renumbered
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, listId), []bson.ObjectId{third, second})
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, otherListId), []bson.ObjectId{first.TaskId, other})
+	})
+
+	t.Run("Updating a task read before its move keeps it moved", func(t *testing.T) {
+		// first was read from its former list, at its former order
+		stale := first
+		stale.Title = testingUpdatedTitle
+		if err := config.GetApp().Store.Tasks.Update(&stale); err != nil {
+			t.Fatalf("Could not update task, got error: %s", err.Error())
+		}
+
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, listId), []bson.ObjectId{third, second})
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, otherListId), []bson.ObjectId{first.TaskId, other})
+	})
+
+	t.Run("Move a task with out of range position appends it", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTaskMoveUrl(boardId, listId, third), bytes.NewReader(getTaskMove(listId, 42)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, listId), []bson.ObjectId{second, third})
+	})
+
+	t.Run("Deleting a task renumbers its list", func(t *testing.T) {
+		req, _ := http.NewRequest("DELETE", getTaskUrl(boardId, listId, second), nil)
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusOK)
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, listId), []bson.ObjectId{third})
+	})
+
+	t.Run("Move a task to a list of another board", func(t *testing.T) {
+		otherBoardId := generator.GenerateBoardAndGetID(t, &models.Board{Name: genBoardName})
+		foreignListId := generator.GenerateListAndGetID(t, otherBoardId, &models.List{Name: genListName})
+
+		req, _ := http.NewRequest("POST", getTaskMoveUrl(boardId, listId, third), bytes.NewReader(getTaskMove(foreignListId, 1)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+
+	t.Run("Move a task with invalid target list", func(t *testing.T) {
+		body := helpers.JsonEncode(map[string]interface{}{"listId": "0", "order": 1})
+		req, _ := http.NewRequest("POST", getTaskMoveUrl(boardId, listId, third), bytes.NewReader(body))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Move a task with negative position", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTaskMoveUrl(boardId, listId, third), bytes.NewReader(getTaskMove(listId, -1)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusBadRequest)
+	})
+
+	t.Run("Move a non existing task", func(t *testing.T) {
+		req, _ := http.NewRequest("POST", getTaskMoveUrl(boardId, listId, bson.NewObjectId()), bytes.NewReader(getTaskMove(listId, 1)))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+}
+
+func TestBulkTaskEndpoint(t *testing.T) {
+	boardId, listId := generateParents(t)
+	otherListId := generator.GenerateListAndGetID(t, boardId, &models.List{Name: genOtherListName})
+
+	first := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForView())
+	second := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForView())
+	third := generator.GenerateTaskAndGetID(t, boardId, listId, getTaskForView())
+	other := generator.GenerateTaskAndGetID(t, boardId, otherListId, getTaskForView())
+
+	t.Run("Set status of tasks across lists", func(t *testing.T) {
+		res := postBulk(t, boardId, map[string]interface{}{"taskIds": hexIDs(first, other, first), "operation": routes.BulkSetStatus, "status": true}, http.StatusOK)
+
+		utils.AssertBoolEqualsTo(t, res.Applied, true)
+		// A task given twice is handled once
+		utils.AssertIntEqualsTo(t, len(res.Results), 2)
+		for _, result := range res.Results {
+			utils.AssertIntEqualsTo(t, result.Status, http.StatusOK)
+			utils.AssertBoolEqualsTo(t, result.Task.Status, true)
+			utils.AssertNotEmpty(t, result.Task.UpdatedBy)
+		}
+		utils.AssertIntEqualsTo(t, getTaskPage(t, boardId, listId, "status=true").Total, 1)
+		utils.AssertIntEqualsTo(t, getTaskPage(t, boardId, otherListId, "status=true").Total, 1)
+	})
+
+	t.Run("Nothing is applied when a task is not valid", func(t *testing.T) {
+		res := postBulk(t, boardId, map[string]interface{}{"taskIds": hexIDs(first, second), "operation": routes.BulkSetPoints, "points": 150}, http.StatusBadRequest)
+
+		utils.AssertBoolEqualsTo(t, res.Applied, false)
+		utils.AssertIntEqualsTo(t, res.Results[0].Status, http.StatusBadRequest)
+		utils.AssertNotEmpty(t, res.Results[0].Error)
+		utils.AssertIntEqualsTo(t, getTaskPage(t, boardId, listId, "pointsMin=100").Total, 0)
+	})
+
+	t.Run("Nothing is applied when a task does not exist", func(t *testing.T) {
+		foreignBoardId, foreignListId := generateParents(t)
+		foreign := generator.GenerateTaskAndGetID(t, foreignBoardId, foreignListId, getTaskForView())
+
+		res := postBulk(t, boardId, map[string]interface{}{"taskIds": hexIDs(first, bson.NewObjectId(), foreign), "operation": routes.BulkSetPoints, "points": 3}, http.StatusBadRequest)
+
+		utils.AssertBoolEqualsTo(t, res.Applied, false)
+		utils.AssertIntEqualsTo(t, res.Results[0].Status, http.StatusFailedDependency)
+		utils.AssertIntEqualsTo(t, res.Results[1].Status, http.StatusNotFound)
+		utils.AssertIntEqualsTo(t, res.Results[2].Status, http.StatusNotFound)
+		utils.AssertIntEqualsTo(t, getTaskPage(t, boardId, listId, "pointsMax=3").Total, 0)
+	})
+
+	t.Run("Set points of tasks", func(t *testing.T) {
+		res := postBulk(t, boardId, map[string]interface{}{"taskIds": hexIDs(second, third), "operation": routes.BulkSetPoints, "points": 3}, http.StatusOK)
+
+		utils.AssertFloatEqualsTo(t, res.Results[1].Task.Points, 3)
+		utils.AssertIntEqualsTo(t, getTaskPage(t, boardId, listId, "pointsMax=3").Total, 2)
+	})
+
+	t.Run("Move tasks at the end of a list", func(t *testing.T) {
+		res := postBulk(t, boardId, map[string]interface{}{"taskIds": hexIDs(third, first), "operation": routes.BulkMove, "listId": otherListId.Hex()}, http.StatusOK)
+
+		utils.AssertStringEqualsTo(t, res.Results[0].Task.ListId.Hex(), otherListId.Hex())
+		utils.AssertIntEqualsTo(t, res.Results[1].Task.Order, 3)
+		// Both source and target lists are renumbered
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, listId), []bson.ObjectId{second})
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, otherListId), []bson.ObjectId{other, third, first})
+	})
+
+	t.Run("Delete tasks", func(t *testing.T) {
+		postBulk(t, boardId, map[string]interface{}{"taskIds": hexIDs(other, first), "operation": routes.BulkDelete}, http.StatusOK)
+
+		assertTaskIDsEqual(t, getListTaskIDs(t, boardId, otherListId), []bson.ObjectId{third})
+		res := postBulk(t, boardId, map[string]interface{}{"taskIds": hexIDs(other), "operation": routes.BulkDelete}, http.StatusBadRequest)
+		utils.AssertIntEqualsTo(t, res.Results[0].Status, http.StatusNotFound)
+	})
+
+	t.Run("Invalid bulk operations", func(t *testing.T) {
+		tooMany := make([]bson.ObjectId, 101)
+		for i := range tooMany {
+			tooMany[i] = bson.NewObjectId()
+		}
+		bodies := []map[string]interface{}{
+			{"taskIds": hexIDs(second), "operation": "archive"},
+			{"taskIds": hexIDs(), "operation": routes.BulkDelete},
+			{"taskIds": hexIDs(tooMany...), "operation": routes.BulkDelete},
+			{"taskIds": []string{"0"}, "operation": routes.BulkDelete},
+			{"taskIds": hexIDs(second), "operation": routes.BulkSetStatus},
+			{"taskIds": hexIDs(second), "operation": routes.BulkSetPoints},
+			{"taskIds": hexIDs(second), "operation": routes.BulkMove},
+		}
+		for _, body := range bodies {
+			req, _ := http.NewRequest("POST", fmt.Sprintf("/boards/%s/tasks/bulk", boardId.Hex()), bytes.NewReader(helpers.JsonEncode(body)))
+			response := utils.ExecuteRequest(req)
+			checkResponseCodeAndErrorMessage(t, response.Code, response.Body.Bytes())
+		}
+	})
+
+	t.Run("Move tasks to a list of another board", func(t *testing.T) {
+		_, foreignListId := generateParents(t)
+
+		req, _ := http.NewRequest("POST", fmt.Sprintf("/boards/%s/tasks/bulk", boardId.Hex()),
+			bytes.NewReader(helpers.JsonEncode(map[string]interface{}{"taskIds": hexIDs(second), "operation": routes.BulkMove, "listId": foreignListId.Hex()})))
+		response := utils.ExecuteRequest(req)
+
+		utils.CheckResponseCode(t, response.Code, http.StatusNotFound)
+	})
+}
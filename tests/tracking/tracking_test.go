@@ -0,0 +1,146 @@
+package tracking
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"github.com/AmFlint/taco-api-go/tests/utils/generator"
+	"github.com/AmFlint/taco-api-go/tests/utils/testconfig"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	testingBoardName = "Tracked board"
+	testingListName  = "Tracked list"
+	testingTaskTitle = "Tracked task"
+)
+
+func getBoardURL(boardID bson.ObjectId) string {
+	return fmt.Sprintf("/boards/%s/", boardID.Hex())
+}
+
+func getListURL(boardID, listID bson.ObjectId) string {
+	return fmt.Sprintf("%slists/%s/", getBoardURL(boardID), listID.Hex())
+}
+
+func getTaskURL(boardID, listID, taskID bson.ObjectId) string {
+	return fmt.Sprintf("%stasks/%s/", getListURL(boardID, listID), taskID.Hex())
+}
+
+// execute -> Execute request with given JSON body, authenticated with given token, decode the response into v
+func execute(t *testing.T, token, method, url string, body interface{}, expectedCode int, v interface{}) {
+	req, _ := http.NewRequest(method, url, bytes.NewReader(helpers.JsonEncode(body)))
+	req.Header.Set("Authorization", "Bearer "+token)
+	response := utils.ExecuteRequest(req)
+	utils.CheckResponseCode(t, response.Code, expectedCode)
+
+	if err := json.Unmarshal(response.Body.Bytes(), v); err != nil {
+		t.Errorf(utils.ERROR__UNMARSHAL_RESPONSE, err.Error())
+	}
+}
+
+// addEditor -> Register a new user and make it an editor of the board, return its ID and token
+func addEditor(t *testing.T, boardID bson.ObjectId) (bson.ObjectId, string) {
+	user, token := generator.GenerateUser(t)
+
+	body := map[string]interface{}{"email": user.Email, "role": models.RoleEditor}
+	req, _ := http.NewRequest("POST", getBoardURL(boardID)+"members/", bytes.NewReader(helpers.JsonEncode(body)))
+	utils.CheckResponseCode(t, utils.ExecuteRequest(req).Code, http.StatusCreated)
+
+	return user.UserId, token
+}
+
+// assertTracking -> Make sure an entity was created and last modified by given users, creation being recent
+func assertTracking(t *testing.T, tracking models.Tracking, createdBy, updatedBy bson.ObjectId) {
+	utils.AssertStringEqualsTo(t, tracking.CreatedBy.Hex(), createdBy.Hex())
+	utils.AssertStringEqualsTo(t, tracking.UpdatedBy.Hex(), updatedBy.Hex())
+	utils.AssertBoolEqualsTo(t, time.Since(tracking.CreatedAt) < time.Minute, true)
+	utils.AssertBoolEqualsTo(t, tracking.UpdatedAt.Before(tracking.CreatedAt), false)
+}
+
+/* ------------------------------------------
+   -------------- Test Suite ----------------
+   ------------------------------------------ */
+
+func TestMain(m *testing.M) {
+	testconfig.Init(m)
+}
+
+func TestTracking(t *testing.T) {
+	board := generator.GenerateBoard(t, &models.Board{Name: testingBoardName})
+	authorID, authorToken := addEditor(t, board.BoardId)
+	editorID, editorToken := addEditor(t, board.BoardId)
+
+	var list models.List
+	var task models.Task
+
+	t.Run("Boards record their creation", func(t *testing.T) {
+		utils.AssertNotEmpty(t, board.CreatedBy)
+		utils.AssertBoolEqualsTo(t, board.CreatedAt.Equal(board.UpdatedAt), true)
+	})
+
+	t.Run("Lists and tasks record their author", func(t *testing.T) {
+		execute(t, authorToken, "POST", getBoardURL(board.BoardId)+"lists/", map[string]interface{}{"name": testingListName}, http.StatusCreated, &list)
+		assertTracking(t, list.Tracking, authorID, authorID)
+
+		// Tracking fields sent by clients are ignored
+		body := map[string]interface{}{"title": testingTaskTitle, "createdAt": "2001-01-01T00:00:00Z", "createdBy": editorID}
+		execute(t, authorToken, "POST", getListURL(board.BoardId, list.ListId)+"tasks/", body, http.StatusCreated, &task)
+		assertTracking(t, task.Tracking, authorID, authorID)
+	})
+
+	t.Run("Updates record their author", func(t *testing.T) {
+		var updated models.Task
+		execute(t, editorToken, "PATCH", getTaskURL(board.BoardId, list.ListId, task.TaskId), map[string]interface{}{"points": 3}, http.StatusOK, &updated)
+
+		assertTracking(t, updated.Tracking, authorID, editorID)
+		utils.AssertBoolEqualsTo(t, updated.CreatedAt.Equal(task.CreatedAt), true)
+		utils.AssertBoolEqualsTo(t, updated.UpdatedAt.Before(task.UpdatedAt), false)
+	})
+
+	t.Run("Tracking is stored", func(t *testing.T) {
+		var stored models.Task
+		execute(t, authorToken, "GET", getTaskURL(board.BoardId, list.ListId, task.TaskId), nil, http.StatusOK, &stored)
+
+		assertTracking(t, stored.Tracking, authorID, editorID)
+		utils.AssertBoolEqualsTo(t, stored.CreatedAt.Equal(task.CreatedAt), true)
+
+		var response struct {
+			Lists []models.List `json:"lists"`
+		}
+		execute(t, authorToken, "GET", getBoardURL(board.BoardId)+"lists/?summary=true", nil, http.StatusOK, &response)
+		utils.AssertIntEqualsTo(t, len(response.Lists), 1)
+		assertTracking(t, response.Lists[0].Tracking, authorID, authorID)
+	})
+
+	t.Run("Moves record their author", func(t *testing.T) {
+		var moved, stored models.Task
+		execute(t, authorToken, "POST", getTaskURL(board.BoardId, list.ListId, task.TaskId)+"move", map[string]interface{}{"order": 1}, http.StatusOK, &moved)
+		assertTracking(t, moved.Tracking, authorID, authorID)
+		execute(t, authorToken, "GET", getTaskURL(board.BoardId, list.ListId, task.TaskId), nil, http.StatusOK, &stored)
+		assertTracking(t, stored.Tracking, authorID, authorID)
+
+		var target models.List
+		execute(t, authorToken, "POST", getBoardURL(board.BoardId)+"lists/", map[string]interface{}{"name": testingListName}, http.StatusCreated, &target)
+		body := map[string]interface{}{"taskIds": []bson.ObjectId{task.TaskId}, "operation": "move", "listId": target.ListId}
+		var bulk struct{}
+		execute(t, editorToken, "POST", getBoardURL(board.BoardId)+"tasks/bulk", body, http.StatusOK, &bulk)
+		execute(t, authorToken, "GET", getTaskURL(board.BoardId, target.ListId, task.TaskId), nil, http.StatusOK, &stored)
+		assertTracking(t, stored.Tracking, authorID, editorID)
+		utils.AssertBoolEqualsTo(t, stored.UpdatedAt.Before(moved.UpdatedAt), false)
+	})
+
+	t.Run("Labels record their author", func(t *testing.T) {
+		var label models.Label
+		execute(t, editorToken, "POST", getBoardURL(board.BoardId)+"labels/", map[string]interface{}{"name": "bug", "color": "#d73a4a"}, http.StatusCreated, &label)
+
+		assertTracking(t, label.Tracking, editorID, editorID)
+	})
+}
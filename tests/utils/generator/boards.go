@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	boardsURL = "/boards/"
+)
+
+// Generate a Board Entity in Database from a given Board Structure
+func GenerateBoard(t *testing.T, board *models.Board) models.Board {
+	// Request to API CREATE board endpoint
+	reqBoard := helpers.JsonEncode(board)
+	req, _ := http.NewRequest("POST", boardsURL, bytes.NewReader(reqBoard))
+	response := utils.ExecuteRequest(req)
+	// Manage response
+	utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+	var resBoard models.Board
+
+	if err := json.Unmarshal(response.Body.Bytes(), &resBoard); err != nil {
+		t.Error("Could not unmarshal Board Response Body from API Create endpoint")
+	}
+
+	log.Print("Board Created Properly!")
+	return resBoard
+}
+
+// GenerateBoardAndGetID = Helper to generate a Board entity and get its ObjectID
+func GenerateBoardAndGetID(t *testing.T, board *models.Board) bson.ObjectId {
+	boardCreated := GenerateBoard(t, board)
+	return boardCreated.BoardId
+}
@@ -13,19 +13,10 @@ import (
 	"fmt"
 )
 
-var (
-	boardID bson.ObjectId
-	listURL string
-)
-
-func init() {
-	boardsID = bson.NewObjectId()
-	listURL = fmt.Sprintf("/boards/%s/lists/", boardsID.Hex())
-}
-
-// Generate a Task Entity in Database from a given Task Structure
-func GenerateList(t *testing.T, list *models.List) models.List {
-	// Request to API CREATE task endpoint
+// Generate a List Entity in Database from a given List Structure, inside given Board
+func GenerateList(t *testing.T, boardID bson.ObjectId, list *models.List) models.List {
+	// Request to API CREATE list endpoint
+	listURL := fmt.Sprintf("/boards/%s/lists/", boardID.Hex())
 	reqList := helpers.JsonEncode(list)
 	req, _ := http.NewRequest("POST", listURL, bytes.NewReader(reqList))
 	response := utils.ExecuteRequest(req)
@@ -41,8 +32,8 @@ func GenerateList(t *testing.T, list *models.List) models.List {
 	return resList
 }
 
-// GenerateTaskAndGetID = Helper to generate a Task entity and get its ObjectID
-func GenerateListAndGetID(t *testing.T, list *models.List) bson.ObjectId {
-	listCreated := GenerateList(t, list)
+// GenerateListAndGetID = Helper to generate a List entity and get its ObjectID
+func GenerateListAndGetID(t *testing.T, boardID bson.ObjectId, list *models.List) bson.ObjectId {
+	listCreated := GenerateList(t, boardID, list)
 	return listCreated.ListId
-}
\ No newline at end of file
+}
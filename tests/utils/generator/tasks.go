@@ -13,22 +13,10 @@ import (
 	"log"
 )
 
-var (
-	boardsID, listID bson.ObjectId
-	taskURL string
-)
-
-func init() {
-	// Init board/list IDS
-	// TODO: Refactor boardsID and listID when it gets implemented
-	boardsID, listID = bson.NewObjectId(), bson.NewObjectId()
-	// Init task URL
-	taskURL = fmt.Sprintf("/boards/%s/lists/%s/tasks/", boardsID.Hex(), listID.Hex())
-}
-
-// Generate a Task Entity in Database from a given Task Structure
-func GenerateTask(t *testing.T, task *models.Task) models.Task {
+// Generate a Task Entity in Database from a given Task Structure, inside given Board's List
+func GenerateTask(t *testing.T, boardID, listID bson.ObjectId, task *models.Task) models.Task {
 	// Request to API CREATE task endpoint
+	taskURL := fmt.Sprintf("/boards/%s/lists/%s/tasks/", boardID.Hex(), listID.Hex())
 	reqTask := helpers.JsonEncode(task)
 	req, _ := http.NewRequest("POST", taskURL, bytes.NewReader(reqTask))
 	response := utils.ExecuteRequest(req)
@@ -45,7 +33,7 @@ func GenerateTask(t *testing.T, task *models.Task) models.Task {
 }
 
 // GenerateTaskAndGetID = Helper to generate a Task entity and get its ObjectID
-func GenerateTaskAndGetID(t *testing.T, task *models.Task) bson.ObjectId {
-	taskCreated := GenerateTask(t, task)
+func GenerateTaskAndGetID(t *testing.T, boardID, listID bson.ObjectId, task *models.Task) bson.ObjectId {
+	taskCreated := GenerateTask(t, boardID, listID, task)
 	return taskCreated.TaskId
 }
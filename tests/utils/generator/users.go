@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"testing"
+
+	"github.com/AmFlint/taco-api-go/helpers"
+	"github.com/AmFlint/taco-api-go/models"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	registerURL           = "/auth/register"
+	generatedUserPassword = "generated-password"
+)
+
+// Register a new User with a unique email, return it along with a bearer token to act as this user
+func GenerateUser(t *testing.T) (models.User, string) {
+	registration := map[string]interface{}{
+		"email":    fmt.Sprintf("user-%s@taco.test", bson.NewObjectId().Hex()),
+		"name":     "Generated user",
+		"password": generatedUserPassword,
+	}
+	req, _ := http.NewRequest("POST", registerURL, bytes.NewReader(helpers.JsonEncode(registration)))
+	response := utils.ExecuteAnonymousRequest(req)
+	// Manage response
+	utils.CheckResponseCode(t, response.Code, http.StatusCreated)
+	var res struct {
+		Token string      `json:"token"`
+		User  models.User `json:"user"`
+	}
+
+	if err := json.Unmarshal(response.Body.Bytes(), &res); err != nil {
+		t.Error("Could not unmarshal Registration Response Body from API Register endpoint")
+	}
+
+	log.Print("User Registered Properly!")
+	return res.User, res.Token
+}
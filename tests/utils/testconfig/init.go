@@ -1,9 +1,15 @@
 package testconfig
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"github.com/AmFlint/taco-api-go/config"
-	"github.com/AmFlint/taco-api-go/helpers"
 	"github.com/AmFlint/taco-api-go/config/database"
+	"github.com/AmFlint/taco-api-go/dao/sqlstore"
+	"github.com/AmFlint/taco-api-go/tests/utils"
+	"gopkg.in/mgo.v2/bson"
+	"net/http"
 	"os"
 	"testing"
 )
@@ -11,35 +17,59 @@ import (
 var app config.App
 
 func clearDatabase() {
-	// Retrieve Database Connection from main.Application Session
-	db := database.GetDatabaseConnection()
-	// Clear Database Content
-	db.DropDatabase()
+	switch app.Storage.Backend {
+	case config.StorageMongo:
+		// Retrieve Database Connection from main.Application Session
+		db := database.GetDatabaseConnection()
+		// Clear Database Content
+		db.DropDatabase()
+	case config.StorageSQL:
+		// Keep the schema, remove every row
+		sqlstore.Clear(database.GetSQLConnection())
+	}
+	// In-memory storage starts empty with every test program
+}
+
+// Register a user for this test program, and authenticate every following request (utils.ExecuteRequest) as this user
+func authenticate() {
+	body := fmt.Sprintf(`{"email": "tests-%s@taco.test", "name": "Tests", "password": "test-password"}`, bson.NewObjectId().Hex())
+	req, _ := http.NewRequest("POST", "/auth/register", bytes.NewBufferString(body))
+	response := utils.ExecuteAnonymousRequest(req)
+	if response.Code != http.StatusCreated {
+		panic(fmt.Sprintf("Could not register test user, got response %d: %s", response.Code, response.Body.String()))
+	}
+
+	var registered struct {
+		Token string `json:"token"`
+	}
+	json.Unmarshal(response.Body.Bytes(), &registered)
+	utils.SetToken(registered.Token)
 }
 
 func Init(m *testing.M) {
 	// Create main.Application Struct
-	// Initialize main.Application with database configuration
-	if app == (config.App{}) {
-		app = config.NewApp(
-			helpers.GetEnv("APP_USERNAME", ""),
-			helpers.GetEnv("APP_PASSWORD", ""),
-			helpers.GetEnv("APP_DB_NAME", "taco"),
-			helpers.GetEnv("APP_DB_HOST", "localhost"),
-			helpers.GetEnv("APP_DB_PORT", "27017"))
+	// Initialize main.Application with storage configuration (APP_STORAGE=memory runs without any database)
+	if app.Router == nil {
+		app = config.NewApp(config.StorageConfigFromEnv(), config.AuthConfigFromEnv())
+		// Bring storage schema up to date (e.g. create SQL tables)
+		if err := app.Migrate(); err != nil {
+			panic(err)
+		}
 	}
 	
 	// Clear database collections
 	clearDatabase()
 
+	authenticate()
+
 	// Run following tests
 	code := m.Run()
 
 	// Clear Database after use
 	clearDatabase()
 
-	defer database.CloseSession()
+	app.Close()
 
 	// Exit testing program with runtime exit code
 	os.Exit(code)
-}
\ No newline at end of file
+}
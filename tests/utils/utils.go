@@ -10,8 +10,24 @@ import (
 	"fmt"
 )
 
-// Execute Http Request
+// Bearer token of the user running the tests, attached to every request sent with ExecuteRequest
+var token string
+
+// Set bearer token attached to requests sent with ExecuteRequest
+func SetToken(t string) {
+	token = t
+}
+
+// Execute Http Request as the authenticated test user, unless request already carries an Authorization header
 func ExecuteRequest(req *http.Request) *httptest.ResponseRecorder {
+	if req.Header.Get("Authorization") == "" && token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return ExecuteAnonymousRequest(req)
+}
+
+// Execute Http Request without authenticating it
+func ExecuteAnonymousRequest(req *http.Request) *httptest.ResponseRecorder {
 	a := config.GetApp()
 	rr := httptest.NewRecorder()
 	a.Router.ServeHTTP(rr, req)